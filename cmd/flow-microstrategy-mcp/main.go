@@ -13,6 +13,7 @@ import (
 	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/database"
 	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/logger"
 	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/server"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools/mstr"
 	"github.com/neo4j/neo4j-go-driver/v6/neo4j"
 )
 
@@ -54,6 +55,12 @@ func main() {
 	// Initialize global logger
 	logger.Init(cfg.LogLevel, cfg.LogFormat, os.Stderr)
 
+	// Creating the search index is a schema write
+	if cliArgs.CreateSearchIndex && cfg.ReadOnly {
+		slog.Error("--create-search-index is not available in read-only mode, unset FLOW_READ_ONLY to create the search index")
+		os.Exit(1)
+	}
+
 	// Initialize Neo4j driver
 	// For STDIO mode: use environment credentials
 	// For HTTP mode with API token: use environment credentials (server-side auth)
@@ -84,6 +91,20 @@ func main() {
 		return
 	}
 
+	// One-off maintenance command: create the full-text search index and exit
+	if cliArgs.CreateSearchIndex {
+		if err := mstr.CreateSearchIndex(ctx, dbService); err != nil {
+			slog.Error("Failed to create search index", "error", err)
+			// os.Exit skips the deferred close
+			if err := driver.Close(ctx); err != nil {
+				slog.Error("Error closing driver", "error", err)
+			}
+			os.Exit(1)
+		}
+		slog.Info("Full-text search index created, Neo4j populates it in the background", "index", mstr.SearchIndexName)
+		return
+	}
+
 	anService := analytics.NewAnalytics(MixPanelToken, MixPanelEndpoint, cfg.URI)
 
 	// Enable telemetry only when user has opted in AND Version is different from "development", which is changed via ldflags at build time.
@@ -99,6 +120,9 @@ func main() {
 	// Create and configure the MCP server
 	mcpServer := server.NewNeo4jMCPServer(Version, cfg, dbService, anService)
 
+	// Forward logs to the clients as MCP notifications/message
+	slog.SetDefault(slog.New(mcpServer.LogHandler(slog.Default().Handler())))
+
 	// Start the server - this blocks until shutdown for both stdio and HTTP modes
 	if err := mcpServer.Start(); err != nil {
 		slog.Error("Server error", "error", err)
@@ -0,0 +1,9 @@
+// Package docs embeds the documentation assets served by the MCP server.
+package docs
+
+import "embed"
+
+// V1Prompts holds the migration workflow prompts (v1-prompts/*.md) registered as MCP prompts
+//
+//go:embed v1-prompts/*.md
+var V1Prompts embed.FS
@@ -215,6 +215,20 @@ func TestEventCreation(t *testing.T) {
 		// Note: Neo4j connection info (version, edition, cypher version) is sent separately in CONNECTION_INITIALIZED event
 	})
 
+	t.Run("NewToolCancelledEvent", func(t *testing.T) {
+		event := analyticsService.NewToolCancelledEvent("trace-metric")
+		if event.Event != "MCP4NEO4J_TOOL_CANCELLED" {
+			t.Errorf("unexpected event name: got %s, want %s", event.Event, "MCP4NEO4J_TOOL_CANCELLED")
+		}
+		props := assertBaseProperties(t, event.Properties)
+		if props["tools_used"] != "trace-metric" {
+			t.Errorf("unexpected tools_used: got %v, want %v", props["tools_used"], "trace-metric")
+		}
+		if _, ok := props["success"]; ok {
+			t.Errorf("unexpected success property in cancelled event")
+		}
+	})
+
 	t.Run("NewStartupEvent", func(t *testing.T) {
 		event := analyticsService.NewStartupEvent(config.TransportModeStdio, false, "1.0.0")
 		if event.Event != "MCP4NEO4J_MCP_STARTUP" {
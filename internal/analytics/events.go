@@ -50,6 +50,12 @@ type toolProperties struct {
 	Success  bool   `json:"success"`
 }
 
+// toolCancelledProperties contains the properties of a tool call cancelled by the client (not a failure)
+type toolCancelledProperties struct {
+	baseProperties
+	ToolUsed string `json:"tools_used"`
+}
+
 type TrackEvent struct {
 	Event      string      `json:"event"`
 	Properties interface{} `json:"properties"`
@@ -121,6 +127,17 @@ func (a *Analytics) NewToolEvent(toolsUsed string, success bool) TrackEvent {
 	}
 }
 
+// NewToolCancelledEvent creates an event for a tool call cancelled by the client or by a client disconnect
+func (a *Analytics) NewToolCancelledEvent(toolsUsed string) TrackEvent {
+	return TrackEvent{
+		Event: strings.Join([]string{eventNamePrefix, "TOOL_CANCELLED"}, "_"),
+		Properties: toolCancelledProperties{
+			baseProperties: a.getBaseProperties(),
+			ToolUsed:       toolsUsed,
+		},
+	}
+}
+
 func (a *Analytics) getBaseProperties() baseProperties {
 	uptime := time.Now().Unix() - a.cfg.startupTime
 	insertID := a.newInsertID()
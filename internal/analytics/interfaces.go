@@ -19,6 +19,7 @@ type Service interface {
 	NewStartupEvent(transportMode config.TransportMode, tlsEnabled bool, mcpServer string) TrackEvent
 	NewConnectionInitializedEvent(connInfo ConnectionEventInfo) TrackEvent
 	NewToolEvent(toolsUsed string, success bool) TrackEvent
+	NewToolCancelledEvent(toolsUsed string) TrackEvent
 }
 
 // dummy http client interface for our testing purposes
@@ -341,6 +341,44 @@ func (c *MockServiceNewStartupEventCall) DoAndReturn(f func(config.TransportMode
 	return c
 }
 
+// NewToolCancelledEvent mocks base method.
+func (m *MockService) NewToolCancelledEvent(toolsUsed string) analytics.TrackEvent {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewToolCancelledEvent", toolsUsed)
+	ret0, _ := ret[0].(analytics.TrackEvent)
+	return ret0
+}
+
+// NewToolCancelledEvent indicates an expected call of NewToolCancelledEvent.
+func (mr *MockServiceMockRecorder) NewToolCancelledEvent(toolsUsed any) *MockServiceNewToolCancelledEventCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewToolCancelledEvent", reflect.TypeOf((*MockService)(nil).NewToolCancelledEvent), toolsUsed)
+	return &MockServiceNewToolCancelledEventCall{Call: call}
+}
+
+// MockServiceNewToolCancelledEventCall wrap *gomock.Call
+type MockServiceNewToolCancelledEventCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceNewToolCancelledEventCall) Return(arg0 analytics.TrackEvent) *MockServiceNewToolCancelledEventCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceNewToolCancelledEventCall) Do(f func(string) analytics.TrackEvent) *MockServiceNewToolCancelledEventCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceNewToolCancelledEventCall) DoAndReturn(f func(string) analytics.TrackEvent) *MockServiceNewToolCancelledEventCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // NewToolEvent mocks base method.
 func (m *MockService) NewToolEvent(toolsUsed string, success bool) analytics.TrackEvent {
 	m.ctrl.T.Helper()
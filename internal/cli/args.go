@@ -35,6 +35,7 @@ Options:
   --flow-http-tls-enabled <BOOLEAN>   Enable TLS/HTTPS for HTTP server: true or false (overrides environment variable FLOW_MCP_HTTP_TLS_ENABLED)
   --flow-http-tls-cert-file <PATH>    Path to TLS certificate file (overrides environment variable FLOW_MCP_HTTP_TLS_CERT_FILE)
   --flow-http-tls-key-file <PATH>     Path to TLS private key file (overrides environment variable FLOW_MCP_HTTP_TLS_KEY_FILE)
+  --create-search-index               Create the full-text index used for ranked search, then exit
 
 Required Environment Variables:
   FLOW_URI        Neo4j database URI
@@ -61,6 +62,9 @@ Examples:
   # Using CLI flags (takes precedence over environment variables)
   flow-microstrategy-mcp --flow-uri bolt://localhost:7687 --flow-username neo4j --flow-password password
 
+  # Create the full-text search index (requires a user allowed to create indexes)
+  flow-microstrategy-mcp --create-search-index
+
 For more information, visit: https://github.com/brunogc-cit/flow-microstrategy-mcp
 `
 
@@ -80,6 +84,7 @@ type Args struct {
 	HTTPTLSEnabled     string
 	HTTPTLSCertFile    string
 	HTTPTLSKeyFile     string
+	CreateSearchIndex  bool
 }
 
 // this is a list of known configuration flags to be skipped in HandleArgs
@@ -101,6 +106,11 @@ var argsSlice = []string{
 	"--flow-http-tls-key-file",
 }
 
+// boolArgsSlice is a list of known boolean flags (without value) to be skipped in HandleArgs
+var boolArgsSlice = []string{
+	"--create-search-index",
+}
+
 // ParseConfigFlags parses CLI flags and returns configuration values.
 // It should be called after HandleArgs to ensure help/version flags are processed first.
 func ParseConfigFlags() *Args {
@@ -118,6 +128,7 @@ func ParseConfigFlags() *Args {
 	flowHTTPTLSEnabled := flag.String("flow-http-tls-enabled", "", "Enable TLS/HTTPS for HTTP server: true or false (overrides FLOW_MCP_HTTP_TLS_ENABLED env var)")
 	flowHTTPTLSCertFile := flag.String("flow-http-tls-cert-file", "", "Path to TLS certificate file (overrides FLOW_MCP_HTTP_TLS_CERT_FILE env var)")
 	flowHTTPTLSKeyFile := flag.String("flow-http-tls-key-file", "", "Path to TLS private key file (overrides FLOW_MCP_HTTP_TLS_KEY_FILE env var)")
+	createSearchIndex := flag.Bool("create-search-index", false, "Create the full-text index used for ranked search, then exit")
 
 	flag.Parse()
 
@@ -136,6 +147,7 @@ func ParseConfigFlags() *Args {
 		HTTPTLSEnabled:     *flowHTTPTLSEnabled,
 		HTTPTLSCertFile:    *flowHTTPTLSCertFile,
 		HTTPTLSKeyFile:     *flowHTTPTLSKeyFile,
+		CreateSearchIndex:  *createSearchIndex,
 	}
 }
 
@@ -172,6 +184,11 @@ func HandleArgs(version string) {
 			i += 2
 			continue
 		}
+		// Boolean configuration flags take no value
+		if slices.Contains(boolArgsSlice, arg) {
+			i++
+			continue
+		}
 
 		switch arg {
 		case "-h", "--help":
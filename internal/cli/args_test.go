@@ -246,6 +246,18 @@ func TestHandleArgs(t *testing.T) {
 			expectedExitCode: 1,
 			expectedStderr:   "--flow-http-tls-key-file requires a value",
 		},
+		{
+			name:             "create search index flag without value",
+			args:             []string{testProgramName, "--create-search-index"},
+			version:          testVersion,
+			expectedExitCode: -1, // Should not exit, flag is allowed
+		},
+		{
+			name:             "create search index flag with other flags",
+			args:             []string{testProgramName, "--create-search-index", "--flow-uri", "bolt://localhost:7687"},
+			version:          testVersion,
+			expectedExitCode: -1, // Should not exit, flag is allowed
+		},
 		{
 			name:             "http allowed origins flag with valid value",
 			args:             []string{testProgramName, "--flow-http-allowed-origins", "https://example.com"},
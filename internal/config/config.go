@@ -7,6 +7,8 @@ import (
 	"os"
 	"slices"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/logger"
 )
@@ -20,6 +22,12 @@ const (
 	TransportModeHTTP       TransportMode = "http"
 )
 
+// DefaultResponseMaxTokens is the default approximate token budget of an MSTR tool response
+const DefaultResponseMaxTokens = 25000
+
+// ToolLimits holds per-tool values, by tool name
+type ToolLimits map[string]int
+
 // ValidTransportModes defines the allowed transport mode values
 var ValidTransportModes = []TransportMode{TransportModeStdio, TransportModeHTTP}
 
@@ -44,6 +52,19 @@ type Config struct {
 	HTTPTLSKeyFile     string        // Path to TLS private key file (required if HTTPTLSEnabled is true)
 	APIToken           string        // Fixed API token for HTTP mode authentication (optional, enables server-side Neo4j credentials)
 	MCPVersion         string        // MCP version string
+	MSTRScope          string        // Default project/location scope of the MSTR tools (optional, e.g. "Retail" or "Retail/Public Objects")
+	PromptsDir         string        // Directory of the workflow prompts served as MCP prompts (optional, defaults to the embedded docs/v1-prompts)
+	CapabilityRefresh  time.Duration // Interval of the re-detection of APOC, GDS and the search index (optional, 0 disables it)
+	ResponseMaxTokens  int           // Approximate token budget of an MSTR tool response (0 disables truncation)
+	ToolMaxTokens      ToolLimits    // Per-tool overrides of ResponseMaxTokens (optional)
+}
+
+// ResponseBudget returns the approximate token budget of the responses of a tool (0: unlimited)
+func (c *Config) ResponseBudget(tool string) int {
+	if budget, ok := c.ToolMaxTokens[tool]; ok {
+		return budget
+	}
+	return c.ResponseMaxTokens
 }
 
 // Validate validates the configuration and returns an error if invalid
@@ -167,6 +188,11 @@ func LoadConfig(cliOverrides *CLIOverrides) (*Config, error) {
 		HTTPTLSCertFile:    GetEnv("FLOW_MCP_HTTP_TLS_CERT_FILE"),
 		HTTPTLSKeyFile:     GetEnv("FLOW_MCP_HTTP_TLS_KEY_FILE"),
 		APIToken:           GetEnv("FLOW_API_TOKEN"),
+		MSTRScope:          GetEnv("FLOW_MSTR_SCOPE"),
+		PromptsDir:         GetEnv("FLOW_PROMPTS_DIR"),
+		CapabilityRefresh:  ParseDuration(GetEnv("FLOW_CAPABILITY_REFRESH_INTERVAL"), 0),
+		ResponseMaxTokens:  ParseNonNegativeInt(GetEnv("FLOW_RESPONSE_MAX_TOKENS"), DefaultResponseMaxTokens),
+		ToolMaxTokens:      ParseToolLimits(GetEnv("FLOW_TOOL_RESPONSE_MAX_TOKENS")),
 	}
 
 	// Apply CLI overrides if provided
@@ -282,3 +308,51 @@ func ParseInt32(value string, defaultValue int32) int32 {
 	}
 	return int32(parsed)
 }
+
+// ParseDuration parses a string to a non-negative time.Duration (e.g. "10m").
+// Returns the default value if the string is empty or invalid.
+func ParseDuration(value string, defaultValue time.Duration) time.Duration {
+	if value == "" {
+		return defaultValue
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil || parsed < 0 {
+		log.Printf("Warning: Invalid duration value %q, using default: %v", value, defaultValue)
+		return defaultValue
+	}
+	return parsed
+}
+
+// ParseNonNegativeInt parses a string to a non-negative int.
+// Returns the default value if the string is empty or invalid.
+func ParseNonNegativeInt(value string, defaultValue int) int {
+	if value == "" {
+		return defaultValue
+	}
+	parsed, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || parsed < 0 {
+		log.Printf("Warning: Invalid non-negative integer value %q, using default: %v", value, defaultValue)
+		return defaultValue
+	}
+	return parsed
+}
+
+// ParseToolLimits parses a comma-separated list of tool=value pairs (e.g. "trace-metric=10000,trace-columns=0").
+// Invalid pairs are skipped with a warning. Returns nil if the string is empty.
+func ParseToolLimits(value string) ToolLimits {
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+	limits := make(ToolLimits)
+	for _, pair := range strings.Split(value, ",") {
+		tool, limit, found := strings.Cut(pair, "=")
+		tool = strings.TrimSpace(tool)
+		parsed, err := strconv.Atoi(strings.TrimSpace(limit))
+		if !found || tool == "" || err != nil || parsed < 0 {
+			log.Printf("Warning: Invalid tool limit %q, expected tool=value with a non-negative value", pair)
+			continue
+		}
+		limits[tool] = parsed
+	}
+	return limits
+}
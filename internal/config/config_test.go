@@ -3,6 +3,7 @@ package config
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/testutil"
 )
@@ -729,3 +730,129 @@ func TestLoadConfig_HTTPAllowedOrigins(t *testing.T) {
 		}
 	})
 }
+
+func TestLoadConfig_MSTRScope(t *testing.T) {
+	t.Setenv("FLOW_MCP_TRANSPORT", "stdio")
+	t.Setenv("FLOW_URI", "bolt://localhost:7687")
+	t.Setenv("FLOW_USERNAME", "testuser")
+	t.Setenv("FLOW_PASSWORD", "testpass")
+
+	t.Run("no default scope", func(t *testing.T) {
+		t.Setenv("FLOW_MSTR_SCOPE", "")
+
+		cfg, err := LoadConfig(nil)
+		if err != nil {
+			t.Fatalf("LoadConfig() unexpected error: %v", err)
+		}
+
+		if cfg.MSTRScope != "" {
+			t.Errorf("LoadConfig() MSTRScope = %q, want empty", cfg.MSTRScope)
+		}
+	})
+
+	t.Run("value from env", func(t *testing.T) {
+		t.Setenv("FLOW_MSTR_SCOPE", "Retail/Public Objects")
+
+		cfg, err := LoadConfig(nil)
+		if err != nil {
+			t.Fatalf("LoadConfig() unexpected error: %v", err)
+		}
+
+		if cfg.MSTRScope != "Retail/Public Objects" {
+			t.Errorf("LoadConfig() MSTRScope = %q, want %q", cfg.MSTRScope, "Retail/Public Objects")
+		}
+	})
+}
+
+func TestLoadConfig_PromptsDir(t *testing.T) {
+	t.Setenv("FLOW_MCP_TRANSPORT", "stdio")
+	t.Setenv("FLOW_URI", "bolt://localhost:7687")
+	t.Setenv("FLOW_USERNAME", "testuser")
+	t.Setenv("FLOW_PASSWORD", "testpass")
+	t.Setenv("FLOW_PROMPTS_DIR", "/etc/flow/prompts")
+
+	cfg, err := LoadConfig(nil)
+	if err != nil {
+		t.Fatalf("LoadConfig() unexpected error: %v", err)
+	}
+
+	if cfg.PromptsDir != "/etc/flow/prompts" {
+		t.Errorf("LoadConfig() PromptsDir = %q, want %q", cfg.PromptsDir, "/etc/flow/prompts")
+	}
+}
+
+func TestLoadConfig_CapabilityRefresh(t *testing.T) {
+	t.Setenv("FLOW_MCP_TRANSPORT", "stdio")
+	t.Setenv("FLOW_URI", "bolt://localhost:7687")
+	t.Setenv("FLOW_USERNAME", "testuser")
+	t.Setenv("FLOW_PASSWORD", "testpass")
+
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{value: "", want: 0},
+		{value: "10m", want: 10 * time.Minute},
+		{value: "often", want: 0},
+		{value: "-1m", want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Setenv("FLOW_CAPABILITY_REFRESH_INTERVAL", tt.value)
+
+			cfg, err := LoadConfig(nil)
+			if err != nil {
+				t.Fatalf("LoadConfig() unexpected error: %v", err)
+			}
+
+			if cfg.CapabilityRefresh != tt.want {
+				t.Errorf("LoadConfig() CapabilityRefresh = %v, want %v", cfg.CapabilityRefresh, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_ResponseBudget(t *testing.T) {
+	t.Setenv("FLOW_MCP_TRANSPORT", "stdio")
+	t.Setenv("FLOW_URI", "bolt://localhost:7687")
+	t.Setenv("FLOW_USERNAME", "testuser")
+	t.Setenv("FLOW_PASSWORD", "testpass")
+
+	t.Run("defaults", func(t *testing.T) {
+		cfg, err := LoadConfig(nil)
+		if err != nil {
+			t.Fatalf("LoadConfig() unexpected error: %v", err)
+		}
+		if got := cfg.ResponseBudget("trace-metric"); got != DefaultResponseMaxTokens {
+			t.Errorf("ResponseBudget() = %v, want %v", got, DefaultResponseMaxTokens)
+		}
+	})
+
+	t.Run("global and per-tool budgets", func(t *testing.T) {
+		t.Setenv("FLOW_RESPONSE_MAX_TOKENS", "8000")
+		t.Setenv("FLOW_TOOL_RESPONSE_MAX_TOKENS", "trace-metric=2000, trace-columns=0,bad,search-metrics=-5")
+
+		cfg, err := LoadConfig(nil)
+		if err != nil {
+			t.Fatalf("LoadConfig() unexpected error: %v", err)
+		}
+		want := map[string]int{"trace-metric": 2000, "trace-columns": 0, "search-metrics": 8000, "list-projects": 8000}
+		for tool, budget := range want {
+			if got := cfg.ResponseBudget(tool); got != budget {
+				t.Errorf("ResponseBudget(%q) = %v, want %v", tool, got, budget)
+			}
+		}
+	})
+
+	t.Run("invalid global budget", func(t *testing.T) {
+		t.Setenv("FLOW_RESPONSE_MAX_TOKENS", "lots")
+
+		cfg, err := LoadConfig(nil)
+		if err != nil {
+			t.Fatalf("LoadConfig() unexpected error: %v", err)
+		}
+		if cfg.ResponseMaxTokens != DefaultResponseMaxTokens {
+			t.Errorf("LoadConfig() ResponseMaxTokens = %v, want %v", cfg.ResponseMaxTokens, DefaultResponseMaxTokens)
+		}
+	})
+}
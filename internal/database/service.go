@@ -74,7 +74,7 @@ func (s *Neo4jService) VerifyConnectivity(ctx context.Context) error {
 		authToken := s.getHTTPAuthToken(ctx)
 		if authToken != nil {
 			if err := s.driver.VerifyAuthentication(ctx, authToken); err != nil {
-				slog.Error("Failed to verify database connectivity", "error", err.Error())
+				slog.ErrorContext(ctx, "Failed to verify database connectivity", "error", err.Error())
 				return err
 			}
 			return nil
@@ -82,7 +82,7 @@ func (s *Neo4jService) VerifyConnectivity(ctx context.Context) error {
 	}
 	// For STDIO mode or API token auth, use driver's built-in connectivity check
 	if err := s.driver.VerifyConnectivity(ctx); err != nil {
-		slog.Error("Failed to verify database connectivity", "error", err.Error())
+		slog.ErrorContext(ctx, "Failed to verify database connectivity", "error", err.Error())
 		return err
 	}
 	return nil
@@ -107,8 +107,11 @@ func (s *Neo4jService) ExecuteReadQuery(ctx context.Context, cypher string, para
 
 	res, err := neo4j.ExecuteQuery(ctx, s.driver, cypher, params, neo4j.EagerResultTransformer, queryOptions...)
 	if err != nil {
+		if ctxErr := queryCancelled(ctx, "ExecuteReadQuery"); ctxErr != nil {
+			return nil, ctxErr
+		}
 		wrappedErr := fmt.Errorf("failed to execute read query: %w", err)
-		slog.Error("Error in ExecuteReadQuery", "error", wrappedErr)
+		slog.ErrorContext(ctx, "Error in ExecuteReadQuery", "error", wrappedErr)
 
 		return nil, wrappedErr
 	}
@@ -122,8 +125,11 @@ func (s *Neo4jService) ExecuteWriteQuery(ctx context.Context, cypher string, par
 
 	res, err := neo4j.ExecuteQuery(ctx, s.driver, cypher, params, neo4j.EagerResultTransformer, queryOptions...)
 	if err != nil {
+		if ctxErr := queryCancelled(ctx, "ExecuteWriteQuery"); ctxErr != nil {
+			return nil, ctxErr
+		}
 		wrappedErr := fmt.Errorf("failed to execute write query: %w", err)
-		slog.Error("Error in ExecuteWriteQuery", "error", wrappedErr)
+		slog.ErrorContext(ctx, "Error in ExecuteWriteQuery", "error", wrappedErr)
 		return nil, wrappedErr
 	}
 
@@ -139,14 +145,17 @@ func (s *Neo4jService) GetQueryType(ctx context.Context, cypher string, params m
 
 	res, err := neo4j.ExecuteQuery(ctx, s.driver, explainedQuery, params, neo4j.EagerResultTransformer, queryOptions...)
 	if err != nil {
+		if ctxErr := queryCancelled(ctx, "GetQueryType"); ctxErr != nil {
+			return neo4j.QueryTypeUnknown, ctxErr
+		}
 		wrappedErr := fmt.Errorf("error during GetQueryType: %w", err)
-		slog.Error("Error during GetQueryType", "error", wrappedErr)
+		slog.ErrorContext(ctx, "Error during GetQueryType", "error", wrappedErr)
 		return neo4j.QueryTypeUnknown, wrappedErr
 	}
 
 	if res.Summary == nil {
 		err := fmt.Errorf("error during GetQueryType: no summary returned for explained query")
-		slog.Error("Error during GetQueryType", "error", err)
+		slog.ErrorContext(ctx, "Error during GetQueryType", "error", err)
 		return neo4j.QueryTypeUnknown, err
 	}
 
@@ -154,6 +163,18 @@ func (s *Neo4jService) GetQueryType(ctx context.Context, cypher string, params m
 
 }
 
+// queryCancelled returns an error when the context of a query was cancelled (client cancellation or
+// disconnect). The driver then closes the connection, which terminates the transaction on the server.
+// Cancellations are logged at info level: they are not database failures.
+func queryCancelled(ctx context.Context, operation string) error {
+	if ctx.Err() == nil {
+		return nil
+	}
+	cause := context.Cause(ctx)
+	slog.InfoContext(ctx, "Query cancelled", "operation", operation, "reason", cause)
+	return fmt.Errorf("query cancelled: %w", cause)
+}
+
 // Neo4jRecordsToJSON converts Neo4j records to JSON string
 func (s *Neo4jService) Neo4jRecordsToJSON(records []*neo4j.Record) (string, error) {
 	results := make([]map[string]any, 0)
@@ -227,3 +227,42 @@ func TestDatabaseService_ExecuteWriteQuery(t *testing.T) {
 		}
 	})
 }
+
+func TestDatabaseService_CancelledQuery(t *testing.T) {
+	// The driver is never reached: the query context is already cancelled
+	driver, err := neo4j.NewDriver("bolt://127.0.0.1:1", neo4j.NoAuth())
+	if err != nil {
+		t.Fatalf("failed to create driver: %v", err)
+	}
+	defer func() { _ = driver.Close(context.Background()) }()
+
+	service, err := database.NewNeo4jService(driver, "neo4j", config.TransportModeStdio, "test-version")
+	if err != nil {
+		t.Fatalf("failed to create service: %v", err)
+	}
+
+	cause := errors.New("cancelled by the client")
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cancel(cause)
+
+	t.Run("read query", func(t *testing.T) {
+		_, err := service.ExecuteReadQuery(ctx, "RETURN 1", nil)
+		if !errors.Is(err, cause) {
+			t.Errorf("expected the cancellation cause, got: %v", err)
+		}
+	})
+
+	t.Run("write query", func(t *testing.T) {
+		_, err := service.ExecuteWriteQuery(ctx, "CREATE (n)", nil)
+		if !errors.Is(err, cause) {
+			t.Errorf("expected the cancellation cause, got: %v", err)
+		}
+	})
+
+	t.Run("query type", func(t *testing.T) {
+		_, err := service.GetQueryType(ctx, "RETURN 1", nil)
+		if !errors.Is(err, cause) {
+			t.Errorf("expected the cancellation cause, got: %v", err)
+		}
+	})
+}
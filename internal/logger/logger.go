@@ -44,7 +44,7 @@ var ValidLogFormats = []string{"text", "json"}
 
 // SetLevel changes the logging level for this Service instance.
 func (s *Service) SetLevel(level string) {
-	s.level.Set(parseLevel(level))
+	s.level.Set(ParseLevel(level))
 }
 
 // Init initializes the global logger for Phase 1 (stdio mode).
@@ -73,7 +73,7 @@ func SetLevel(level string) {
 // Returns a configured *Service instance with the specified logging behavior.
 func New(level, format string, writer io.Writer) *Service {
 	levelVar := &slog.LevelVar{}
-	levelVar.Set(parseLevel(level))
+	levelVar.Set(ParseLevel(level))
 
 	opts := &slog.HandlerOptions{
 		Level:       levelVar,
@@ -97,10 +97,10 @@ func New(level, format string, writer io.Writer) *Service {
 	return service
 }
 
-// parseLevel converts a string to a slog.Level using a switch statement.
+// ParseLevel converts a string to a slog.Level using a switch statement.
 // Supports MCP log levels: debug, info, notice, warning, error, critical, alert, emergency.
 // Returns LevelInfo as default if level is not recognized.
-func parseLevel(level string) slog.Level {
+func ParseLevel(level string) slog.Level {
 	switch strings.ToLower(level) {
 	case "debug":
 		return LevelDebug
@@ -123,6 +123,29 @@ func parseLevel(level string) slog.Level {
 	}
 }
 
+// LevelName returns the MCP log level name of a slog.Level (e.g. "warning"), the inverse of ParseLevel.
+// Levels between two MCP levels take the name of the lower one.
+func LevelName(level slog.Level) string {
+	switch {
+	case level < LevelInfo:
+		return "debug"
+	case level < LevelNotice:
+		return "info"
+	case level < LevelWarning:
+		return "notice"
+	case level < LevelError:
+		return "warning"
+	case level < LevelCritical:
+		return "error"
+	case level < LevelAlert:
+		return "critical"
+	case level < LevelEmergency:
+		return "alert"
+	default:
+		return "emergency"
+	}
+}
+
 var sensitiveKeys = map[string]bool{
 	// Authentication & API
 	"password":   true,
@@ -146,30 +169,11 @@ func IsSensitiveKey(key string) bool {
 }
 
 // replaceAttr is a slog.HandlerOptions.ReplaceAttr function that customizes
-// log level attribute formatting. It maps log levels to uppercase string
-// representations using range-based switch cases (following slog custom levels pattern).
+// log level attribute formatting. It maps log levels to the uppercase MCP level names (see LevelName).
 // It also redacts sensitive information from log attributes based on predefined keys.
 func replaceAttr(_ []string, a slog.Attr) slog.Attr {
 	if a.Key == slog.LevelKey {
-		level := a.Value.Any().(slog.Level)
-		switch {
-		case level < LevelInfo:
-			a.Value = slog.StringValue("DEBUG")
-		case level < LevelNotice:
-			a.Value = slog.StringValue("INFO")
-		case level < LevelWarning:
-			a.Value = slog.StringValue("NOTICE")
-		case level < LevelError:
-			a.Value = slog.StringValue("WARNING")
-		case level < LevelCritical:
-			a.Value = slog.StringValue("ERROR")
-		case level < LevelAlert:
-			a.Value = slog.StringValue("CRITICAL")
-		case level < LevelEmergency:
-			a.Value = slog.StringValue("ALERT")
-		default:
-			a.Value = slog.StringValue("EMERGENCY")
-		}
+		a.Value = slog.StringValue(strings.ToUpper(LevelName(a.Value.Any().(slog.Level))))
 	}
 
 	// Redact sensitive information
@@ -122,6 +122,17 @@ func TestLogLevelChange(t *testing.T) {
 		}
 	})
 
+	t.Run("level names round trip", func(t *testing.T) {
+		for _, lvl := range logger.ValidLogLevels {
+			if name := logger.LevelName(logger.ParseLevel(lvl)); name != lvl {
+				t.Errorf("LevelName(ParseLevel(%q)) = %q", lvl, name)
+			}
+		}
+		if name := logger.LevelName(logger.LevelInfo + 1); name != "info" {
+			t.Errorf("expected levels between info and notice to be named info, got %q", name)
+		}
+	})
+
 	t.Run("json format with log level changes", func(t *testing.T) {
 		buf := &bytes.Buffer{}
 		log := logger.New("info", "json", buf)
@@ -0,0 +1,85 @@
+package prompts
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// NameCompleter returns the names of the MSTR objects of the given types starting with prefix
+type NameCompleter func(ctx context.Context, types []string, prefix string) (*mcp.Completion, error)
+
+// Completer completes prompt arguments: arguments with allowed values (e.g. scd=<1|2>) complete to
+// those values, arguments naming a metric or an attribute complete to object names. It implements
+// server.PromptCompletionProvider; the prompts are set once loaded.
+type Completer struct {
+	names   NameCompleter
+	prompts atomic.Pointer[[]Prompt]
+}
+
+// NewCompleter returns a Completer completing object names with names
+func NewCompleter(names NameCompleter) *Completer {
+	return &Completer{names: names}
+}
+
+// SetPrompts sets the prompts whose arguments are completed
+func (c *Completer) SetPrompts(prompts []Prompt) {
+	c.prompts.Store(&prompts)
+}
+
+// CompletePromptArgument completes an argument of a prompt
+func (c *Completer) CompletePromptArgument(ctx context.Context, promptName string, argument mcp.CompleteArgument, _ mcp.CompleteContext) (*mcp.Completion, error) {
+	arg, ok := c.argument(promptName, argument.Name)
+	if !ok {
+		return &mcp.Completion{Values: []string{}}, nil
+	}
+	if len(arg.Values) > 0 {
+		prefix := strings.ToLower(strings.TrimSpace(argument.Value))
+		values := make([]string, 0, len(arg.Values))
+		for _, value := range arg.Values {
+			if strings.HasPrefix(strings.ToLower(value), prefix) {
+				values = append(values, value)
+			}
+		}
+		return &mcp.Completion{Values: values}, nil
+	}
+	if types := objectTypes(arg.Name); len(types) > 0 && c.names != nil {
+		return c.names(ctx, types, argument.Value)
+	}
+	return &mcp.Completion{Values: []string{}}, nil
+}
+
+func (c *Completer) argument(promptName, name string) (Argument, bool) {
+	prompts := c.prompts.Load()
+	if prompts == nil {
+		return Argument{}, false
+	}
+	for _, p := range *prompts {
+		if p.Name != promptName {
+			continue
+		}
+		for _, arg := range p.Arguments {
+			if arg.Name == name {
+				return arg, true
+			}
+		}
+	}
+	return Argument{}, false
+}
+
+// objectTypes returns the object types named by an argument, e.g. "metric-or-attribute-name-or-guid"
+// names metrics and attributes
+func objectTypes(name string) []string {
+	var types []string
+	for _, word := range strings.Split(strings.ToLower(name), "-") {
+		switch word {
+		case "metric":
+			types = append(types, "Metric", "DerivedMetric")
+		case "attribute":
+			types = append(types, "Attribute")
+		}
+	}
+	return types
+}
@@ -0,0 +1,211 @@
+// Package prompts loads the migration workflow prompts (docs/v1-prompts) and exposes them as MCP prompts.
+package prompts
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/brunogc-cit/flow-microstrategy-mcp/docs"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// argumentsPlaceholder is replaced by the prompt arguments; prompts without it get them appended
+const argumentsPlaceholder = "$ARGUMENTS"
+
+// Argument is a typed prompt argument parsed from the argument-hint front matter, e.g.
+// "<attribute> [domain=<domain>] [scd=<1|2>]": <x> is required, [x] optional, [key=<values>] named
+type Argument struct {
+	Name        string
+	Description string
+	Required    bool
+	Named       bool     // rendered as name=value instead of a positional value
+	Values      []string // allowed values (e.g. scd=<1|2>), empty for free text
+}
+
+// Prompt is a workflow prompt file: front matter (name, description, argument-hint) and a markdown body
+type Prompt struct {
+	Name        string
+	Description string
+	Arguments   []Argument
+	Body        string
+}
+
+var hintPattern = regexp.MustCompile(`<([^<>]+)>|\[([^\[\]]+)\]`)
+
+// Load returns the prompts of dir, or the embedded docs/v1-prompts when dir is empty
+func Load(dir string) ([]Prompt, error) {
+	var fsys fs.FS
+	if dir == "" {
+		sub, err := fs.Sub(docs.V1Prompts, "v1-prompts")
+		if err != nil {
+			return nil, err
+		}
+		fsys = sub
+	} else {
+		fsys = os.DirFS(dir)
+	}
+	return LoadFS(fsys)
+}
+
+// LoadFS returns the prompts of the *.md files at the root of fsys, sorted by name
+func LoadFS(fsys fs.FS) ([]Prompt, error) {
+	files, err := fs.Glob(fsys, "*.md")
+	if err != nil {
+		return nil, err
+	}
+
+	prompts := make([]Prompt, 0, len(files))
+	for _, file := range files {
+		content, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read prompt %s: %w", file, err)
+		}
+		prompt, err := Parse(strings.TrimSuffix(path.Base(file), ".md"), string(content))
+		if err != nil {
+			return nil, fmt.Errorf("invalid prompt %s: %w", file, err)
+		}
+		prompts = append(prompts, prompt)
+	}
+	sort.Slice(prompts, func(i, j int) bool { return prompts[i].Name < prompts[j].Name })
+	return prompts, nil
+}
+
+// Parse parses a prompt file; the name defaults to the file name when the front matter has none
+func Parse(name, content string) (Prompt, error) {
+	prompt := Prompt{Name: name, Body: content}
+
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	if rest, ok := strings.CutPrefix(content, "---\n"); ok {
+		header, body, found := strings.Cut(rest, "\n---\n")
+		if !found {
+			return Prompt{}, fmt.Errorf("unterminated front matter")
+		}
+		prompt.Body = strings.TrimLeft(body, "\n")
+
+		for _, line := range strings.Split(header, "\n") {
+			key, value, ok := strings.Cut(line, ":")
+			if !ok {
+				continue
+			}
+			value = strings.Trim(strings.TrimSpace(value), `"'`)
+			switch strings.TrimSpace(key) {
+			case "name":
+				if value != "" {
+					prompt.Name = value
+				}
+			case "description":
+				prompt.Description = value
+			case "argument-hint":
+				prompt.Arguments = parseArgumentHint(value)
+			}
+		}
+	}
+
+	if prompt.Name == "" {
+		return Prompt{}, fmt.Errorf("missing prompt name")
+	}
+	return prompt, nil
+}
+
+// parseArgumentHint parses an argument-hint such as "<attribute> [domain=<domain>] [scd=<1|2>]"
+func parseArgumentHint(hint string) []Argument {
+	var args []Argument
+	for _, match := range hintPattern.FindAllStringSubmatch(hint, -1) {
+		arg := Argument{Required: match[1] != ""}
+		spec := match[1] + match[2]
+
+		if key, values, ok := strings.Cut(spec, "="); ok {
+			arg.Named = true
+			arg.Name = strings.TrimSpace(key)
+			values = strings.Trim(strings.TrimSpace(values), "<>")
+			if strings.Contains(values, "|") {
+				arg.Values = strings.Split(values, "|")
+				arg.Description = fmt.Sprintf("One of: %s", strings.Join(arg.Values, ", "))
+			} else {
+				arg.Description = humanize(values)
+			}
+		} else {
+			arg.Name = strings.TrimSpace(spec)
+			arg.Description = humanize(arg.Name)
+		}
+		args = append(args, arg)
+	}
+	return args
+}
+
+// humanize turns an argument name such as "metric-or-attribute-name-or-guid" into a description
+func humanize(name string) string {
+	text := strings.ReplaceAll(name, "-", " ")
+	text = strings.ReplaceAll(text, "guid", "GUID")
+	if text == "" {
+		return ""
+	}
+	return strings.ToUpper(text[:1]) + text[1:]
+}
+
+// Render returns the prompt body with the arguments substituted for $ARGUMENTS (or appended when
+// the body has no placeholder). Positional values come first, then name=value pairs.
+func (p Prompt) Render(arguments map[string]string) (string, error) {
+	var parts []string
+	for _, arg := range p.Arguments {
+		value := strings.TrimSpace(arguments[arg.Name])
+		if value == "" {
+			if arg.Required {
+				return "", fmt.Errorf("missing required argument '%s'", arg.Name)
+			}
+			continue
+		}
+		if len(arg.Values) > 0 && !slices.Contains(arg.Values, value) {
+			return "", fmt.Errorf("invalid %s '%s': must be one of %s", arg.Name, value, strings.Join(arg.Values, ", "))
+		}
+		if arg.Named {
+			parts = append(parts, arg.Name+"="+value)
+		} else if strings.Contains(value, " ") {
+			parts = append(parts, `"`+value+`"`)
+		} else {
+			parts = append(parts, value)
+		}
+	}
+	args := strings.Join(parts, " ")
+
+	if strings.Contains(p.Body, argumentsPlaceholder) {
+		return strings.ReplaceAll(p.Body, argumentsPlaceholder, args), nil
+	}
+	if args == "" {
+		return p.Body, nil
+	}
+	return strings.TrimRight(p.Body, "\n") + "\n\nARGUMENTS: " + args + "\n", nil
+}
+
+// ServerPrompt returns the MCP prompt definition and handler of the prompt
+func (p Prompt) ServerPrompt() server.ServerPrompt {
+	opts := []mcp.PromptOption{mcp.WithPromptDescription(p.Description)}
+	for _, arg := range p.Arguments {
+		argOpts := []mcp.ArgumentOption{mcp.ArgumentDescription(arg.Description)}
+		if arg.Required {
+			argOpts = append(argOpts, mcp.RequiredArgument())
+		}
+		opts = append(opts, mcp.WithArgument(arg.Name, argOpts...))
+	}
+
+	return server.ServerPrompt{
+		Prompt: mcp.NewPrompt(p.Name, opts...),
+		Handler: func(_ context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+			text, err := p.Render(request.Params.Arguments)
+			if err != nil {
+				return nil, err
+			}
+			return mcp.NewGetPromptResult(p.Description, []mcp.PromptMessage{
+				mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(text)),
+			}), nil
+		},
+	}
+}
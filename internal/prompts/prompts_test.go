@@ -0,0 +1,173 @@
+package prompts_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"testing/fstest"
+
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/prompts"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const dimensionPrompt = `---
+name: dbt-dimension-generator
+description: Generate DBT dimension models
+mode: agent
+argument-hint: <attribute> [domain=<domain>] [scd=<1|2>]
+---
+
+Parse the input arguments from: ` + "`$ARGUMENTS`" + `
+`
+
+func TestLoadEmbedded(t *testing.T) {
+	loaded, err := prompts.Load("")
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(loaded))
+	for _, p := range loaded {
+		names = append(names, p.Name)
+		assert.NotEmpty(t, p.Description, p.Name)
+		assert.NotEmpty(t, p.Body, p.Name)
+		assert.NotContains(t, p.Body, "argument-hint:", p.Name)
+	}
+	assert.Equal(t, []string{
+		"dbt-dimension-generator",
+		"metric-formula-generator",
+		"mstr-ade-gap-analysis",
+		"parity-validation",
+	}, names)
+}
+
+func TestLoadDirectory(t *testing.T) {
+	t.Run("loads markdown files", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(dir+"/custom.md", []byte("Check $ARGUMENTS\n"), 0o600))
+		require.NoError(t, os.WriteFile(dir+"/notes.txt", []byte("ignored"), 0o600))
+
+		loaded, err := prompts.Load(dir)
+		require.NoError(t, err)
+		require.Len(t, loaded, 1)
+		assert.Equal(t, "custom", loaded[0].Name)
+	})
+
+	t.Run("rejects unterminated front matter", func(t *testing.T) {
+		_, err := prompts.LoadFS(fstest.MapFS{"broken.md": {Data: []byte("---\nname: broken\n")}})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid prompt broken.md")
+	})
+}
+
+func TestParseArguments(t *testing.T) {
+	p, err := prompts.Parse("file", dimensionPrompt)
+	require.NoError(t, err)
+
+	assert.Equal(t, "dbt-dimension-generator", p.Name)
+	assert.Equal(t, []prompts.Argument{
+		{Name: "attribute", Description: "Attribute", Required: true},
+		{Name: "domain", Description: "Domain", Named: true},
+		{Name: "scd", Description: "One of: 1, 2", Named: true, Values: []string{"1", "2"}},
+	}, p.Arguments)
+
+	p, err = prompts.Parse("gap", "---\nargument-hint: [metric-or-attribute-name-or-guid]\n---\nBody\n")
+	require.NoError(t, err)
+	assert.Equal(t, "gap", p.Name)
+	assert.Equal(t, []prompts.Argument{
+		{Name: "metric-or-attribute-name-or-guid", Description: "Metric or attribute name or GUID"},
+	}, p.Arguments)
+}
+
+func TestRender(t *testing.T) {
+	p, err := prompts.Parse("file", dimensionPrompt)
+	require.NoError(t, err)
+
+	t.Run("substitutes the arguments placeholder", func(t *testing.T) {
+		text, err := p.Render(map[string]string{"attribute": "Product Category", "scd": "2"})
+		require.NoError(t, err)
+		assert.Contains(t, text, "Parse the input arguments from: `\"Product Category\" scd=2`")
+	})
+
+	t.Run("appends arguments without a placeholder", func(t *testing.T) {
+		gap, err := prompts.Parse("gap", "---\nargument-hint: [metric-name-or-guid]\n---\nBody\n")
+		require.NoError(t, err)
+
+		text, err := gap.Render(map[string]string{"metric-name-or-guid": "2F00974D"})
+		require.NoError(t, err)
+		assert.Equal(t, "Body\n\nARGUMENTS: 2F00974D\n", text)
+	})
+
+	t.Run("requires required arguments", func(t *testing.T) {
+		_, err := p.Render(map[string]string{"domain": "sales"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "missing required argument 'attribute'")
+	})
+
+	t.Run("validates allowed values", func(t *testing.T) {
+		_, err := p.Render(map[string]string{"attribute": "Region", "scd": "3"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid scd '3'")
+	})
+}
+
+func TestServerPrompt(t *testing.T) {
+	p, err := prompts.Parse("file", dimensionPrompt)
+	require.NoError(t, err)
+
+	sp := p.ServerPrompt()
+	assert.Equal(t, "dbt-dimension-generator", sp.Prompt.Name)
+	require.Len(t, sp.Prompt.Arguments, 3)
+	assert.True(t, sp.Prompt.Arguments[0].Required)
+	assert.False(t, sp.Prompt.Arguments[2].Required)
+
+	request := mcp.GetPromptRequest{}
+	request.Params.Arguments = map[string]string{"attribute": "Region", "domain": "channel"}
+	result, err := sp.Handler(context.Background(), request)
+	require.NoError(t, err)
+	require.Len(t, result.Messages, 1)
+	assert.Equal(t, mcp.RoleUser, result.Messages[0].Role)
+	text, ok := result.Messages[0].Content.(mcp.TextContent)
+	require.True(t, ok)
+	assert.Contains(t, text.Text, "`Region domain=channel`")
+}
+
+func TestCompleter(t *testing.T) {
+	dimension, err := prompts.Parse("file", dimensionPrompt)
+	require.NoError(t, err)
+	gap, err := prompts.Parse("gap", "---\nargument-hint: [metric-or-attribute-name-or-guid]\n---\nBody\n")
+	require.NoError(t, err)
+
+	var lookups [][]string
+	completer := prompts.NewCompleter(func(_ context.Context, types []string, prefix string) (*mcp.Completion, error) {
+		lookups = append(lookups, types)
+		return &mcp.Completion{Values: []string{prefix + " Region"}}, nil
+	})
+	complete := func(prompt, name, value string) []string {
+		completion, err := completer.CompletePromptArgument(context.Background(), prompt, mcp.CompleteArgument{Name: name, Value: value}, mcp.CompleteContext{})
+		require.NoError(t, err)
+		return completion.Values
+	}
+
+	assert.Empty(t, complete("dbt-dimension-generator", "attribute", "Cust"), "nothing before the prompts are set")
+	completer.SetPrompts([]prompts.Prompt{dimension, gap})
+
+	t.Run("completes allowed values", func(t *testing.T) {
+		assert.Equal(t, []string{"1", "2"}, complete("dbt-dimension-generator", "scd", ""))
+		assert.Equal(t, []string{"2"}, complete("dbt-dimension-generator", "scd", "2"))
+	})
+
+	t.Run("completes object names", func(t *testing.T) {
+		lookups = nil
+		assert.Equal(t, []string{"Cust Region"}, complete("dbt-dimension-generator", "attribute", "Cust"))
+		assert.Equal(t, []string{"Sal Region"}, complete("gap", "metric-or-attribute-name-or-guid", "Sal"))
+		assert.Equal(t, [][]string{{"Attribute"}, {"Metric", "DerivedMetric", "Attribute"}}, lookups)
+	})
+
+	t.Run("leaves free text arguments alone", func(t *testing.T) {
+		lookups = nil
+		assert.Empty(t, complete("dbt-dimension-generator", "domain", "sa"))
+		assert.Empty(t, complete("unknown", "attribute", "sa"))
+		assert.Empty(t, lookups)
+	})
+}
@@ -0,0 +1,159 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// requestIDMetaKey carries the JSON-RPC id of a tools/call request from the BeforeCallTool hook to the
+// tool handler middleware (the SDK does not pass the id to tool handlers). It is removed before the tool runs.
+const requestIDMetaKey = "flow-microstrategy-mcp/requestId"
+
+// methodNotificationCancelled is sent by clients to cancel a request (not defined by the SDK)
+const methodNotificationCancelled = "notifications/cancelled"
+
+var (
+	// errCancelledByClient is the cause of a tool call cancelled with notifications/cancelled
+	errCancelledByClient = errors.New("cancelled by the client")
+	// errSessionClosed is the cause of a tool call whose client session ended (e.g. SSE stream dropped)
+	errSessionClosed = errors.New("client session closed")
+)
+
+// callKey identifies an in-flight tool call: JSON-RPC ids are only unique within a session
+type callKey struct {
+	session string
+	id      string
+}
+
+// inflightCalls tracks running tool calls so that MCP cancellation and client disconnects cancel their
+// context, which terminates the running Neo4j transaction. Streamable HTTP requests are already cancelled
+// when the client disconnects (the call context is the request context).
+type inflightCalls struct {
+	mu        sync.Mutex
+	calls     map[callKey]context.CancelCauseFunc
+	cancelled map[callKey]error
+}
+
+func newInflightCalls() *inflightCalls {
+	return &inflightCalls{
+		calls:     make(map[callKey]context.CancelCauseFunc),
+		cancelled: make(map[callKey]error),
+	}
+}
+
+// newCallKey returns the key of a request in the session of ctx (ids are normalized, e.g. 1 and 1.0)
+func newCallKey(ctx context.Context, id any) callKey {
+	key := callKey{id: mcp.NewRequestId(id).String()}
+	if session := server.ClientSessionFromContext(ctx); session != nil {
+		key.session = session.SessionID()
+	}
+	return key
+}
+
+// tagRequest is a BeforeCallTool hook recording the JSON-RPC id in the request _meta
+func (c *inflightCalls) tagRequest(_ context.Context, id any, request *mcp.CallToolRequest) {
+	if request.Params.Meta == nil {
+		request.Params.Meta = &mcp.Meta{}
+	}
+	if request.Params.Meta.AdditionalFields == nil {
+		request.Params.Meta.AdditionalFields = make(map[string]any)
+	}
+	request.Params.Meta.AdditionalFields[requestIDMetaKey] = id
+}
+
+// middleware runs every tool call with a cancellable context registered under its session and id.
+// Cancelled calls are logged as such (not as failures) and return a cancellation error result.
+func (c *inflightCalls) middleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var id any
+		if request.Params.Meta != nil {
+			id = request.Params.Meta.AdditionalFields[requestIDMetaKey]
+			delete(request.Params.Meta.AdditionalFields, requestIDMetaKey)
+		}
+		key := newCallKey(ctx, id)
+
+		ctx, cancel := context.WithCancelCause(ctx)
+		defer cancel(nil)
+		if id != nil {
+			c.mu.Lock()
+			c.calls[key] = cancel
+			c.mu.Unlock()
+			defer func() {
+				c.mu.Lock()
+				delete(c.calls, key)
+				c.mu.Unlock()
+			}()
+		}
+
+		result, err := next(ctx, request)
+		if ctx.Err() == nil {
+			return result, err
+		}
+
+		cause := context.Cause(ctx)
+		slog.InfoContext(ctx, "tool call cancelled", "tool", request.Params.Name, "requestId", key.id, "reason", cause)
+		if id != nil {
+			c.mu.Lock()
+			c.cancelled[key] = cause
+			c.mu.Unlock()
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("Request cancelled: %v", cause)), nil
+	}
+}
+
+// takeCancelled reports whether the call was cancelled, forgetting it (used once the call completed)
+func (c *inflightCalls) takeCancelled(ctx context.Context, id any) bool {
+	key := newCallKey(ctx, id)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.cancelled[key]
+	delete(c.cancelled, key)
+	return ok
+}
+
+// handleCancelled handles notifications/cancelled by cancelling the matching in-flight call.
+// Stateless HTTP requests have no session, so their ids cannot be matched across requests:
+// they are only cancelled by the client disconnecting.
+func (c *inflightCalls) handleCancelled(ctx context.Context, notification mcp.JSONRPCNotification) {
+	requestID, ok := notification.Params.AdditionalFields["requestId"]
+	if !ok {
+		return
+	}
+	key := newCallKey(ctx, requestID)
+	if key.session == "" {
+		slog.DebugContext(ctx, "ignoring cancellation without a client session", "requestId", key.id)
+		return
+	}
+
+	cause := errCancelledByClient
+	if reason, _ := notification.Params.AdditionalFields["reason"].(string); reason != "" {
+		cause = fmt.Errorf("%w: %s", errCancelledByClient, reason)
+	}
+
+	c.mu.Lock()
+	cancel, ok := c.calls[key]
+	c.mu.Unlock()
+	if !ok {
+		// The call may already have completed: cancellation is best effort
+		slog.DebugContext(ctx, "no in-flight tool call to cancel", "requestId", key.id)
+		return
+	}
+	cancel(cause)
+}
+
+// cancelSession is an UnregisterSession hook cancelling the in-flight calls of a closed session
+func (c *inflightCalls) cancelSession(_ context.Context, session server.ClientSession) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, cancel := range c.calls {
+		if key.session == session.SessionID() {
+			cancel(errSessionClosed)
+		}
+	}
+}
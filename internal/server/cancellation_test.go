@@ -0,0 +1,196 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/analytics"
+	analytics_mocks "github.com/brunogc-cit/flow-microstrategy-mcp/internal/analytics/mocks"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/config"
+	db_mocks "github.com/brunogc-cit/flow-microstrategy-mcp/internal/database/mocks"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// cancellationSession is an initialized client session with a fixed id
+type cancellationSession struct {
+	id string
+}
+
+func (s *cancellationSession) Initialize()       {}
+func (s *cancellationSession) Initialized() bool { return true }
+func (s *cancellationSession) SessionID() string { return s.id }
+func (s *cancellationSession) NotificationChannel() chan<- mcp.JSONRPCNotification {
+	return make(chan mcp.JSONRPCNotification, 10)
+}
+
+// blockingTool returns a tool signalling when it starts, then waiting for its context to be cancelled
+func blockingTool(started chan<- struct{}) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("blocking"),
+		Handler: func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			close(started)
+			select {
+			case <-ctx.Done():
+				return mcp.NewToolResultError("Query execution failed: " + ctx.Err().Error()), nil
+			case <-time.After(5 * time.Second):
+				return mcp.NewToolResultText("not cancelled"), nil
+			}
+		},
+	}
+}
+
+func newCancellationTestServer(t *testing.T, anService *analytics_mocks.MockService) *Neo4jMCPServer {
+	t.Helper()
+	cfg := &config.Config{TransportMode: config.TransportModeStdio}
+	return NewNeo4jMCPServer("test-version", cfg, db_mocks.NewMockService(gomock.NewController(t)), anService)
+}
+
+func handle(t *testing.T, s *Neo4jMCPServer, ctx context.Context, message map[string]any) mcp.JSONRPCMessage {
+	t.Helper()
+	data, err := json.Marshal(message)
+	require.NoError(t, err)
+	return s.MCPServer.HandleMessage(ctx, data)
+}
+
+// callBlocking calls the blocking tool in the background and returns its result once it completes
+func callBlocking(t *testing.T, s *Neo4jMCPServer, ctx context.Context, id any) (<-chan struct{}, <-chan mcp.CallToolResult) {
+	t.Helper()
+	started := make(chan struct{})
+	s.MCPServer.AddTools(blockingTool(started))
+
+	results := make(chan mcp.CallToolResult, 1)
+	go func() {
+		response := handle(t, s, ctx, map[string]any{"jsonrpc": "2.0", "id": id, "method": "tools/call", "params": map[string]any{"name": "blocking"}})
+		if response, ok := response.(mcp.JSONRPCResponse); ok {
+			results <- *response.Result.(*mcp.CallToolResult)
+		}
+		close(results)
+	}()
+	return started, results
+}
+
+func waitResult(t *testing.T, results <-chan mcp.CallToolResult) mcp.CallToolResult {
+	t.Helper()
+	select {
+	case result, ok := <-results:
+		require.True(t, ok, "expected a tool result")
+		return result
+	case <-time.After(10 * time.Second):
+		t.Fatal("tool call did not complete")
+		return mcp.CallToolResult{}
+	}
+}
+
+func TestToolCallCancellation(t *testing.T) {
+	t.Run("cancels the call matching notifications/cancelled", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		anService := analytics_mocks.NewMockService(ctrl)
+		anService.EXPECT().IsEnabled().Return(true).AnyTimes()
+		anService.EXPECT().NewToolCancelledEvent("blocking").Return(analytics.TrackEvent{Event: "cancelled"})
+		anService.EXPECT().EmitEvent(analytics.TrackEvent{Event: "cancelled"})
+
+		s := newCancellationTestServer(t, anService)
+		ctx := s.MCPServer.WithContext(context.Background(), &cancellationSession{id: "session-1"})
+		started, results := callBlocking(t, s, ctx, 7)
+		<-started
+
+		// Another session cannot cancel the call
+		other := s.MCPServer.WithContext(context.Background(), &cancellationSession{id: "session-2"})
+		handle(t, s, other, map[string]any{"jsonrpc": "2.0", "method": "notifications/cancelled", "params": map[string]any{"requestId": 7}})
+		handle(t, s, ctx, map[string]any{"jsonrpc": "2.0", "method": "notifications/cancelled", "params": map[string]any{"requestId": 7, "reason": "user aborted"}})
+
+		result := waitResult(t, results)
+		assert.True(t, result.IsError)
+		assert.Equal(t, "Request cancelled: cancelled by the client: user aborted", result.Content[0].(mcp.TextContent).Text)
+	})
+
+	t.Run("cancels the calls of a closed session", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		anService := analytics_mocks.NewMockService(ctrl)
+		anService.EXPECT().IsEnabled().Return(false).AnyTimes()
+
+		s := newCancellationTestServer(t, anService)
+		session := &cancellationSession{id: "sse-1"}
+		require.NoError(t, s.MCPServer.RegisterSession(context.Background(), session))
+		started, results := callBlocking(t, s, s.MCPServer.WithContext(context.Background(), session), "call-1")
+		<-started
+
+		s.MCPServer.UnregisterSession(context.Background(), session.SessionID())
+
+		result := waitResult(t, results)
+		assert.True(t, result.IsError)
+		assert.Equal(t, "Request cancelled: client session closed", result.Content[0].(mcp.TextContent).Text)
+	})
+
+	t.Run("ignores cancellations without a session", func(t *testing.T) {
+		calls := newInflightCalls()
+		cancelled := false
+		calls.calls[callKey{id: mcp.NewRequestId(1).String()}] = func(error) { cancelled = true }
+
+		calls.handleCancelled(context.Background(), mcp.JSONRPCNotification{
+			Notification: mcp.Notification{
+				Method: methodNotificationCancelled,
+				Params: mcp.NotificationParams{AdditionalFields: map[string]any{"requestId": 1}},
+			},
+		})
+		assert.False(t, cancelled)
+	})
+
+	t.Run("reports completed calls as usual", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		anService := analytics_mocks.NewMockService(ctrl)
+		anService.EXPECT().IsEnabled().Return(true).AnyTimes()
+		anService.EXPECT().NewToolEvent("echo", true).Return(analytics.TrackEvent{Event: "used"})
+		anService.EXPECT().EmitEvent(analytics.TrackEvent{Event: "used"})
+
+		s := newCancellationTestServer(t, anService)
+		var meta *mcp.Meta
+		s.MCPServer.AddTool(mcp.NewTool("echo"), func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			meta = request.Params.Meta
+			return mcp.NewToolResultText("ok"), nil
+		})
+
+		ctx := s.MCPServer.WithContext(context.Background(), &cancellationSession{id: "session-1"})
+		response, ok := handle(t, s, ctx, map[string]any{"jsonrpc": "2.0", "id": 1, "method": "tools/call", "params": map[string]any{"name": "echo"}}).(mcp.JSONRPCResponse)
+		require.True(t, ok)
+		assert.False(t, response.Result.(*mcp.CallToolResult).IsError)
+		require.NotNil(t, meta)
+		assert.NotContains(t, meta.AdditionalFields, requestIDMetaKey, "the request id is internal")
+		assert.Empty(t, s.calls.calls)
+	})
+}
+
+func TestInflightCallsMiddleware(t *testing.T) {
+	t.Run("returns the handler result when the context is not cancelled", func(t *testing.T) {
+		handlerErr := errors.New("boom")
+		handler := newInflightCalls().middleware(func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return nil, handlerErr
+		})
+		_, err := handler(context.Background(), mcp.CallToolRequest{})
+		assert.ErrorIs(t, err, handlerErr)
+	})
+
+	t.Run("reports a disconnected client as cancelled", func(t *testing.T) {
+		calls := newInflightCalls()
+		ctx, cancel := context.WithCancel(context.Background())
+		handler := calls.middleware(func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			cancel()
+			return mcp.NewToolResultError("Query execution failed"), nil
+		})
+
+		request := mcp.CallToolRequest{}
+		calls.tagRequest(ctx, int64(3), &request)
+		result, err := handler(ctx, request)
+		require.NoError(t, err)
+		assert.Equal(t, "Request cancelled: context canceled", result.Content[0].(mcp.TextContent).Text)
+		assert.True(t, calls.takeCancelled(ctx, float64(3)), "ids are normalized")
+		assert.False(t, calls.takeCancelled(ctx, int64(3)), "cancellations are reported once")
+	})
+}
@@ -0,0 +1,114 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"slices"
+	"syscall"
+	"time"
+
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/config"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// watchCapabilities re-detects the capabilities of the Neo4j instance every Config.CapabilityRefresh
+// (when set) and, in HTTP mode, on SIGHUP, until ctx is done. In STDIO mode the server is a child of the
+// client and SIGHUP keeps its default behavior (terminate when the client's terminal goes away).
+// In HTTP mode without API token there are no server-side credentials: the re-detection runs at the
+// next initialize request instead, with the credentials of that client.
+func (s *Neo4jMCPServer) watchCapabilities(ctx context.Context) {
+	var tick <-chan time.Time
+	if s.config.CapabilityRefresh > 0 {
+		ticker := time.NewTicker(s.config.CapabilityRefresh)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	hangup := make(chan os.Signal, 1)
+	if s.config.TransportMode == config.TransportModeHTTP {
+		signal.Notify(hangup, syscall.SIGHUP)
+		defer signal.Stop(hangup)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick:
+		case <-hangup:
+			slog.Info("Capability re-detection requested (SIGHUP)")
+		}
+		s.requestCapabilityRefresh(ctx)
+	}
+}
+
+// requestCapabilityRefresh re-detects the capabilities now, or marks the re-detection due for the next
+// initialize request when the server has no credentials of its own
+func (s *Neo4jMCPServer) requestCapabilityRefresh(ctx context.Context) {
+	if s.config.TransportMode == config.TransportModeHTTP {
+		// Nothing to refresh before the first client initialized the server
+		if !s.connectionVerified.Load() {
+			return
+		}
+		if s.config.APIToken == "" {
+			s.refreshDue.Store(true)
+			return
+		}
+	}
+	s.refreshCapabilities(ctx)
+}
+
+// refreshCapabilities re-detects APOC, GDS and the full-text search index, then adds and removes tools to
+// match. When the Neo4j instance cannot be reached, the tools are left unchanged.
+func (s *Neo4jMCPServer) refreshCapabilities(ctx context.Context) {
+	s.refreshMu.Lock()
+	defer s.refreshMu.Unlock()
+
+	apocAvailable, err := s.checkAPOC(ctx)
+	if err != nil {
+		slog.Warn("Impossible to re-detect the Neo4j capabilities, tools are unchanged", "error", err)
+		return
+	}
+	if !apocAvailable && s.apocAvailable.Load() {
+		slog.Warn("APOC apoc.meta.schema is no longer available, get-schema is disabled")
+	}
+	s.apocAvailable.Store(apocAvailable)
+	s.checkSearchIndex(ctx)
+	s.gdsInstalled.Store(s.checkGDS(ctx))
+	s.refreshDue.Store(false)
+
+	s.syncTools()
+}
+
+// syncTools adds and removes tools so that the registered tools are the enabled ones.
+// The SDK sends notifications/tools/list_changed to the connected clients when the list changes.
+func (s *Neo4jMCPServer) syncTools() {
+	enabled := s.getEnabledTools()
+	registered := s.MCPServer.ListTools()
+
+	var added []server.ServerTool
+	var addedNames []string
+	for _, tool := range enabled {
+		if _, ok := registered[tool.Tool.Name]; !ok {
+			added = append(added, tool)
+			addedNames = append(addedNames, tool.Tool.Name)
+		}
+	}
+	var removed []string
+	for name := range registered {
+		if !slices.ContainsFunc(enabled, func(tool server.ServerTool) bool { return tool.Tool.Name == name }) {
+			removed = append(removed, name)
+		}
+	}
+
+	if len(removed) > 0 {
+		slices.Sort(removed)
+		slog.Info("Removing tools", "tools", removed)
+		s.MCPServer.DeleteTools(removed...)
+	}
+	if len(added) > 0 {
+		slog.Info("Adding tools", "tools", addedNames)
+		s.MCPServer.AddTools(added...)
+	}
+}
@@ -0,0 +1,127 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	analytics_mocks "github.com/brunogc-cit/flow-microstrategy-mcp/internal/analytics/mocks"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/config"
+	db_mocks "github.com/brunogc-cit/flow-microstrategy-mcp/internal/database/mocks"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools/mstr"
+	"github.com/neo4j/neo4j-go-driver/v6/neo4j"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// expectCapabilities sets the responses of the capability detection queries
+func expectCapabilities(mockDB *db_mocks.MockService, apoc, gds bool) {
+	mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), checkApocMetaSchemaQuery, gomock.Any()).
+		Return([]*neo4j.Record{{Keys: []string{"apocMetaSchemaAvailable"}, Values: []any{apoc}}}, nil)
+	mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), mstr.CheckSearchIndexQuery, gomock.Any()).Return([]*neo4j.Record{}, nil)
+	gdsQuery := mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), "RETURN gds.version() as gdsVersion", gomock.Any())
+	if gds {
+		gdsQuery.Return([]*neo4j.Record{{Keys: []string{"gdsVersion"}, Values: []any{"2.22.0"}}}, nil)
+	} else {
+		gdsQuery.Return(nil, errors.New("unknown function gds.version"))
+	}
+}
+
+func newCapabilitiesTestServer(t *testing.T, cfg *config.Config) (*Neo4jMCPServer, *db_mocks.MockService, *loggingSession) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	anService := analytics_mocks.NewMockService(ctrl)
+	anService.EXPECT().IsEnabled().Return(false).AnyTimes()
+	mockDB := db_mocks.NewMockService(ctrl)
+
+	s := NewNeo4jMCPServer("test-version", cfg, mockDB, anService)
+	require.NoError(t, s.registerTools())
+	session := newLoggingSession("client")
+	require.NoError(t, s.MCPServer.RegisterSession(context.Background(), session))
+	return s, mockDB, session
+}
+
+// toolListChanges counts the notifications/tools/list_changed received by a session
+func toolListChanges(session *loggingSession) int {
+	count := 0
+	for {
+		select {
+		case notification := <-session.notifications:
+			if notification.Method == "notifications/tools/list_changed" {
+				count++
+			}
+		default:
+			return count
+		}
+	}
+}
+
+func TestRefreshCapabilities(t *testing.T) {
+	stdio := &config.Config{TransportMode: config.TransportModeStdio}
+
+	t.Run("adds GDS tools when GDS appears", func(t *testing.T) {
+		s, mockDB, session := newCapabilitiesTestServer(t, stdio)
+		require.Nil(t, s.MCPServer.GetTool("list-gds-procedures"))
+
+		expectCapabilities(mockDB, true, true)
+		s.refreshCapabilities(context.Background())
+
+		assert.NotNil(t, s.MCPServer.GetTool("list-gds-procedures"))
+		assert.Equal(t, 1, toolListChanges(session))
+	})
+
+	t.Run("removes tools whose capability is lost", func(t *testing.T) {
+		s, mockDB, session := newCapabilitiesTestServer(t, stdio)
+		s.gdsInstalled.Store(true)
+		s.syncTools()
+		toolListChanges(session)
+
+		expectCapabilities(mockDB, false, false)
+		s.refreshCapabilities(context.Background())
+
+		assert.Nil(t, s.MCPServer.GetTool("list-gds-procedures"))
+		assert.Nil(t, s.MCPServer.GetTool("get-schema"))
+		assert.NotNil(t, s.MCPServer.GetTool("read-cypher"))
+		assert.Equal(t, 1, toolListChanges(session), "one notification for the removed tools")
+	})
+
+	t.Run("does not notify when nothing changed", func(t *testing.T) {
+		s, mockDB, session := newCapabilitiesTestServer(t, stdio)
+
+		expectCapabilities(mockDB, true, false)
+		s.refreshCapabilities(context.Background())
+
+		assert.NotNil(t, s.MCPServer.GetTool("get-schema"))
+		assert.Zero(t, toolListChanges(session))
+	})
+
+	t.Run("keeps the tools when Neo4j cannot be reached", func(t *testing.T) {
+		s, mockDB, session := newCapabilitiesTestServer(t, stdio)
+		tools := len(s.MCPServer.ListTools())
+
+		mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), checkApocMetaSchemaQuery, gomock.Any()).Return(nil, errors.New("connection refused"))
+		s.refreshCapabilities(context.Background())
+
+		assert.Len(t, s.MCPServer.ListTools(), tools)
+		assert.Zero(t, toolListChanges(session))
+	})
+
+	t.Run("defers the re-detection to the next initialize without server-side credentials", func(t *testing.T) {
+		s, mockDB, _ := newCapabilitiesTestServer(t, &config.Config{TransportMode: config.TransportModeHTTP})
+		s.connectionVerified.Store(true)
+
+		// No query: the server has no credentials of its own
+		s.requestCapabilityRefresh(context.Background())
+		require.True(t, s.refreshDue.Load())
+
+		expectCapabilities(mockDB, true, true)
+		response := handle(t, s, context.Background(), map[string]any{
+			"jsonrpc": "2.0", "id": 1, "method": "initialize",
+			"params": map[string]any{"protocolVersion": "2025-06-18", "clientInfo": map[string]any{"name": "test", "version": "1"}},
+		})
+		require.NotNil(t, response)
+		assert.False(t, s.refreshDue.Load())
+		assert.NotNil(t, s.MCPServer.GetTool("list-gds-procedures"))
+	})
+}
@@ -0,0 +1,163 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/config"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/logger"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// loggerName is the logger reported in notifications/message
+const loggerName = "flow-microstrategy-mcp"
+
+// clientLogs forwards log records to MCP clients as notifications/message.
+// The level of each client is the one it set with logging/setLevel (error by default), stored by the SDK
+// in its session. In STDIO mode logging/setLevel also sets the level of the server logs (logger.SetLevel).
+type clientLogs struct {
+	server *Neo4jMCPServer
+	// stdio is the session of the STDIO client, which receives the records logged without a session
+	stdio atomic.Value
+}
+
+// registerSession is a RegisterSession hook recording the session of the STDIO client
+func (c *clientLogs) registerSession(_ context.Context, session server.ClientSession) {
+	if c.server.config.TransportMode == config.TransportModeStdio {
+		c.stdio.Store(session)
+	}
+}
+
+// setLevel is an AfterSetLevel hook applying the level of the STDIO client to the server logs.
+// In HTTP mode the level only applies to the session that set it.
+func (c *clientLogs) setLevel(_ context.Context, _ any, request *mcp.SetLevelRequest, _ *mcp.EmptyResult) {
+	if c.server.config.TransportMode != config.TransportModeStdio {
+		return
+	}
+	logger.SetLevel(string(request.Params.Level))
+	slog.Info("Log level set by the client", "level", request.Params.Level)
+}
+
+// session returns the client session receiving a record logged with ctx: the session of the tool call,
+// or the STDIO client. Records without a session are not forwarded in HTTP mode, as they may concern other clients.
+func (c *clientLogs) session(ctx context.Context) (context.Context, server.SessionWithLogging) {
+	session := server.ClientSessionFromContext(ctx)
+	if session == nil {
+		stdio, ok := c.stdio.Load().(server.ClientSession)
+		if !ok {
+			return ctx, nil
+		}
+		session = stdio
+		ctx = c.server.MCPServer.WithContext(ctx, session)
+	}
+	withLogging, ok := session.(server.SessionWithLogging)
+	if !ok || !session.Initialized() {
+		return ctx, nil
+	}
+	return ctx, withLogging
+}
+
+// enabled reports whether the client receiving a record logged with ctx accepts the level
+func (c *clientLogs) enabled(ctx context.Context, level slog.Level) bool {
+	_, session := c.session(ctx)
+	return session != nil && level >= logger.ParseLevel(string(session.GetLogLevel()))
+}
+
+// LogHandler returns a slog handler writing records to next and forwarding them to the MCP clients at or above their
+// level. Use the Context variants of slog (e.g. slog.ErrorContext) in tool calls so that their records reach the client.
+func (s *Neo4jMCPServer) LogHandler(next slog.Handler) slog.Handler {
+	return &clientLogHandler{next: next, logs: s.logs}
+}
+
+// clientLogHandler is the slog handler returned by LogHandler
+type clientLogHandler struct {
+	next  slog.Handler
+	logs  *clientLogs
+	attrs map[string]any // attributes added with WithAttrs, keyed with their group
+	group string         // prefix of the current group, e.g. "request."
+}
+
+func (h *clientLogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level) || h.logs.enabled(ctx, level)
+}
+
+func (h *clientLogHandler) Handle(ctx context.Context, record slog.Record) error {
+	var err error
+	if h.next.Enabled(ctx, record.Level) {
+		err = h.next.Handle(ctx, record)
+	}
+
+	ctx, session := h.logs.session(ctx)
+	if session == nil {
+		return err
+	}
+	data := map[string]any{"message": record.Message}
+	for key, value := range h.attrs {
+		data[key] = value
+	}
+	record.Attrs(func(attr slog.Attr) bool {
+		addAttr(data, h.group, attr)
+		return true
+	})
+	notification := mcp.NewLoggingMessageNotification(mcp.LoggingLevel(logger.LevelName(record.Level)), loggerName, data)
+	// Forwarding is best effort, and failures are not logged to avoid forwarding them again
+	_ = h.logs.server.MCPServer.SendLogMessageToClient(ctx, notification)
+	return err
+}
+
+func (h *clientLogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	clone.next = h.next.WithAttrs(attrs)
+	clone.attrs = make(map[string]any, len(h.attrs)+len(attrs))
+	for key, value := range h.attrs {
+		clone.attrs[key] = value
+	}
+	for _, attr := range attrs {
+		addAttr(clone.attrs, h.group, attr)
+	}
+	return &clone
+}
+
+func (h *clientLogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	clone := *h
+	clone.next = h.next.WithGroup(name)
+	clone.group = h.group + name + "."
+	return &clone
+}
+
+// addAttr adds an attribute to the data of a notification, flattening groups and redacting sensitive keys
+func addAttr(data map[string]any, prefix string, attr slog.Attr) {
+	value := attr.Value.Resolve()
+	if value.Kind() == slog.KindGroup {
+		group := prefix
+		if attr.Key != "" {
+			group += attr.Key + "."
+		}
+		for _, member := range value.Group() {
+			addAttr(data, group, member)
+		}
+		return
+	}
+	if attr.Key == "" {
+		return
+	}
+
+	key := prefix + attr.Key
+	switch {
+	case logger.IsSensitiveKey(attr.Key):
+		data[key] = "[REDACTED]"
+	case value.Kind() == slog.KindAny:
+		if err, ok := value.Any().(error); ok {
+			data[key] = err.Error()
+		} else {
+			data[key] = value.Any()
+		}
+	default:
+		data[key] = value.Any()
+	}
+}
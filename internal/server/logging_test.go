@@ -0,0 +1,133 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"testing"
+
+	analytics_mocks "github.com/brunogc-cit/flow-microstrategy-mcp/internal/analytics/mocks"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/config"
+	db_mocks "github.com/brunogc-cit/flow-microstrategy-mcp/internal/database/mocks"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// loggingSession is an initialized client session with a log level, collecting its notifications
+type loggingSession struct {
+	id            string
+	notifications chan mcp.JSONRPCNotification
+
+	mu    sync.Mutex
+	level mcp.LoggingLevel
+}
+
+func newLoggingSession(id string) *loggingSession {
+	return &loggingSession{id: id, notifications: make(chan mcp.JSONRPCNotification, 10), level: mcp.LoggingLevelError}
+}
+
+func (s *loggingSession) Initialize()       {}
+func (s *loggingSession) Initialized() bool { return true }
+func (s *loggingSession) SessionID() string { return s.id }
+func (s *loggingSession) NotificationChannel() chan<- mcp.JSONRPCNotification {
+	return s.notifications
+}
+
+func (s *loggingSession) SetLogLevel(level mcp.LoggingLevel) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.level = level
+}
+
+func (s *loggingSession) GetLogLevel() mcp.LoggingLevel {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.level
+}
+
+// received returns the params of the notifications/message received so far
+func (s *loggingSession) received(t *testing.T) []map[string]any {
+	t.Helper()
+	var params []map[string]any
+	for {
+		select {
+		case notification := <-s.notifications:
+			assert.Equal(t, "notifications/message", notification.Method)
+			params = append(params, notification.Params.AdditionalFields)
+		default:
+			return params
+		}
+	}
+}
+
+func newLoggingTestServer(t *testing.T, mode config.TransportMode) (*Neo4jMCPServer, *slog.Logger, *bytes.Buffer) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	anService := analytics_mocks.NewMockService(ctrl)
+	anService.EXPECT().IsEnabled().Return(false).AnyTimes()
+
+	s := NewNeo4jMCPServer("test-version", &config.Config{TransportMode: mode}, db_mocks.NewMockService(ctrl), anService)
+	buf := &bytes.Buffer{}
+	log := slog.New(s.LogHandler(slog.NewTextHandler(buf, &slog.HandlerOptions{Level: slog.LevelInfo})))
+	return s, log, buf
+}
+
+func TestClientLogs(t *testing.T) {
+	t.Run("forwards STDIO records at or above the client level", func(t *testing.T) {
+		s, log, buf := newLoggingTestServer(t, config.TransportModeStdio)
+		session := newLoggingSession("stdio")
+		require.NoError(t, s.MCPServer.RegisterSession(context.Background(), session))
+
+		log.Info("not forwarded at the default error level")
+		log.With("tool", "trace-metric").Error("query failed", "error", errors.New("boom"), "password", "secret")
+
+		received := session.received(t)
+		require.Len(t, received, 1)
+		assert.Equal(t, mcp.LoggingLevelError, received[0]["level"])
+		assert.Equal(t, loggerName, received[0]["logger"])
+		assert.Equal(t, map[string]any{"message": "query failed", "tool": "trace-metric", "error": "boom", "password": "[REDACTED]"}, received[0]["data"])
+		assert.Contains(t, buf.String(), "not forwarded at the default error level", "records are still written to the server logs")
+	})
+
+	t.Run("honors logging/setLevel", func(t *testing.T) {
+		s, log, buf := newLoggingTestServer(t, config.TransportModeStdio)
+		session := newLoggingSession("stdio")
+		require.NoError(t, s.MCPServer.RegisterSession(context.Background(), session))
+
+		response := handle(t, s, s.MCPServer.WithContext(context.Background(), session), map[string]any{
+			"jsonrpc": "2.0", "id": 1, "method": "logging/setLevel", "params": map[string]any{"level": "debug"},
+		})
+		_, ok := response.(mcp.JSONRPCResponse)
+		require.True(t, ok, "expected a successful response, got %v", response)
+		session.received(t)
+
+		log.Debug("cache miss", slog.Group("query", "name", "trace-metric"))
+		received := session.received(t)
+		require.Len(t, received, 1)
+		assert.Equal(t, mcp.LoggingLevelDebug, received[0]["level"])
+		assert.Equal(t, map[string]any{"message": "cache miss", "query.name": "trace-metric"}, received[0]["data"])
+		assert.NotContains(t, buf.String(), "cache miss", "the server logs keep their own level")
+	})
+
+	t.Run("forwards HTTP records to the session of the call only", func(t *testing.T) {
+		s, log, _ := newLoggingTestServer(t, config.TransportModeHTTP)
+		first, second := newLoggingSession("first"), newLoggingSession("second")
+		second.SetLogLevel(mcp.LoggingLevelDebug)
+
+		log.Error("server error without a session")
+		log.ErrorContext(s.MCPServer.WithContext(context.Background(), first), "first error")
+		log.InfoContext(s.MCPServer.WithContext(context.Background(), first), "first info")
+		log.InfoContext(s.MCPServer.WithContext(context.Background(), second), "second info")
+
+		received := first.received(t)
+		require.Len(t, received, 1)
+		assert.Equal(t, "first error", received[0]["data"].(map[string]any)["message"])
+		received = second.received(t)
+		require.Len(t, received, 1)
+		assert.Equal(t, "second info", received[0]["data"].(map[string]any)["message"])
+	})
+}
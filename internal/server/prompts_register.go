@@ -0,0 +1,28 @@
+package server
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/prompts"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// registerPrompts registers the migration workflow prompts (gap analysis, dbt dimension generator,
+// parity validation, formula generator) as MCP prompts. Prompts are loaded from the FLOW_PROMPTS_DIR
+// directory when configured, otherwise from the prompts embedded from docs/v1-prompts.
+func (s *Neo4jMCPServer) registerPrompts() error {
+	loaded, err := prompts.Load(s.config.PromptsDir)
+	if err != nil {
+		return fmt.Errorf("failed to load prompts: %w", err)
+	}
+
+	serverPrompts := make([]server.ServerPrompt, 0, len(loaded))
+	for _, p := range loaded {
+		serverPrompts = append(serverPrompts, p.ServerPrompt())
+	}
+	s.MCPServer.AddPrompts(serverPrompts...)
+	s.promptCompleter.SetPrompts(loaded)
+	slog.Info("Registered prompts", "count", len(serverPrompts), "dir", s.config.PromptsDir)
+	return nil
+}
@@ -0,0 +1,23 @@
+package server
+
+import (
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools/mstr"
+)
+
+// registerResources registers the MCP resource templates (mstr://metric/{guid}, mstr://attribute/{guid},
+// mstr://report/{guid}) so clients can attach MSTR object cards as context without a tool call.
+// Resources are read-only and are therefore registered in read-only mode as well.
+func (s *Neo4jMCPServer) registerResources() {
+	s.MCPServer.AddResourceTemplates(mstr.ObjectResourceTemplates(s.resourceDependencies())...)
+}
+
+// resourceDependencies returns the dependencies of the resource templates and of the completions
+func (s *Neo4jMCPServer) resourceDependencies() *tools.ToolDependencies {
+	return &tools.ToolDependencies{
+		DBService:        s.dbService,
+		AnalyticsService: s.anService,
+		SearchIndex:      &s.searchIndexAvailable,
+		DefaultScope:     s.config.MSTRScope,
+	}
+}
@@ -19,6 +19,8 @@ import (
 	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/analytics"
 	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/config"
 	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/database"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/prompts"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools/mstr"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 	"github.com/neo4j/neo4j-go-driver/v6/neo4j"
@@ -32,6 +34,8 @@ const (
 	serverHTTPReadTimeout       = 15 * time.Second  // SECURITY: Maximum time to read entire request including body (prevents slow-read attacks)
 	serverHTTPWriteTimeout      = 60 * time.Second  // FUNCTIONALITY: Maximum time to write response (allows complex Neo4j queries and large result sets)
 	serverHTTPIdleTimeout       = 120 * time.Second // PERFORMANCE: Maximum time to keep idle keep-alive connections open (improves connection reuse)
+
+	checkApocMetaSchemaQuery = "SHOW PROCEDURES YIELD name WHERE name = 'apoc.meta.schema' RETURN count(name) > 0 AS apocMetaSchemaAvailable"
 )
 
 // Neo4jMCPServer represents the MCP server instance
@@ -44,9 +48,26 @@ type Neo4jMCPServer struct {
 	dbService          database.Service
 	version            string
 	anService          analytics.Service
-	gdsInstalled       bool
+	gdsInstalled       atomic.Bool
+	apocAvailable      atomic.Bool // apoc.meta.schema is required at startup, but may be lost at runtime (see refreshCapabilities)
 	initMu             sync.Mutex
 	connectionVerified atomic.Bool
+
+	// calls tracks in-flight tool calls so that client cancellations and disconnects cancel them
+	calls *inflightCalls
+	// logs forwards log records to the clients (MCP logging capability)
+	logs *clientLogs
+
+	// refreshMu serializes capability re-detections; refreshDue marks a re-detection to run at the next
+	// initialize request (HTTP mode without server-side credentials)
+	refreshMu  sync.Mutex
+	refreshDue atomic.Bool
+
+	// searchIndexAvailable is shared with the search tools, which fall back to CONTAINS matching when false
+	searchIndexAvailable atomic.Bool
+
+	// promptCompleter completes prompt arguments (completion/complete); the prompts are set once registered
+	promptCompleter *prompts.Completer
 }
 
 // NewNeo4jMCPServer creates a new MCP server instance
@@ -60,8 +81,12 @@ func NewNeo4jMCPServer(version string, cfg *config.Config, dbService database.Se
 		dbService:       dbService,
 		version:         version,
 		anService:       anService,
-		gdsInstalled:    false,
+		calls:           newInflightCalls(),
 	}
+	neo4jServer.apocAvailable.Store(true)
+	neo4jServer.logs = &clientLogs{server: neo4jServer}
+	objectCompleter := mstr.NewObjectCompleter(neo4jServer.resourceDependencies())
+	neo4jServer.promptCompleter = prompts.NewCompleter(objectCompleter.CompleteNames)
 
 	hooks := neo4jServer.configureHooks()
 
@@ -69,11 +94,21 @@ func NewNeo4jMCPServer(version string, cfg *config.Config, dbService database.Se
 		"flow-microstrategy-mcp",
 		version,
 		server.WithToolCapabilities(true),
+		server.WithResourceCapabilities(false, false),
+		server.WithPromptCapabilities(false),
+		server.WithLogging(),
+		server.WithElicitation(),
+		server.WithCompletions(),
+		server.WithPromptCompletionProvider(neo4jServer.promptCompleter),
+		server.WithResourceCompletionProvider(objectCompleter),
 		server.WithHooks(hooks),
+		server.WithToolHandlerMiddleware(neo4jServer.calls.middleware),
 		server.WithInstructions("This is the Flow Microstrategy MCP server (powered by CI&T Flow) and can provide tool calling to interact with your Neo4j database,"+
 			"by inferring the schema with tools like get-schema and executing arbitrary Cypher queries with read-cypher."),
 	)
 
+	mcpServer.AddNotificationHandler(methodNotificationCancelled, neo4jServer.calls.handleCancelled)
+
 	neo4jServer.MCPServer = mcpServer
 
 	return neo4jServer
@@ -88,11 +123,19 @@ func (s *Neo4jMCPServer) Start() error {
 		if err := s.registerTools(); err != nil {
 			return err
 		}
+		s.registerResources()
+		if err := s.registerPrompts(); err != nil {
+			return err
+		}
 		// in case of http mode, the initialization process is delayed until the credentials are available.
 		// when the first client is performing the initialize request then the server perform
 
 		s.emitServerStartupEvent()
 
+		ctx, stopWatching := context.WithCancel(context.Background())
+		defer stopWatching()
+		go s.watchCapabilities(ctx)
+
 		return s.StartHTTPServer()
 	case config.TransportModeStdio:
 		{
@@ -105,10 +148,18 @@ func (s *Neo4jMCPServer) Start() error {
 			if err := s.registerTools(); err != nil {
 				return fmt.Errorf("failed to register tools: %w", err)
 			}
+			s.registerResources()
+			if err := s.registerPrompts(); err != nil {
+				return fmt.Errorf("failed to register prompts: %w", err)
+			}
 
 			s.emitServerStartupEvent()
 			s.emitConnectionInitializedEvent(context.Background())
 
+			ctx, stopWatching := context.WithCancel(context.Background())
+			defer stopWatching()
+			go s.watchCapabilities(ctx)
+
 			return server.ServeStdio(s.MCPServer)
 		}
 	default:
@@ -139,6 +190,7 @@ func parseAllowedOrigins(allowedOriginsStr string) []string {
 // - A valid connection with a Neo4j instance.
 // - The ability to perform a read query (database name is correctly defined).
 // - Required plugin installed: APOC (specifically apoc.meta.schema as it's used for get-schema)
+// - The MSTR full-text search index is optional: when missing a warning is logged and search tools fall back to CONTAINS matching
 // - In case GDS is not installed a flag is set in the server and tools will be registered accordingly
 func (s *Neo4jMCPServer) verifyRequirements(ctx context.Context) error {
 	err := s.dbService.VerifyConnectivity(ctx)
@@ -158,37 +210,58 @@ func (s *Neo4jMCPServer) verifyRequirements(ctx context.Context) error {
 	if !ok || one != 1 {
 		return fmt.Errorf("failed to verify connectivity with the Neo4j instance: unexpected response from test query")
 	}
-	// Check for apoc.meta.schema procedure
-	checkApocMetaSchemaQuery := "SHOW PROCEDURES YIELD name WHERE name = 'apoc.meta.schema' RETURN count(name) > 0 AS apocMetaSchemaAvailable"
+	apocAvailable, err := s.checkAPOC(ctx)
+	if err != nil {
+		return err
+	}
+	if !apocAvailable {
+		return fmt.Errorf("please ensure the APOC plugin is installed and includes the 'meta' component")
+	}
+	s.apocAvailable.Store(true)
+	s.checkSearchIndex(ctx)
+	s.gdsInstalled.Store(s.checkGDS(ctx))
 
-	// Check for apoc.meta.schema availability
-	records, err = s.dbService.ExecuteReadQuery(ctx, checkApocMetaSchemaQuery, nil)
+	return nil
+}
+
+// checkAPOC reports whether apoc.meta.schema, used by get-schema, is available
+func (s *Neo4jMCPServer) checkAPOC(ctx context.Context) (bool, error) {
+	records, err := s.dbService.ExecuteReadQuery(ctx, checkApocMetaSchemaQuery, nil)
 	if err != nil {
-		return fmt.Errorf("failed to check for APOC availability: %w", err)
+		return false, fmt.Errorf("failed to check for APOC availability: %w", err)
 	}
 	if len(records) != 1 || len(records[0].Values) != 1 {
-		return fmt.Errorf("failed to verify APOC availability: unexpected response from test query")
-	}
-	apocMetaSchemaAvailable, ok := records[0].Values[0].(bool)
-	if !ok || !apocMetaSchemaAvailable {
-		return fmt.Errorf("please ensure the APOC plugin is installed and includes the 'meta' component")
+		return false, fmt.Errorf("failed to verify APOC availability: unexpected response from test query")
 	}
-	// Call gds.version procedure to determine if GDS is installed
-	records, err = s.dbService.ExecuteReadQuery(ctx, "RETURN gds.version() as gdsVersion", nil)
+	available, _ := records[0].Values[0].(bool)
+	return available, nil
+}
+
+// checkGDS calls the gds.version procedure to determine if GDS is installed
+func (s *Neo4jMCPServer) checkGDS(ctx context.Context) bool {
+	records, err := s.dbService.ExecuteReadQuery(ctx, "RETURN gds.version() as gdsVersion", nil)
 	if err != nil {
 		// GDS is optional, so we log a warning and continue, assuming it's not installed.
 		log.Print("Impossible to verify GDS installation.")
-		s.gdsInstalled = false
-		return nil
+		return false
 	}
 	if len(records) == 1 && len(records[0].Values) == 1 {
 		_, ok := records[0].Values[0].(string)
-		if ok {
-			s.gdsInstalled = true
-		}
+		return ok
 	}
+	return false
+}
 
-	return nil
+// checkSearchIndex records whether the MSTR full-text search index is ONLINE.
+// The index is optional, so failures are only logged.
+func (s *Neo4jMCPServer) checkSearchIndex(ctx context.Context) {
+	available, err := mstr.CheckSearchIndex(ctx, s.dbService)
+	if err != nil {
+		slog.Warn("Impossible to verify the full-text search index, search tools will use CONTAINS matching", "index", mstr.SearchIndexName, "error", err)
+	} else if !available {
+		slog.Warn("Full-text search index not found or not ONLINE, search tools will use CONTAINS matching. Run with --create-search-index to create it", "index", mstr.SearchIndexName)
+	}
+	s.searchIndexAvailable.Store(available)
 }
 
 // emitServerStartupEvent emits the server startup event immediately with available info (no DB query)
@@ -429,11 +502,18 @@ func (s *Neo4jMCPServer) StartHTTPServer() error {
 func (s *Neo4jMCPServer) configureHooks() *server.Hooks {
 	hooks := &server.Hooks{}
 
+	hooks.AddBeforeCallTool(s.calls.tagRequest)
 	hooks.AddAfterCallTool(s.handleToolCallComplete)
+	hooks.AddOnUnregisterSession(s.calls.cancelSession)
+	hooks.AddOnRegisterSession(s.logs.registerSession)
+	hooks.AddAfterSetLevel(s.logs.setLevel)
 	if s.config.TransportMode == config.TransportModeHTTP {
 		hooks.AddBeforeInitialize(func(ctx context.Context, _ any, _ *mcp.InitializeRequest) {
-			// if requirements and events are already verified/sent return
+			// if requirements and events are already verified/sent return, unless a capability re-detection is due
 			if s.connectionVerified.Load() {
+				if s.refreshDue.Load() {
+					s.refreshCapabilities(ctx)
+				}
 				return
 			}
 			// lock
@@ -451,9 +531,7 @@ func (s *Neo4jMCPServer) configureHooks() *server.Hooks {
 				return
 			}
 
-			if s.gdsInstalled {
-				s.addGDSTools()
-			}
+			s.syncTools()
 
 			s.emitConnectionInitializedEvent(ctx)
 
@@ -465,13 +543,20 @@ func (s *Neo4jMCPServer) configureHooks() *server.Hooks {
 }
 
 // handleToolCallComplete is called after every tool call completes
-func (s *Neo4jMCPServer) handleToolCallComplete(_ context.Context, _ any, request *mcp.CallToolRequest, result *mcp.CallToolResult) {
+func (s *Neo4jMCPServer) handleToolCallComplete(ctx context.Context, id any, request *mcp.CallToolRequest, result any) {
+	cancelled := s.calls.takeCancelled(ctx, id)
 	if s.anService == nil || !s.anService.IsEnabled() {
 		return
 	}
 
 	toolName := request.Params.Name
-	success := !result.IsError
+	if cancelled {
+		// Cancellations are recorded apart from failures
+		s.anService.EmitEvent(s.anService.NewToolCancelledEvent(toolName))
+		return
+	}
+	callResult, _ := result.(*mcp.CallToolResult)
+	success := callResult != nil && !callResult.IsError
 
 	// Emit tool event (connection info sent separately in CONNECTION_INITIALIZED event)
 	s.anService.EmitEvent(s.anService.NewToolEvent(toolName, success))
@@ -26,6 +26,7 @@ import (
 	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/config"
 	db "github.com/brunogc-cit/flow-microstrategy-mcp/internal/database/mocks"
 	server "github.com/brunogc-cit/flow-microstrategy-mcp/internal/server"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools/mstr"
 	"github.com/mark3labs/mcp-go/client"
 	"github.com/mark3labs/mcp-go/client/transport"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -102,6 +103,7 @@ func TestNeo4jMCPServerHTTPMode(t *testing.T) {
 				},
 			},
 		}, nil)
+		mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), mstr.CheckSearchIndexQuery, gomock.Any()).Times(1).Return([]*neo4j.Record{}, nil)
 		gdsVersionQuery := "RETURN gds.version() as gdsVersion"
 		mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), gdsVersionQuery, gomock.Any()).Times(1).Return([]*neo4j.Record{
 			{
@@ -170,6 +172,7 @@ func TestNeo4jMCPServerHTTPMode(t *testing.T) {
 				},
 			},
 		}, nil)
+		mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), mstr.CheckSearchIndexQuery, gomock.Any()).Times(1).Return([]*neo4j.Record{}, nil)
 		gdsVersionQuery := "RETURN gds.version() as gdsVersion"
 		mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), gdsVersionQuery, gomock.Any()).Times(1).Return([]*neo4j.Record{
 			{
@@ -219,6 +222,7 @@ func TestNeo4jMCPServerHTTPMode(t *testing.T) {
 				},
 			},
 		}, nil)
+		mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), mstr.CheckSearchIndexQuery, gomock.Any()).Times(1).Return([]*neo4j.Record{}, nil)
 		gdsVersionQuery := "RETURN gds.version() as gdsVersion"
 		mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), gdsVersionQuery, gomock.Any()).Times(1).Return([]*neo4j.Record{
 			{
@@ -272,6 +276,7 @@ func TestNeo4jMCPServerHTTPMode(t *testing.T) {
 			},
 		}, nil)
 
+		mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), mstr.CheckSearchIndexQuery, gomock.Any()).Times(1).Return([]*neo4j.Record{}, nil)
 		gdsVersionQuery := "RETURN gds.version() as gdsVersion"
 		mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), gdsVersionQuery, gomock.Any()).Times(1).Return(nil, fmt.Errorf("Unknown function 'gds.version'"))
 
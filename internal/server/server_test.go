@@ -9,6 +9,7 @@ import (
 	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/config"
 	db "github.com/brunogc-cit/flow-microstrategy-mcp/internal/database/mocks"
 	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/server"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools/mstr"
 	"github.com/neo4j/neo4j-go-driver/v6/neo4j"
 	"go.uber.org/mock/gomock"
 )
@@ -51,6 +52,7 @@ func TestNewNeo4jMCPServer(t *testing.T) {
 				},
 			},
 		}, nil)
+		mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), mstr.CheckSearchIndexQuery, gomock.Any()).Times(1).Return([]*neo4j.Record{}, nil)
 		gdsVersionQuery := "RETURN gds.version() as gdsVersion"
 		mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), gdsVersionQuery, gomock.Any()).Times(1).Return([]*neo4j.Record{
 			{
@@ -131,6 +133,7 @@ func TestNewNeo4jMCPServer(t *testing.T) {
 				},
 			},
 		}, nil)
+		mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), mstr.CheckSearchIndexQuery, gomock.Any()).Times(1).Return([]*neo4j.Record{}, nil)
 		gdsVersionQuery := "RETURN gds.version() as gdsVersion"
 		mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), gdsVersionQuery, gomock.Any()).Times(1).Return([]*neo4j.Record{
 			{
@@ -182,6 +185,7 @@ func TestNewNeo4jMCPServer(t *testing.T) {
 				},
 			},
 		}, nil)
+		mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), mstr.CheckSearchIndexQuery, gomock.Any()).Times(1).Return([]*neo4j.Record{}, nil)
 		gdsVersionQuery := "RETURN gds.version() as gdsVersion"
 		mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), gdsVersionQuery, gomock.Any()).Times(1).Return(nil, fmt.Errorf("Unknown function 'gds.version'"))
 		mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), "CALL dbms.components()", gomock.Any()).Times(1)
@@ -217,6 +221,7 @@ func TestNewNeo4jMCPServer(t *testing.T) {
 				},
 			},
 		}, nil)
+		mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), mstr.CheckSearchIndexQuery, gomock.Any()).Times(1).Return([]*neo4j.Record{}, nil)
 		gdsVersionQuery := "RETURN gds.version() as gdsVersion"
 		mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), gdsVersionQuery, gomock.Any()).Times(1).Return([]*neo4j.Record{
 			{
@@ -272,6 +277,7 @@ func TestNewNeo4jMCPServerEvents(t *testing.T) {
 			},
 		},
 	}, nil)
+	mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), mstr.CheckSearchIndexQuery, gomock.Any()).Times(1).Return([]*neo4j.Record{}, nil)
 	gdsVersionQuery := "RETURN gds.version() as gdsVersion"
 	mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), gdsVersionQuery, gomock.Any()).AnyTimes().Return([]*neo4j.Record{
 		{
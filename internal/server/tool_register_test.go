@@ -8,6 +8,7 @@ import (
 	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/config"
 	db "github.com/brunogc-cit/flow-microstrategy-mcp/internal/database/mocks"
 	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/server"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools/mstr"
 	"github.com/neo4j/neo4j-go-driver/v6/neo4j"
 	"go.uber.org/mock/gomock"
 )
@@ -34,8 +35,8 @@ func TestToolRegister(t *testing.T) {
 		}
 		s := server.NewNeo4jMCPServer("test-version", cfg, mockDB, aService)
 
-		// 2 Cypher (get-schema, read-cypher) + 1 GDS (list-gds-procedures) + 4 MSTR = 7 total
-		expectedTotalToolsCount := 7
+		// 2 Cypher (get-schema, read-cypher) + 1 GDS (list-gds-procedures) + 17 MSTR = 20 total
+		expectedTotalToolsCount := 20
 
 		err := s.Start()
 		if err != nil {
@@ -61,8 +62,8 @@ func TestToolRegister(t *testing.T) {
 		}
 		s := server.NewNeo4jMCPServer("test-version", cfg, mockDB, aService)
 
-		// All tools are readonly, so all 7 tools are registered
-		expectedTotalToolsCount := 7
+		// All tools are readonly, so all 20 tools are registered
+		expectedTotalToolsCount := 20
 
 		err := s.Start()
 		if err != nil {
@@ -88,8 +89,8 @@ func TestToolRegister(t *testing.T) {
 		}
 		s := server.NewNeo4jMCPServer("test-version", cfg, mockDB, aService)
 
-		// 2 Cypher + 1 GDS + 4 MSTR = 7 total (no write tools exist)
-		expectedTotalToolsCount := 7
+		// 2 Cypher + 1 GDS + 17 MSTR = 20 total (no write tools exist)
+		expectedTotalToolsCount := 20
 
 		err := s.Start()
 		if err != nil {
@@ -102,6 +103,35 @@ func TestToolRegister(t *testing.T) {
 		}
 	})
 
+	t.Run("should publish an output schema for every MSTR tool", func(t *testing.T) {
+		mockDB := getMockedDBService(ctrl, true)
+		mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), "CALL dbms.components()", gomock.Any()).Times(1)
+		cfg := &config.Config{
+			URI:           "bolt://test-host:7687",
+			Username:      "neo4j",
+			Password:      "password",
+			Database:      "neo4j",
+			TransportMode: config.TransportModeStdio,
+		}
+		s := server.NewNeo4jMCPServer("test-version", cfg, mockDB, aService)
+
+		err := s.Start()
+		if err != nil {
+			t.Fatalf("Start() failed: %v", err)
+		}
+
+		// Only the Cypher and GDS tools return free-form results
+		freeForm := map[string]bool{"get-schema": true, "read-cypher": true, "list-gds-procedures": true}
+		for name, tool := range s.MCPServer.ListTools() {
+			if freeForm[name] {
+				continue
+			}
+			if tool.Tool.OutputSchema.Type != "object" || len(tool.Tool.OutputSchema.Properties) == 0 {
+				t.Errorf("Expected %s to publish an object output schema", name)
+			}
+		}
+	})
+
 	t.Run("should remove GDS tools if GDS is not present", func(t *testing.T) {
 		mockDB := getMockedDBService(ctrl, false)
 		mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), "CALL dbms.components()", gomock.Any()).Times(1)
@@ -115,8 +145,8 @@ func TestToolRegister(t *testing.T) {
 		}
 		s := server.NewNeo4jMCPServer("test-version", cfg, mockDB, aService)
 
-		// 2 Cypher + 4 MSTR = 6 total (list-gds-procedures excluded)
-		expectedTotalToolsCount := 6
+		// 2 Cypher + 17 MSTR = 19 total (list-gds-procedures excluded)
+		expectedTotalToolsCount := 19
 
 		err := s.Start()
 		if err != nil {
@@ -151,6 +181,7 @@ func getMockedDBService(ctrl *gomock.Controller, withGDS bool) *db.MockService {
 			},
 		},
 	}, nil)
+	mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), mstr.CheckSearchIndexQuery, gomock.Any()).Times(1).Return([]*neo4j.Record{}, nil)
 	gdsVersionQuery := "RETURN gds.version() as gdsVersion"
 	if withGDS {
 		mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), gdsVersionQuery, gomock.Any()).Times(1).Return([]*neo4j.Record{
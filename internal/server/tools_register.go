@@ -37,25 +37,6 @@ type ToolDefinition struct {
 	readonly   bool
 }
 
-func (s *Neo4jMCPServer) addGDSTools() {
-	deps := &tools.ToolDependencies{
-		DBService:        s.dbService,
-		AnalyticsService: s.anService,
-	}
-	toolDefs := s.getAllToolsDefs(deps)
-	toolDefinition := make([]server.ServerTool, 0)
-	GDSTools := make([]ToolDefinition, 0, len(toolDefs))
-	for _, t := range toolDefs {
-		if t.category == gdsCategory {
-			GDSTools = append(GDSTools, t)
-		}
-	}
-	for _, toolDef := range GDSTools {
-		toolDefinition = append(toolDefinition, toolDef.definition)
-	}
-	s.MCPServer.AddTools(toolDefinition...)
-}
-
 func (s *Neo4jMCPServer) getEnabledTools() []server.ServerTool {
 	filters := make([]toolFilter, 0)
 
@@ -64,13 +45,19 @@ func (s *Neo4jMCPServer) getEnabledTools() []server.ServerTool {
 		filters = append(filters, filterWriteTools)
 	}
 	// If GDS is not installed, disable GDS tools.
-	if !s.gdsInstalled {
+	if !s.gdsInstalled.Load() {
 		filters = append(filters, filterGDSTools)
 	}
+	// If APOC was lost since startup, disable get-schema (it relies on apoc.meta.schema).
+	if !s.apocAvailable.Load() {
+		filters = append(filters, filterAPOCTools)
+	}
 
 	deps := &tools.ToolDependencies{
 		DBService:        s.dbService,
 		AnalyticsService: s.anService,
+		SearchIndex:      &s.searchIndexAvailable,
+		DefaultScope:     s.config.MSTRScope,
 	}
 	toolDefs := s.getAllToolsDefs(deps)
 
@@ -104,9 +91,39 @@ func filterGDSTools(tools []ToolDefinition) []ToolDefinition {
 	return nonGDSTools
 }
 
+func filterAPOCTools(tools []ToolDefinition) []ToolDefinition {
+	nonAPOCTools := make([]ToolDefinition, 0, len(tools))
+	for _, t := range tools {
+		if t.definition.Tool.Name != "get-schema" {
+			nonAPOCTools = append(nonAPOCTools, t)
+		}
+	}
+	return nonAPOCTools
+}
+
 // getAllToolsDefs returns all available tools with their specs and handlers
 func (s *Neo4jMCPServer) getAllToolsDefs(deps *tools.ToolDependencies) []ToolDefinition {
+	toolDefs := s.getToolSpecs(deps)
+
+	// MSTR tools share progress notifications, the fields/profile output projection and the response
+	// budget; the projected (and truncated) JSON is also returned as structuredContent, conforming to the
+	// output schema of each tool
+	for i := range toolDefs {
+		if toolDefs[i].category == mstrCategory {
+			definition := mstr.WithFieldProjection(mstr.WithProgress(toolDefs[i].definition))
+			definition = tools.WithResponseBudget(definition, s.config.ResponseBudget(definition.Tool.Name))
+			toolDefs[i].definition = mstr.WithStructuredOutput(definition)
+		}
+		// read-cypher returns a list of records, cut by the budget like the lists of MSTR tools
+		if toolDefs[i].definition.Tool.Name == "read-cypher" {
+			toolDefs[i].definition = tools.WithResponseBudget(toolDefs[i].definition, s.config.ResponseBudget("read-cypher"))
+		}
+	}
+	return toolDefs
+}
 
+// getToolSpecs returns the specs and handlers of every tool
+func (s *Neo4jMCPServer) getToolSpecs(deps *tools.ToolDependencies) []ToolDefinition {
 	return []ToolDefinition{
 		// =============================================================================
 		// Read-only Cypher Tools
@@ -179,5 +196,117 @@ func (s *Neo4jMCPServer) getAllToolsDefs(deps *tools.ToolDependencies) []ToolDef
 			},
 			readonly: true,
 		},
+
+		// =============================================================================
+		// MicroStrategy Migration Tools - Analysis (consolidation and coverage)
+		// =============================================================================
+		{
+			category: mstrCategory,
+			definition: server.ServerTool{
+				Tool:    mstr.FindSimilarReportsSpec(),
+				Handler: mstr.FindSimilarReportsHandler(deps),
+			},
+			readonly: true,
+		},
+
+		// =============================================================================
+		// MicroStrategy Migration Tools - Navigation (hierarchies and definitions)
+		// =============================================================================
+		{
+			category: mstrCategory,
+			definition: server.ServerTool{
+				Tool:    mstr.GetAttributeHierarchySpec(),
+				Handler: mstr.GetAttributeHierarchyHandler(deps),
+			},
+			readonly: true,
+		},
+		{
+			category: mstrCategory,
+			definition: server.ServerTool{
+				Tool:    mstr.SearchFiltersSpec(),
+				Handler: mstr.SearchFiltersHandler(deps),
+			},
+			readonly: true,
+		},
+		{
+			category: mstrCategory,
+			definition: server.ServerTool{
+				Tool:    mstr.GetFilterSpec(),
+				Handler: mstr.GetFilterHandler(deps),
+			},
+			readonly: true,
+		},
+		{
+			category: mstrCategory,
+			definition: server.ServerTool{
+				Tool:    mstr.SearchPromptsSpec(),
+				Handler: mstr.SearchPromptsHandler(deps),
+			},
+			readonly: true,
+		},
+		{
+			category: mstrCategory,
+			definition: server.ServerTool{
+				Tool:    mstr.GetPromptSpec(),
+				Handler: mstr.GetPromptHandler(deps),
+			},
+			readonly: true,
+		},
+		{
+			category: mstrCategory,
+			definition: server.ServerTool{
+				Tool:    mstr.ListTransformationsSpec(),
+				Handler: mstr.ListTransformationsHandler(deps),
+			},
+			readonly: true,
+		},
+		{
+			category: mstrCategory,
+			definition: server.ServerTool{
+				Tool:    mstr.TraceTransformationSpec(),
+				Handler: mstr.TraceTransformationHandler(deps),
+			},
+			readonly: true,
+		},
+		{
+			category: mstrCategory,
+			definition: server.ServerTool{
+				Tool:    mstr.SemanticModelCoverageSpec(),
+				Handler: mstr.SemanticModelCoverageHandler(deps),
+			},
+			readonly: true,
+		},
+		{
+			category: mstrCategory,
+			definition: server.ServerTool{
+				Tool:    mstr.TraceColumnsSpec(),
+				Handler: mstr.TraceColumnsHandler(deps),
+			},
+			readonly: true,
+		},
+		{
+			category: mstrCategory,
+			definition: server.ServerTool{
+				Tool:    mstr.SearchByDefinitionSpec(),
+				Handler: mstr.SearchByDefinitionHandler(deps),
+			},
+			readonly: true,
+		},
+		{
+			category: mstrCategory,
+			definition: server.ServerTool{
+				Tool:    mstr.ListProjectsSpec(),
+				Handler: mstr.ListProjectsHandler(deps),
+			},
+			readonly: true,
+		},
+		{
+			category: mstrCategory,
+			definition: server.ServerTool{
+				Tool:    mstr.EssentialObjectsSpec(),
+				Handler: mstr.EssentialObjectsHandler(deps),
+			},
+			readonly: true,
+		},
 	}
 }
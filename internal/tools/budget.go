@@ -0,0 +1,299 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// bytesPerToken approximates the number of tokens of a JSON response from its size
+const bytesPerToken = 4
+
+type budgetKey struct{}
+
+// budgetPage is the cursor-paginated list of a tool call, registered by the tool so that a truncated
+// response can be continued with a cursor
+type budgetPage struct {
+	path   []string // from the result object to the page items, e.g. ("result", "reports")
+	cursor PageCursorFunc
+}
+
+// PageCursorFunc returns the cursor continuing a page cut after kept items, last being the last item kept
+type PageCursorFunc func(last map[string]any, kept int) string
+
+// truncatedList is a list of a response that was cut to fit the budget
+type truncatedList struct {
+	path    string
+	kept    int
+	omitted int
+}
+
+// WithResponseBudget truncates the JSON output of a tool that exceeds maxTokens (approximated as
+// bytes/4) at record boundaries. The cursor-paginated list is cut first and its nextCursor continues
+// after the last item kept; other lists are cut largest first. Every list cut is reported in the
+// truncated field of the response object (path, kept and omitted items), and a summary of the omitted
+// items is appended as a second text content. A maxTokens of 0 disables the budget.
+func WithResponseBudget(tool server.ServerTool, maxTokens int) server.ServerTool {
+	if maxTokens <= 0 {
+		return tool
+	}
+	handler := tool.Handler
+	name := tool.Tool.Name
+	tool.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		page := &budgetPage{}
+		result, err := handler(context.WithValue(ctx, budgetKey{}, page), request)
+		if err != nil || result == nil || result.IsError {
+			return result, err
+		}
+		truncateResult(ctx, name, result, maxTokens, page)
+		return result, nil
+	}
+	return tool
+}
+
+// RegisterBudgetPage records the cursor-paginated list of a tool call for WithResponseBudget: path leads
+// from the result object to the page items and cursor continues the page when the budget cuts it
+func RegisterBudgetPage(ctx context.Context, path []string, cursor PageCursorFunc) {
+	if page, ok := ctx.Value(budgetKey{}).(*budgetPage); ok {
+		page.path, page.cursor = path, cursor
+	}
+}
+
+// truncateResult cuts the first JSON text content of a tool result to maxTokens
+func truncateResult(ctx context.Context, tool string, result *mcp.CallToolResult, maxTokens int, page *budgetPage) {
+	maxBytes := maxTokens * bytesPerToken
+	for i, content := range result.Content {
+		text, ok := content.(mcp.TextContent)
+		if !ok {
+			continue
+		}
+		if len(text.Text) <= maxBytes {
+			return
+		}
+		decoder := json.NewDecoder(bytes.NewReader([]byte(text.Text)))
+		decoder.UseNumber() // keep integers (e.g. counts, priorities) as written
+		var value any
+		if err := decoder.Decode(&value); err != nil {
+			slog.WarnContext(ctx, "tool response exceeds the budget but is not JSON, left untouched", "tool", tool, "bytes", len(text.Text))
+			return
+		}
+
+		value, truncated, cursor := truncateValue(value, maxBytes, page)
+		data, err := json.MarshalIndent(value, "", "  ")
+		if err != nil || len(truncated) == 0 {
+			slog.WarnContext(ctx, "tool response exceeds the budget and cannot be truncated", "tool", tool, "bytes", len(text.Text))
+			return
+		}
+		slog.InfoContext(ctx, "tool response truncated to the budget", "tool", tool, "maxTokens", maxTokens, "bytes", len(text.Text), "truncatedBytes", len(data))
+
+		text.Text = string(data)
+		result.Content[i] = text
+		result.Content = append(result.Content, mcp.NewTextContent(truncationSummary(truncated, maxTokens, cursor)))
+		return
+	}
+}
+
+// truncateValue cuts the lists of a decoded response (a JSON object, a single record or a list of
+// records, as returned by read-cypher) until it fits maxBytes. Returns the truncated response, the lists
+// cut and whether a continuation cursor was set.
+func truncateValue(value any, maxBytes int, page *budgetPage) (any, []truncatedList, bool) {
+	root, _ := value.(map[string]any)
+	depth := 0
+	if records, isList := value.([]any); isList {
+		if len(records) == 1 {
+			root, _ = records[0].(map[string]any)
+			depth = 1
+		} else {
+			// Several records: the top-level list is the only one cut
+			root = map[string]any{recordsPath: records}
+			depth = -1
+		}
+	}
+	if root == nil {
+		return value, nil, false
+	}
+	marshalled := func() any {
+		if depth < 0 {
+			return root[recordsPath]
+		}
+		return value
+	}
+	size := func() int {
+		data, _ := json.MarshalIndent(marshalled(), "", "  ")
+		return len(data)
+	}
+
+	var truncated []truncatedList
+	cursor := false
+	total := size()
+	for _, list := range budgetLists(root, depth, page) {
+		if total <= maxBytes {
+			break
+		}
+		items, _ := list.parent[list.key].([]any)
+		paged := page.cursor != nil && list.path == strings.Join(page.path, ".")
+		minimum := 0
+		if paged {
+			minimum = 1 // always make progress through the pages
+		}
+		restore := continuationState(root, list.parent)
+		fit := func(kept int) {
+			list.parent[list.key] = items[:kept]
+			if depth >= 0 {
+				setTruncated(root, list.path, kept, len(items)-kept)
+			}
+			if paged {
+				restore()
+				if kept < len(items) {
+					setContinuation(root, list.parent, items[kept-1], kept, page)
+				}
+			}
+		}
+
+		// Keep the largest prefix whose omitted items cover the excess, from the item sizes measured once
+		prefix := itemPrefixSums(items, list.depth)
+		limit := prefix[len(items)] - (total - maxBytes)
+		kept := sort.Search(len(items)+1, func(k int) bool { return prefix[k] > limit }) - 1
+		kept = max(kept, minimum)
+		fit(kept)
+		// Verify with a single marshal; the cursor and separators can still overflow by a few items
+		for total = size(); total > maxBytes && kept > minimum; total = size() {
+			kept--
+			fit(kept)
+		}
+		if kept < len(items) {
+			truncated = append(truncated, truncatedList{path: list.path, kept: kept, omitted: len(items) - kept})
+			cursor = cursor || paged
+		}
+	}
+	return marshalled(), truncated, cursor
+}
+
+// recordsPath names the top-level list of a response made of several records
+const recordsPath = "records"
+
+// truncatedKey is the field of a response object listing the lists cut by the budget
+const truncatedKey = "truncated"
+
+// setTruncated records in the truncated field of root that the list at path was cut after kept items,
+// or removes the record when nothing is omitted
+func setTruncated(root map[string]any, path string, kept, omitted int) {
+	entries, _ := root[truncatedKey].([]any)
+	updated := make([]any, 0, len(entries)+1)
+	for _, entry := range entries {
+		if e, _ := entry.(map[string]any); e["path"] != path {
+			updated = append(updated, entry)
+		}
+	}
+	if omitted > 0 {
+		updated = append(updated, map[string]any{"path": path, "kept": kept, "omitted": omitted})
+	}
+	if len(updated) == 0 {
+		delete(root, truncatedKey)
+		return
+	}
+	root[truncatedKey] = updated
+}
+
+// itemPrefixSums returns the cumulated size of the items of a list, as indented at depth in the response:
+// sums[k] is the size of the first k items
+func itemPrefixSums(items []any, depth int) []int {
+	indent := strings.Repeat("  ", depth)
+	sums := make([]int, len(items)+1)
+	for i, item := range items {
+		data, _ := json.MarshalIndent(item, indent, "  ")
+		sums[i+1] = sums[i] + len(data) + len(indent) + 2 // leading newline and indent, trailing comma
+	}
+	return sums
+}
+
+// budgetList is a list of a response, referenced by its parent object
+type budgetList struct {
+	parent map[string]any
+	key    string
+	path   string
+	size   int
+	depth  int // indentation of the items in the response
+}
+
+// budgetLists returns the lists reachable through the objects of a response, root being indented at
+// depth: the cursor-paginated list first, then the others from the largest
+func budgetLists(root map[string]any, depth int, page *budgetPage) []budgetList {
+	var lists []budgetList
+	var walk func(object map[string]any, prefix string, depth int)
+	walk = func(object map[string]any, prefix string, depth int) {
+		for key, field := range object {
+			switch v := field.(type) {
+			case []any:
+				if len(v) > 0 {
+					data, _ := json.Marshal(v)
+					lists = append(lists, budgetList{parent: object, key: key, path: prefix + key, size: len(data), depth: depth + 2})
+				}
+			case map[string]any:
+				walk(v, prefix+key+".", depth+1)
+			}
+		}
+	}
+	walk(root, "", depth)
+
+	pagePath := strings.Join(page.path, ".")
+	sort.SliceStable(lists, func(i, j int) bool {
+		if (lists[i].path == pagePath) != (lists[j].path == pagePath) {
+			return lists[i].path == pagePath
+		}
+		if lists[i].size != lists[j].size {
+			return lists[i].size > lists[j].size
+		}
+		return lists[i].path < lists[j].path
+	})
+	return lists
+}
+
+// continuationState returns a function restoring the nextCursor and moreResults of a page as returned
+// by the tool
+func continuationState(root, parent map[string]any) func() {
+	cursor, hasCursor := root["nextCursor"]
+	more, hasMore := parent["moreResults"]
+	return func() {
+		delete(root, "nextCursor")
+		if hasCursor {
+			root["nextCursor"] = cursor
+		}
+		if hasMore {
+			parent["moreResults"] = more
+		}
+	}
+}
+
+// setContinuation sets the nextCursor of a page cut after kept items, last being the last item kept,
+// and its moreResults flag
+func setContinuation(root, parent map[string]any, last any, kept int, page *budgetPage) {
+	item, _ := last.(map[string]any)
+	root["nextCursor"] = page.cursor(item, kept)
+	if _, ok := parent["moreResults"]; ok {
+		parent["moreResults"] = true
+	}
+}
+
+// truncationSummary describes the items omitted from a truncated response
+func truncationSummary(truncated []truncatedList, maxTokens int, cursor bool) string {
+	parts := make([]string, len(truncated))
+	for i, list := range truncated {
+		parts[i] = fmt.Sprintf("%d of %d %s", list.omitted, list.kept+list.omitted, list.path)
+	}
+	summary := fmt.Sprintf("Response truncated to fit the budget of about %d tokens: omitted %s.", maxTokens, strings.Join(parts, ", "))
+	if cursor {
+		return summary + " Call again with cursor=nextCursor to continue, or narrow the request with fields, profile or scope."
+	}
+	if len(truncated) == 1 && truncated[0].path == recordsPath {
+		return summary + " Add a LIMIT or SKIP to the query, or return fewer properties, to see the omitted records."
+	}
+	return summary + " Narrow the request with fields, profile, scope or offset to see the omitted items."
+}
@@ -0,0 +1,109 @@
+package tools_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func callTool(t *testing.T, handler server.ToolHandlerFunc, args map[string]any) *mcp.CallToolResult {
+	t.Helper()
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = args
+	result, err := handler(context.Background(), request)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	return result
+}
+
+func resultText(t *testing.T, result *mcp.CallToolResult) string {
+	t.Helper()
+	require.NotEmpty(t, result.Content)
+	text, ok := result.Content[0].(mcp.TextContent)
+	require.True(t, ok, "expected text content")
+	return text.Text
+}
+
+func TestWithResponseBudget(t *testing.T) {
+	t.Run("cuts lists without pagination largest first", func(t *testing.T) {
+		small := make([]any, 3)
+		large := make([]any, 200)
+		for i := range small {
+			small[i] = map[string]any{"guid": fmt.Sprintf("A%d", i), "name": "Attribute"}
+		}
+		for i := range large {
+			large[i] = map[string]any{"guid": fmt.Sprintf("M%03d", i), "name": "Metric " + strings.Repeat("x", 40)}
+		}
+		data, err := json.Marshal(map[string]any{"column": "sales_amount", "direction": "reverse", "attributes": small, "metrics": large})
+		require.NoError(t, err)
+		handler := func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText(string(data)), nil
+		}
+		tool := tools.WithResponseBudget(server.ServerTool{Tool: mcp.NewTool("list-tool"), Handler: handler}, 1000)
+
+		result := callTool(t, tool.Handler, map[string]any{})
+		require.Len(t, result.Content, 2)
+		var output struct {
+			Attributes []map[string]any `json:"attributes"`
+			Metrics    []map[string]any `json:"metrics"`
+			NextCursor string           `json:"nextCursor"`
+			Truncated  []map[string]any `json:"truncated"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(resultText(t, result)), &output))
+		assert.Len(t, output.Attributes, 3)
+		assert.NotEmpty(t, output.Metrics)
+		assert.Less(t, len(output.Metrics), 200)
+		assert.Empty(t, output.NextCursor)
+		assert.Equal(t, []map[string]any{{"path": "metrics", "kept": float64(len(output.Metrics)), "omitted": float64(200 - len(output.Metrics))}}, output.Truncated,
+			"lists cut without a cursor are reported in the response")
+		summary := result.Content[1].(mcp.TextContent).Text
+		assert.Contains(t, summary, "of 200 metrics")
+		assert.NotContains(t, summary, "cursor")
+	})
+
+	t.Run("cuts a list of records to the most that fit", func(t *testing.T) {
+		records := make([]any, 100)
+		for i := range records {
+			records[i] = map[string]any{"n": map[string]any{"name": fmt.Sprintf("Node %03d", i), "labels": []any{"MSTRObject"}}}
+		}
+		data, err := json.Marshal(records)
+		require.NoError(t, err)
+		handler := func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText(string(data)), nil
+		}
+		tool := tools.WithResponseBudget(server.ServerTool{Tool: mcp.NewTool("read-cypher"), Handler: handler}, 500)
+
+		result := callTool(t, tool.Handler, map[string]any{})
+		require.Len(t, result.Content, 2)
+		var output []any
+		require.NoError(t, json.Unmarshal([]byte(resultText(t, result)), &output))
+		require.NotEmpty(t, output)
+		assert.LessOrEqual(t, len(resultText(t, result)), 2000)
+		oneMore, err := json.MarshalIndent(records[:len(output)+1], "", "  ")
+		require.NoError(t, err)
+		assert.Greater(t, len(oneMore), 2000, "keeps every record that fits")
+		summary := result.Content[1].(mcp.TextContent).Text
+		assert.Contains(t, summary, fmt.Sprintf("%d of 100 records", 100-len(output)))
+		assert.Contains(t, summary, "LIMIT")
+	})
+
+	t.Run("leaves non-JSON responses untouched", func(t *testing.T) {
+		text := strings.Repeat("lineage ", 1000)
+		handler := func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText(text), nil
+		}
+		tool := tools.WithResponseBudget(server.ServerTool{Tool: mcp.NewTool("text-tool"), Handler: handler}, 100)
+
+		result := callTool(t, tool.Handler, map[string]any{})
+		require.Len(t, result.Content, 1)
+		assert.Equal(t, text, resultText(t, result))
+	})
+}
@@ -33,32 +33,32 @@ func GetSchemaHandler(deps *tools.ToolDependencies, schemaSampleSize int32) func
 func handleGetSchema(ctx context.Context, deps *tools.ToolDependencies, schemaSampleSize int32) (*mcp.CallToolResult, error) {
 	if deps.DBService == nil {
 		errMessage := "database service is not initialized"
-		slog.Error(errMessage)
+		slog.ErrorContext(ctx, errMessage)
 		return mcp.NewToolResultError(errMessage), nil
 	}
 
-	slog.Info("retrieving schema from the database")
+	slog.InfoContext(ctx, "retrieving schema from the database")
 
 	// Execute the APOC schema query
 	records, err := deps.DBService.ExecuteReadQuery(ctx, schemaQuery, map[string]any{
 		"sampleSize": schemaSampleSize,
 	})
 	if err != nil {
-		slog.Error("failed to execute schema query", "error", err)
+		slog.ErrorContext(ctx, "failed to execute schema query", "error", err)
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 	if len(records) == 0 {
-		slog.Warn("schema is empty, no data in the database")
+		slog.WarnContext(ctx, "schema is empty, no data in the database")
 		return mcp.NewToolResultText("The get-schema tool executed successfully; however, since the Neo4j instance contains no data, no schema information was returned."), nil
 	}
 	structuredOutput, err := processCypherSchema(records)
 	if err != nil {
-		slog.Error("failed to process get-schema Cypher Query", "error", err)
+		slog.ErrorContext(ctx, "failed to process get-schema Cypher Query", "error", err)
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 	jsonData, err := json.Marshal(structuredOutput)
 	if err != nil {
-		slog.Error("failed to serialize structured schema", "error", err)
+		slog.ErrorContext(ctx, "failed to serialize structured schema", "error", err)
 		return mcp.NewToolResultError(err.Error()), nil
 
 	}
@@ -18,52 +18,52 @@ func ReadCypherHandler(deps *tools.ToolDependencies) func(context.Context, mcp.C
 func handleReadCypher(ctx context.Context, request mcp.CallToolRequest, deps *tools.ToolDependencies) (*mcp.CallToolResult, error) {
 	if deps.DBService == nil {
 		errMessage := "Database service is not initialized"
-		slog.Error(errMessage)
+		slog.ErrorContext(ctx, errMessage)
 		return mcp.NewToolResultError(errMessage), nil
 	}
 
 	var args ReadCypherInput
 
 	if err := request.BindArguments(&args); err != nil {
-		slog.Error("error binding arguments", "error", err)
+		slog.ErrorContext(ctx, "error binding arguments", "error", err)
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 	Query := args.Query
 	Params := args.Params
 
-	slog.Info("executing read cypher query", "query", Query)
+	slog.InfoContext(ctx, "executing read cypher query", "query", Query)
 
 	// Validate that query is not empty
 	if Query == "" {
 		errMessage := "Query parameter is required and cannot be empty"
-		slog.Error(errMessage)
+		slog.ErrorContext(ctx, errMessage)
 		return mcp.NewToolResultError(errMessage), nil
 	}
 
 	// Get queryType by pre-appending "EXPLAIN" to identify if the query is of type "r", if not raise a ToolResultError
 	queryType, err := deps.DBService.GetQueryType(ctx, Query, Params)
 	if err != nil {
-		slog.Error("error classifying cypher query", "error", err)
+		slog.ErrorContext(ctx, "error classifying cypher query", "error", err)
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
 	if queryType != neo4j.QueryTypeReadOnly { // only queryType == "r" are allowed in read-cypher
 		errMessage := "read-cypher can only run read-only Cypher statements. Write operations (CREATE, MERGE, DELETE, SET, etc.), schema/admin commands, and PROFILE queries are not supported."
-		slog.Error("rejected non-read query", "type", queryType, "query", Query)
+		slog.ErrorContext(ctx, "rejected non-read query", "type", queryType, "query", Query)
 		return mcp.NewToolResultError(errMessage), nil
 	}
 
 	// Execute the Cypher query using the database service (now confirmed read-only)
 	records, err := deps.DBService.ExecuteReadQuery(ctx, Query, Params)
 	if err != nil {
-		slog.Error("error executing cypher query", "error", err)
+		slog.ErrorContext(ctx, "error executing cypher query", "error", err)
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
 	// Format records to JSON
 	response, err := deps.DBService.Neo4jRecordsToJSON(records)
 	if err != nil {
-		slog.Error("error formatting query results", "error", err)
+		slog.ErrorContext(ctx, "error formatting query results", "error", err)
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
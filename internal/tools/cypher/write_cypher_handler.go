@@ -17,14 +17,14 @@ func WriteCypherHandler(deps *tools.ToolDependencies) func(context.Context, mcp.
 func handleWriteCypher(ctx context.Context, request mcp.CallToolRequest, deps *tools.ToolDependencies) (*mcp.CallToolResult, error) {
 	if deps.DBService == nil {
 		errMessage := "Database service is not initialized"
-		slog.Error(errMessage)
+		slog.ErrorContext(ctx, errMessage)
 		return mcp.NewToolResultError(errMessage), nil
 	}
 
 	var args WriteCypherInput
 	// Use our custom BindArguments that preserves integer types
 	if err := request.BindArguments(&args); err != nil {
-		slog.Error("error binding arguments", "error", err)
+		slog.ErrorContext(ctx, "error binding arguments", "error", err)
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
@@ -34,22 +34,22 @@ func handleWriteCypher(ctx context.Context, request mcp.CallToolRequest, deps *t
 	// Validate that query is not empty
 	if Query == "" {
 		errMessage := "Query parameter is required and cannot be empty"
-		slog.Error(errMessage)
+		slog.ErrorContext(ctx, errMessage)
 		return mcp.NewToolResultError(errMessage), nil
 	}
 
-	slog.Info("executing write cypher query", "query", Query)
+	slog.InfoContext(ctx, "executing write cypher query", "query", Query)
 
 	// Execute the Cypher query using the database service
 	records, err := deps.DBService.ExecuteWriteQuery(ctx, Query, Params)
 	if err != nil {
-		slog.Error("error executing cypher query", "error", err)
+		slog.ErrorContext(ctx, "error executing cypher query", "error", err)
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
 	response, err := deps.DBService.Neo4jRecordsToJSON(records)
 	if err != nil {
-		slog.Error("error formatting query results", "error", err)
+		slog.ErrorContext(ctx, "error formatting query results", "error", err)
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
@@ -25,20 +25,20 @@ func ListGdsProceduresHandler(deps *tools.ToolDependencies) func(context.Context
 func handleListGdsProcedures(ctx context.Context, deps *tools.ToolDependencies) (*mcp.CallToolResult, error) {
 	if deps.DBService == nil {
 		errMessage := "Database service is not initialized"
-		slog.Error(errMessage)
+		slog.ErrorContext(ctx, errMessage)
 		return mcp.NewToolResultError(errMessage), nil
 	}
 
 	records, err := deps.DBService.ExecuteReadQuery(ctx, listGdsProceduresQuery, nil)
 	if err != nil {
 		formattedErrorMessage := fmt.Errorf("failed to execute list-gds-procedure query: %v. Ensure that the Graph Data Science (GDS) library is installed and properly configured in your Neo4j database", err)
-		slog.Error("failed to execute list gds procedures query", "error", err)
+		slog.ErrorContext(ctx, "failed to execute list gds procedures query", "error", err)
 		return mcp.NewToolResultError(formattedErrorMessage.Error()), nil
 	}
 
 	response, err := deps.DBService.Neo4jRecordsToJSON(records)
 	if err != nil {
-		slog.Error("failed to format list-gds-procedures results to JSON", "error", err)
+		slog.ErrorContext(ctx, "failed to format list-gds-procedures results to JSON", "error", err)
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
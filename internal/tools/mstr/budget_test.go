@@ -0,0 +1,101 @@
+package mstr_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	db "github.com/brunogc-cit/flow-microstrategy-mcp/internal/database/mocks"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools/mstr"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/neo4j/neo4j-go-driver/v6/neo4j"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// upstreamRecord is a trace-metric upstream result with count reports
+func upstreamRecord(count int) *neo4j.Record {
+	reports := make([]any, count)
+	for i := range reports {
+		reports[i] = map[string]any{"type": "Report", "guid": fmt.Sprintf("R%03d", i), "name": fmt.Sprintf("Sales Report %03d", i), "area": "Retail"}
+	}
+	return &neo4j.Record{
+		Keys: []string{"result"},
+		Values: []any{map[string]any{
+			"metric":      map[string]any{"type": "Metric", "guid": "M1", "name": "Sales"},
+			"direction":   "upstream",
+			"reports":     reports,
+			"moreResults": false,
+		}},
+	}
+}
+
+// budgetTool wraps a tool the way the server registers MSTR tools, with a response budget
+func budgetTool(spec mcp.Tool, handler server.ToolHandlerFunc, maxTokens int) server.ServerTool {
+	return mstr.WithStructuredOutput(tools.WithResponseBudget(mstr.WithFieldProjection(server.ServerTool{Tool: spec, Handler: handler}), maxTokens))
+}
+
+func TestWithResponseBudget(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	traceMetricDB := func(params *[]map[string]any) *db.MockService {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, p map[string]any) ([]*neo4j.Record, error) {
+				*params = append(*params, p)
+				return []*neo4j.Record{upstreamRecord(40)}, nil
+			}).AnyTimes()
+		mockDB.EXPECT().Neo4jRecordsToJSON(gomock.Any()).DoAndReturn(recordsJSON).AnyTimes()
+		return mockDB
+	}
+
+	t.Run("leaves responses within the budget untouched", func(t *testing.T) {
+		var params []map[string]any
+		deps := &tools.ToolDependencies{DBService: traceMetricDB(&params)}
+		tool := budgetTool(mstr.TraceMetricSpec(), mstr.TraceMetricHandler(deps), 25000)
+
+		result := callTool(t, tool.Handler, map[string]any{"guid": "M1", "direction": "upstream"})
+		require.Len(t, result.Content, 1)
+		var output mstr.TraceMetricOutput
+		decodeStructured(t, tool, result, &output)
+		assert.Len(t, output.Result.Reports, 40)
+		assert.Empty(t, output.NextCursor)
+		assert.Empty(t, output.Truncated)
+	})
+
+	t.Run("truncates the page and continues with a cursor", func(t *testing.T) {
+		var params []map[string]any
+		deps := &tools.ToolDependencies{DBService: traceMetricDB(&params)}
+		tool := budgetTool(mstr.TraceMetricSpec(), mstr.TraceMetricHandler(deps), 500)
+
+		result := callTool(t, tool.Handler, map[string]any{"guid": "M1", "direction": "upstream"})
+		require.Len(t, result.Content, 2)
+		assert.LessOrEqual(t, len(resultText(t, result)), 500*4)
+
+		var output mstr.TraceMetricOutput
+		decodeStructured(t, tool, result, &output)
+		kept := len(output.Result.Reports)
+		require.Positive(t, kept)
+		require.Less(t, kept, 40)
+		assert.True(t, output.Result.MoreResults)
+		require.NotEmpty(t, output.NextCursor)
+		assert.Equal(t, "M1", output.Result.Metric.GUID, "fields outside the lists are kept")
+		assert.Equal(t, []mstr.TruncatedList{{Path: "result.reports", Kept: kept, Omitted: 40 - kept}}, output.Truncated)
+
+		summary := result.Content[1].(mcp.TextContent).Text
+		assert.Contains(t, summary, fmt.Sprintf("omitted %d of 40 result.reports", 40-kept))
+		assert.Contains(t, summary, "cursor=nextCursor")
+
+		// The cursor continues after the last report kept
+		callTool(t, tool.Handler, map[string]any{"guid": "M1", "direction": "upstream", "cursor": output.NextCursor})
+		require.Len(t, params, 2)
+		last := output.Result.Reports[kept-1]
+		assert.Equal(t, map[string]any{"name": last.Name, "guid": last.GUID}, params[1]["after"])
+		assert.Equal(t, kept, params[1]["offset"])
+	})
+}
@@ -0,0 +1,211 @@
+package mstr
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/neo4j/neo4j-go-driver/v6/neo4j"
+)
+
+const (
+	// maxCompletionValues is the maximum number of values of a completion (MCP limit)
+	maxCompletionValues = 100
+	// minCompletionPrefix is the shortest value completed from the graph, shorter values complete to nothing
+	minCompletionPrefix = 2
+	// completionCacheTTL is how long completions looked up in the graph are reused
+	completionCacheTTL = time.Minute
+	// completionCacheSize is the maximum number of completions kept in the cache
+	completionCacheSize = 512
+)
+
+// completeGUIDQuery completes the GUIDs of the objects of the given types by prefix
+const completeGUIDQuery = `
+// Complete object GUIDs by prefix
+// $types: object types accepted
+// $prefix: beginning of the GUID, as typed or upper-case (MSTR GUIDs are upper-case hexadecimal)
+// $scope: optional location prefixes (project/folder scope), null for all projects
+// $limit: maximum number of values
+
+MATCH (n:MSTRObject)
+WHERE n.type IN $types
+  AND (n.guid STARTS WITH $prefix OR n.guid STARTS WITH toUpper($prefix))
+  AND ($scope IS NULL OR any(p IN $scope WHERE replace(toLower(n.location), '\\', '/') + '/' STARTS WITH p))
+RETURN DISTINCT n.guid as value
+ORDER BY value ASC
+LIMIT $limit
+`
+
+// completeNameQuery completes the names of the objects of the given types by prefix
+const completeNameQuery = `
+// Complete object names by prefix
+// $types: object types accepted
+// $prefix: beginning of the name, matched case-insensitively
+// $scope: optional location prefixes (project/folder scope), null for all projects
+// $limit: maximum number of values
+
+MATCH (n:MSTRObject)
+WHERE n.type IN $types
+  AND toLower(n.name) STARTS WITH toLower($prefix)
+  AND ($scope IS NULL OR any(p IN $scope WHERE replace(toLower(n.location), '\\', '/') + '/' STARTS WITH p))
+RETURN DISTINCT n.name as value
+ORDER BY value ASC
+LIMIT $limit
+`
+
+// completionKey identifies a completion looked up in the graph
+type completionKey struct {
+	query  string
+	types  string
+	prefix string
+	scope  string
+}
+
+type completionEntry struct {
+	completion *mcp.Completion
+	expires    time.Time
+}
+
+// ObjectCompleter completes MSTR object GUIDs and names by prefix for the completion/complete requests
+// of the mstr:// resource templates and of the prompts. Lookups return at most maxCompletionValues
+// values and are cached for completionCacheTTL, since clients complete on every keystroke.
+type ObjectCompleter struct {
+	deps *tools.ToolDependencies
+	now  func() time.Time
+
+	mu    sync.Mutex
+	cache map[completionKey]completionEntry
+}
+
+// NewObjectCompleter returns an ObjectCompleter querying deps.DBService within the default scope
+func NewObjectCompleter(deps *tools.ToolDependencies) *ObjectCompleter {
+	return &ObjectCompleter{deps: deps, now: time.Now, cache: make(map[completionKey]completionEntry)}
+}
+
+// CompleteResourceArgument completes the guid and format variables of the mstr://{kind}/{guid}{?format}
+// resource templates; it implements server.ResourceCompletionProvider
+func (c *ObjectCompleter) CompleteResourceArgument(ctx context.Context, uri string, argument mcp.CompleteArgument, _ mcp.CompleteContext) (*mcp.Completion, error) {
+	kind, ok := objectResourceKindOf(uri)
+	if !ok {
+		return &mcp.Completion{Values: []string{}}, nil
+	}
+	switch argument.Name {
+	case "guid":
+		return c.CompleteGUIDs(ctx, kind.types, argument.Value)
+	case "format":
+		return completeValues([]string{objectCardJSON, objectCardMarkdown}, argument.Value), nil
+	}
+	return &mcp.Completion{Values: []string{}}, nil
+}
+
+// CompleteGUIDs returns the GUIDs of the objects of the given types starting with prefix
+func (c *ObjectCompleter) CompleteGUIDs(ctx context.Context, types []string, prefix string) (*mcp.Completion, error) {
+	return c.lookup(ctx, completeGUIDQuery, types, prefix)
+}
+
+// CompleteNames returns the names of the objects of the given types starting with prefix
+func (c *ObjectCompleter) CompleteNames(ctx context.Context, types []string, prefix string) (*mcp.Completion, error) {
+	return c.lookup(ctx, completeNameQuery, types, prefix)
+}
+
+// lookup runs a completion query, or returns its cached result
+func (c *ObjectCompleter) lookup(ctx context.Context, query string, types []string, prefix string) (*mcp.Completion, error) {
+	prefix = strings.TrimSpace(prefix)
+	if len(prefix) < minCompletionPrefix || c.deps.DBService == nil {
+		return &mcp.Completion{Values: []string{}}, nil
+	}
+
+	scope := scopeParam(c.deps, "")
+	key := completionKey{query: query, types: strings.Join(types, ","), prefix: strings.ToLower(prefix), scope: fmt.Sprint(scope)}
+	if completion, ok := c.cached(key); ok {
+		return completion, nil
+	}
+
+	records, err := c.deps.DBService.ExecuteReadQuery(ctx, query, map[string]any{
+		"types":  types,
+		"prefix": prefix,
+		"scope":  scope,
+		"limit":  maxCompletionValues + 1,
+	})
+	if err != nil {
+		slog.WarnContext(ctx, "completion lookup failed", "error", err)
+		return nil, fmt.Errorf("completion lookup failed: %w", err)
+	}
+
+	values := make([]string, 0, len(records))
+	for _, record := range records {
+		if value, _, err := neo4j.GetRecordValue[string](record, "value"); err == nil && value != "" {
+			values = append(values, value)
+		}
+	}
+	completion := &mcp.Completion{Values: values}
+	if len(values) > maxCompletionValues {
+		completion.Values = values[:maxCompletionValues]
+		completion.HasMore = true
+	}
+	c.store(key, completion)
+	return completion, nil
+}
+
+func (c *ObjectCompleter) cached(key completionKey) (*mcp.Completion, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.cache[key]
+	if !ok || c.now().After(entry.expires) {
+		return nil, false
+	}
+	return entry.completion, true
+}
+
+// store caches a completion; when the cache is full the expired entries are dropped, and all of them
+// when none has expired yet
+func (c *ObjectCompleter) store(key completionKey, completion *mcp.Completion) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	if len(c.cache) >= completionCacheSize {
+		for k, entry := range c.cache {
+			if now.After(entry.expires) {
+				delete(c.cache, k)
+			}
+		}
+		if len(c.cache) >= completionCacheSize {
+			clear(c.cache)
+		}
+	}
+	c.cache[key] = completionEntry{completion: completion, expires: now.Add(completionCacheTTL)}
+}
+
+// completeValues returns the allowed values starting with prefix (case-insensitive)
+func completeValues(allowed []string, prefix string) *mcp.Completion {
+	prefix = strings.ToLower(strings.TrimSpace(prefix))
+	values := make([]string, 0, len(allowed))
+	for _, value := range allowed {
+		if strings.HasPrefix(strings.ToLower(value), prefix) {
+			values = append(values, value)
+		}
+	}
+	sort.Strings(values)
+	return &mcp.Completion{Values: values}
+}
+
+// objectResourceKindOf returns the resource template kind of an mstr://{kind}/... URI or URI template
+func objectResourceKindOf(uri string) (objectResourceKind, bool) {
+	rest, ok := strings.CutPrefix(uri, "mstr://")
+	if !ok {
+		return objectResourceKind{}, false
+	}
+	name, _, _ := strings.Cut(rest, "/")
+	for _, kind := range objectResourceKinds {
+		if kind.kind == name {
+			return kind, true
+		}
+	}
+	return objectResourceKind{}, false
+}
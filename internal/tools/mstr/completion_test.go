@@ -0,0 +1,122 @@
+package mstr_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	db "github.com/brunogc-cit/flow-microstrategy-mcp/internal/database/mocks"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools/mstr"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/neo4j/neo4j-go-driver/v6/neo4j"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// completeResource sends a completion/complete request for a variable of a resource template
+func completeResource(t *testing.T, s *server.MCPServer, uri, argument, value string) mcp.Completion {
+	t.Helper()
+	message, err := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": 1, "method": "completion/complete",
+		"params": map[string]any{
+			"ref":      map[string]any{"type": "ref/resource", "uri": uri},
+			"argument": map[string]any{"name": argument, "value": value},
+		}})
+	require.NoError(t, err)
+
+	response, ok := s.HandleMessage(context.Background(), message).(mcp.JSONRPCResponse)
+	require.True(t, ok, "expected a successful response")
+	result, ok := response.Result.(mcp.CompleteResult)
+	require.True(t, ok)
+	return result.Completion
+}
+
+func valueRecords(values ...string) []*neo4j.Record {
+	records := make([]*neo4j.Record, len(values))
+	for i, value := range values {
+		records[i] = &neo4j.Record{Keys: []string{"value"}, Values: []any{value}}
+	}
+	return records
+}
+
+func TestObjectCompleter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	completionServer := func(deps *tools.ToolDependencies) *server.MCPServer {
+		s := server.NewMCPServer("test", "test",
+			server.WithResourceCapabilities(false, false),
+			server.WithCompletions(),
+			server.WithResourceCompletionProvider(mstr.NewObjectCompleter(deps)))
+		s.AddResourceTemplates(mstr.ObjectResourceTemplates(deps)...)
+		return s
+	}
+
+	t.Run("completes GUIDs by prefix and caches the lookup", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Regex("Complete object GUIDs"), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, params map[string]any) ([]*neo4j.Record, error) {
+				assert.Equal(t, "2f00", params["prefix"])
+				assert.Equal(t, []string{"Metric", "DerivedMetric"}, params["types"])
+				assert.Equal(t, []string{"/retail/", "retail/"}, params["scope"])
+				assert.Equal(t, 101, params["limit"])
+				return valueRecords("2F00974D44E1D0D24CA344ABD872806A", "2F0011AA"), nil
+			}).Times(1)
+		s := completionServer(&tools.ToolDependencies{DBService: mockDB, DefaultScope: "Retail"})
+
+		completion := completeResource(t, s, "mstr://metric/{guid}{?format}", "guid", "2f00")
+		assert.Equal(t, []string{"2F00974D44E1D0D24CA344ABD872806A", "2F0011AA"}, completion.Values)
+		assert.False(t, completion.HasMore)
+
+		cached := completeResource(t, s, "mstr://metric/{guid}{?format}", "guid", "2F00")
+		assert.Equal(t, completion.Values, cached.Values)
+	})
+
+	t.Run("caps the values", func(t *testing.T) {
+		values := make([]string, 101)
+		for i := range values {
+			values[i] = fmt.Sprintf("AB%03d", i)
+		}
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).Return(valueRecords(values...), nil)
+		s := completionServer(&tools.ToolDependencies{DBService: mockDB})
+
+		completion := completeResource(t, s, "mstr://report/{guid}{?format}", "guid", "AB")
+		assert.Len(t, completion.Values, 100)
+		assert.True(t, completion.HasMore)
+	})
+
+	t.Run("skips lookups for short prefixes", func(t *testing.T) {
+		s := completionServer(&tools.ToolDependencies{DBService: db.NewMockService(ctrl)})
+
+		completion := completeResource(t, s, "mstr://attribute/{guid}{?format}", "guid", "A")
+		assert.Empty(t, completion.Values)
+	})
+
+	t.Run("completes the card format", func(t *testing.T) {
+		s := completionServer(&tools.ToolDependencies{DBService: db.NewMockService(ctrl)})
+
+		assert.Equal(t, []string{"json", "markdown"}, completeResource(t, s, "mstr://metric/{guid}{?format}", "format", "").Values)
+		assert.Equal(t, []string{"markdown"}, completeResource(t, s, "mstr://metric/{guid}{?format}", "format", "m").Values)
+	})
+
+	t.Run("completes object names for prompts", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Regex("Complete object names"), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, params map[string]any) ([]*neo4j.Record, error) {
+				assert.Equal(t, "cust", params["prefix"])
+				assert.Nil(t, params["scope"])
+				return valueRecords("Customer", "Customer Region"), nil
+			})
+		completer := mstr.NewObjectCompleter(&tools.ToolDependencies{DBService: mockDB})
+
+		completion, err := completer.CompleteNames(context.Background(), []string{"Attribute"}, "cust")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Customer", "Customer Region"}, completion.Values)
+	})
+}
@@ -0,0 +1,182 @@
+package mstr
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools"
+	"github.com/neo4j/neo4j-go-driver/v6/neo4j"
+)
+
+// pageSize is the number of results per page of the paginated tools (queries fetch pageSize+1)
+const pageSize = 100
+
+// errCursorMismatch is returned when a cursor is reused with a different tool or different arguments
+var errCursorMismatch = errors.New("cursor does not belong to this query: call again without cursor to restart")
+
+// pageCursor is the decoded form of the opaque cursor returned as nextCursor
+type pageCursor struct {
+	// Fingerprint identifies the tool and arguments the cursor was issued for
+	Fingerprint string `json:"f"`
+	// Name and GUID are the sort key of the last item of the previous page (keyset pagination)
+	Name string `json:"n"`
+	GUID string `json:"g"`
+	// Offset is the position of the next page, for relevance-ranked results that have no stable key
+	Offset int `json:"o"`
+}
+
+// pager threads cursor pagination through a tool call. Queries receive $after ({name, guid} of the
+// last item of the previous page, or null) for keyset pagination on (name, guid) and $offset for
+// rank-ordered results: `WHERE $after IS NULL OR n.name > $after.name OR (n.name = $after.name AND
+// n.guid > $after.guid)` then `SKIP CASE WHEN $after IS NULL THEN $offset ELSE 0 END`.
+type pager struct {
+	fingerprint string
+	offset      int
+}
+
+// newPager decodes the cursor of a tool call and sets the $after and $offset parameters.
+// The fingerprint covers the tool name and every parameter except the pagination ones,
+// so a cursor cannot be replayed against a different query. Keyset pagination only applies
+// to the (name, guid) order: with another $sortBy, the cursor continues at its offset.
+func newPager(tool string, params map[string]any, cursor string) (*pager, error) {
+	return openPager(tool, params, cursor, true)
+}
+
+// newOffsetPager is newPager for results that are not ordered by (name, guid), or paged in Go:
+// queries only receive $offset, and the cursor continues at its offset.
+func newOffsetPager(tool string, params map[string]any, cursor string) (*pager, error) {
+	return openPager(tool, params, cursor, false)
+}
+
+func openPager(tool string, params map[string]any, cursor string, keyset bool) (*pager, error) {
+	delete(params, "after")
+	offset, _ := params["offset"].(int)
+	delete(params, "offset")
+	fingerprint, err := queryFingerprint(tool, params)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &pager{fingerprint: fingerprint, offset: offset}
+	if keyset {
+		params["after"] = nil
+	}
+	if cursor != "" {
+		decoded, err := decodeCursor(cursor)
+		if err != nil {
+			return nil, err
+		}
+		if decoded.Fingerprint != fingerprint {
+			return nil, errCursorMismatch
+		}
+		p.offset = decoded.Offset
+		if sortBy, _ := params["sortBy"].(string); keyset && (sortBy == "" || sortBy == sortByName) {
+			params["after"] = map[string]any{"name": decoded.Name, "guid": decoded.GUID}
+		}
+	}
+	params["offset"] = p.offset
+	return p, nil
+}
+
+// attachNextCursor adds a nextCursor column to the result record when there is a next page: the
+// moreResults flag next to the list of page items is set (queries fetch pageSize+1 to decide it), or,
+// without flag, the list holds more than pageSize items. A full last page gets no cursor.
+// path leads from the record to the list of page items, e.g. ("results") or ("result", "reports").
+// The page is also registered for tools.WithResponseBudget, which continues truncated pages with a cursor.
+func (p *pager) attachNextCursor(ctx context.Context, records []*neo4j.Record, path ...string) {
+	if len(records) != 1 || len(path) == 0 {
+		return
+	}
+	p.registerBudgetPage(ctx, path)
+	value, ok := records[0].Get(path[0])
+	more, hasMore := records[0].Get("moreResults")
+	for _, key := range path[1:] {
+		m, isMap := value.(map[string]any)
+		if !ok || !isMap {
+			return
+		}
+		value, ok = m[key]
+		more, hasMore = m["moreResults"]
+	}
+	items, _ := value.([]any)
+	if next, isBool := more.(bool); hasMore && isBool {
+		if !next {
+			return
+		}
+	} else if len(items) <= pageSize {
+		return
+	}
+	if len(items) < pageSize {
+		return
+	}
+	last, _ := items[pageSize-1].(map[string]any)
+	if last == nil {
+		return
+	}
+	name, _ := last["name"].(string)
+	guid, _ := last["guid"].(string)
+	records[0].Keys = append(records[0].Keys, "nextCursor")
+	records[0].Values = append(records[0].Values, encodeCursor(pageCursor{
+		Fingerprint: p.fingerprint,
+		Name:        name,
+		GUID:        guid,
+		Offset:      p.offset + pageSize,
+	}))
+}
+
+// nextCursor returns the nextCursor of a tool that pages in Go or by position only (no (name, guid)
+// key), or "" when more is false. path leads from the result object to the list of page items and is
+// registered for tools.WithResponseBudget; without path (several lists paged together), budget cuts
+// are only reported as truncated.
+func (p *pager) nextCursor(ctx context.Context, more bool, path ...string) string {
+	p.registerBudgetPage(ctx, path)
+	if !more {
+		return ""
+	}
+	return encodeCursor(pageCursor{Fingerprint: p.fingerprint, Offset: p.offset + pageSize})
+}
+
+// registerBudgetPage registers the page items at path for tools.WithResponseBudget: a page cut by the
+// budget continues after the last item kept. Without path, nothing is registered.
+func (p *pager) registerBudgetPage(ctx context.Context, path []string) {
+	if len(path) == 0 {
+		return
+	}
+	tools.RegisterBudgetPage(ctx, path, func(last map[string]any, kept int) string {
+		name, _ := last["name"].(string)
+		guid, _ := last["guid"].(string)
+		return encodeCursor(pageCursor{Fingerprint: p.fingerprint, Name: name, GUID: guid, Offset: p.offset + kept})
+	})
+}
+
+// queryFingerprint hashes the tool name and its query parameters (map keys are marshalled in order)
+func queryFingerprint(tool string, params map[string]any) (string, error) {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return "", fmt.Errorf("failed to fingerprint query: %w", err)
+	}
+	sum := sha256.Sum256(append([]byte(tool+"\x00"), data...))
+	return hex.EncodeToString(sum[:8]), nil
+}
+
+func encodeCursor(c pageCursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(cursor string) (pageCursor, error) {
+	var c pageCursor
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err == nil {
+		err = json.Unmarshal(data, &c)
+	}
+	if err != nil {
+		return pageCursor{}, fmt.Errorf("invalid cursor: %w", err)
+	}
+	return c, nil
+}
@@ -0,0 +1,271 @@
+package mstr_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"testing"
+
+	db "github.com/brunogc-cit/flow-microstrategy-mcp/internal/database/mocks"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools/mstr"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/neo4j/neo4j-go-driver/v6/neo4j"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// pageItems returns n page items named Item 000, Item 001, ...
+func pageItems(n int) []any {
+	items := make([]any, n)
+	for i := range items {
+		items[i] = map[string]any{"name": fmt.Sprintf("Item %03d", i), "guid": fmt.Sprintf("G%03d", i)}
+	}
+	return items
+}
+
+// searchFiltersPage calls search-filters with the given arguments and returns the params passed to the query
+// and the nextCursor column of the result (empty when absent)
+func searchFiltersPage(t *testing.T, args map[string]any, items []any) (map[string]any, string) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var params map[string]any
+	var cursor string
+	mockDB := db.NewMockService(ctrl)
+	mockDB.EXPECT().
+		ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, p map[string]any) ([]*neo4j.Record, error) {
+			params = p
+			return []*neo4j.Record{{Keys: []string{"results", "moreResults"}, Values: []any{items, len(items) > 100}}}, nil
+		}).
+		AnyTimes()
+	mockDB.EXPECT().
+		Neo4jRecordsToJSON(gomock.Any()).
+		DoAndReturn(func(records []*neo4j.Record) (string, error) {
+			if value, ok := records[0].Get("nextCursor"); ok {
+				cursor = value.(string)
+			}
+			return "[]", nil
+		}).
+		AnyTimes()
+
+	result := callTool(t, mstr.SearchFiltersHandler(&tools.ToolDependencies{DBService: mockDB}), args)
+	if result.IsError {
+		return nil, resultText(t, result)
+	}
+	return params, cursor
+}
+
+func TestCursorPagination(t *testing.T) {
+	t.Run("first page starts without a key", func(t *testing.T) {
+		params, cursor := searchFiltersPage(t, map[string]any{"query": "region"}, pageItems(101))
+		assert.Nil(t, params["after"])
+		assert.Equal(t, 0, params["offset"])
+		assert.NotEmpty(t, cursor)
+	})
+
+	t.Run("next page continues after the last item", func(t *testing.T) {
+		_, cursor := searchFiltersPage(t, map[string]any{"query": "region"}, pageItems(101))
+		params, next := searchFiltersPage(t, map[string]any{"query": "region", "cursor": cursor}, pageItems(101))
+		assert.Equal(t, map[string]any{"name": "Item 099", "guid": "G099"}, params["after"])
+		assert.Equal(t, 100, params["offset"])
+		assert.NotEqual(t, cursor, next)
+	})
+
+	t.Run("last page has no cursor", func(t *testing.T) {
+		_, cursor := searchFiltersPage(t, map[string]any{"query": "region"}, pageItems(42))
+		assert.Empty(t, cursor)
+	})
+
+	t.Run("full last page has no cursor", func(t *testing.T) {
+		_, cursor := searchFiltersPage(t, map[string]any{"query": "region"}, pageItems(100))
+		assert.Empty(t, cursor)
+	})
+
+	t.Run("rejects cursors from another query", func(t *testing.T) {
+		_, cursor := searchFiltersPage(t, map[string]any{"query": "region"}, pageItems(101))
+		params, message := searchFiltersPage(t, map[string]any{"query": "store", "cursor": cursor}, nil)
+		assert.Nil(t, params)
+		assert.Contains(t, message, "cursor does not belong to this query")
+	})
+
+	t.Run("rejects malformed cursors", func(t *testing.T) {
+		params, message := searchFiltersPage(t, map[string]any{"query": "region", "cursor": "not a cursor!"}, nil)
+		assert.Nil(t, params)
+		assert.Contains(t, message, "invalid cursor")
+	})
+
+	t.Run("trace cursors follow the direction's list", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return([]*neo4j.Record{{
+				Keys:   []string{"result"},
+				Values: []any{map[string]any{"reports": []any{}, "tables": pageItems(101), "moreResults": true}},
+			}}, nil)
+		mockDB.EXPECT().
+			Neo4jRecordsToJSON(gomock.Any()).
+			DoAndReturn(func(records []*neo4j.Record) (string, error) {
+				_, ok := records[0].Get("nextCursor")
+				assert.True(t, ok)
+				return "[]", nil
+			})
+
+		result := callTool(t, mstr.TraceMetricHandler(&tools.ToolDependencies{DBService: mockDB}), map[string]any{"guid": "ABC", "direction": "downstream"})
+		require.False(t, result.IsError, resultText(t, result))
+	})
+
+	t.Run("list tools continue with a cursor", func(t *testing.T) {
+		listTools := []struct {
+			name    string
+			handler func(*tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error)
+			args    map[string]any
+			// record returns the query record holding the page items
+			record func(items []any) *neo4j.Record
+			// keyset is set for tools ordered by (name, guid), others continue by position
+			keyset bool
+		}{
+			{
+				name:    "list-transformations",
+				handler: mstr.ListTransformationsHandler,
+				args:    map[string]any{},
+				record: func(items []any) *neo4j.Record {
+					return &neo4j.Record{Keys: []string{"results", "moreResults"}, Values: []any{items[:100], true}}
+				},
+				keyset: true,
+			},
+			{
+				name:    "get-filter",
+				handler: mstr.GetFilterHandler,
+				args:    map[string]any{"guid": "F1"},
+				record: func(items []any) *neo4j.Record {
+					return &neo4j.Record{Keys: []string{"result"}, Values: []any{map[string]any{"reports": items[:100], "moreResults": true}}}
+				},
+				keyset: true,
+			},
+			{
+				name:    "get-prompt",
+				handler: mstr.GetPromptHandler,
+				args:    map[string]any{"guid": "P1"},
+				record: func(items []any) *neo4j.Record {
+					return &neo4j.Record{Keys: []string{"result"}, Values: []any{map[string]any{"reports": items[:100], "moreResults": true}}}
+				},
+				keyset: true,
+			},
+			{
+				name:    "list-projects",
+				handler: mstr.ListProjectsHandler,
+				args:    map[string]any{},
+				record: func(items []any) *neo4j.Record {
+					return &neo4j.Record{Keys: []string{"results", "moreResults"}, Values: []any{items[:100], true}}
+				},
+			},
+			{
+				name:    "essential-objects",
+				handler: mstr.EssentialObjectsHandler,
+				args:    map[string]any{"platform": "databricks"},
+				record: func(items []any) *neo4j.Record {
+					return &neo4j.Record{Keys: []string{"result"}, Values: []any{map[string]any{"objects": items[:100], "moreResults": true}}}
+				},
+			},
+			{
+				name:    "semantic-model-coverage summary",
+				handler: mstr.SemanticModelCoverageHandler,
+				args:    map[string]any{},
+				record: func(items []any) *neo4j.Record {
+					return &neo4j.Record{Keys: []string{"result"}, Values: []any{map[string]any{"models": items[:100], "moreResults": true}}}
+				},
+			},
+			{
+				name:    "semantic-model-coverage detail",
+				handler: mstr.SemanticModelCoverageHandler,
+				args:    map[string]any{"model": "Retail"},
+				record: func(items []any) *neo4j.Record {
+					model := map[string]any{"model": "Retail", "mapped": items[:100], "unmapped": items[:3]}
+					return &neo4j.Record{Keys: []string{"result"}, Values: []any{map[string]any{"models": []any{model}, "moreResults": true}}}
+				},
+			},
+		}
+
+		for _, tt := range listTools {
+			t.Run(tt.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				var params []map[string]any
+				var cursors []string
+				mockDB := db.NewMockService(ctrl)
+				mockDB.EXPECT().
+					ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, p map[string]any) ([]*neo4j.Record, error) {
+						params = append(params, maps.Clone(p))
+						return []*neo4j.Record{tt.record(pageItems(101))}, nil
+					}).Times(2)
+				mockDB.EXPECT().
+					Neo4jRecordsToJSON(gomock.Any()).
+					DoAndReturn(func(records []*neo4j.Record) (string, error) {
+						value, _ := records[0].Get("nextCursor")
+						cursor, _ := value.(string)
+						cursors = append(cursors, cursor)
+						return "[]", nil
+					}).Times(2)
+				handler := tt.handler(&tools.ToolDependencies{DBService: mockDB})
+
+				result := callTool(t, handler, tt.args)
+				require.False(t, result.IsError, resultText(t, result))
+				require.NotEmpty(t, cursors[0])
+
+				args := maps.Clone(tt.args)
+				args["cursor"] = cursors[0]
+				result = callTool(t, handler, args)
+				require.False(t, result.IsError, resultText(t, result))
+				assert.Equal(t, 100, params[1]["offset"])
+				if tt.keyset {
+					assert.Equal(t, map[string]any{"name": "Item 099", "guid": "G099"}, params[1]["after"])
+				} else {
+					assert.NotContains(t, params[1], "after")
+				}
+			})
+		}
+	})
+
+	t.Run("offset-paged tools continue by position", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		records := make([]*neo4j.Record, 101)
+		for i := range records {
+			records[i] = &neo4j.Record{
+				Keys:   []string{"type", "guid", "name", "location", "status", "definition"},
+				Values: []any{"Metric", fmt.Sprintf("M%03d", i), fmt.Sprintf("Metric %03d", i), nil, "Planned", map[string]any{"formula": "Sum(Sales)"}},
+			}
+		}
+		var params []map[string]any
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, p map[string]any) ([]*neo4j.Record, error) {
+				params = append(params, p)
+				return records, nil
+			}).Times(2)
+		handler := mstr.SearchByDefinitionHandler(&tools.ToolDependencies{DBService: mockDB})
+
+		var output mstr.SearchByDefinitionOutput
+		result := callTool(t, handler, map[string]any{"query": "sales"})
+		require.NoError(t, json.Unmarshal([]byte(resultText(t, result)), &output))
+		require.True(t, output.MoreResults)
+		require.NotEmpty(t, output.NextCursor)
+
+		callTool(t, handler, map[string]any{"query": "sales", "cursor": output.NextCursor})
+		require.Len(t, params, 2)
+		assert.Equal(t, 100, params[1]["offset"])
+		assert.NotContains(t, params[1], "after")
+	})
+}
@@ -0,0 +1,252 @@
+package mstr
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Target platforms of the essential-objects tool
+const (
+	platformDatabricks = "databricks"
+	platformPowerBI    = "powerbi"
+)
+
+// essentialObjectTypes are the object types carrying the essential flags
+var essentialObjectTypes = []string{"Metric", "Attribute"}
+
+// EssentialObjectsInput defines the input parameters for the essential-objects tool
+type EssentialObjectsInput struct {
+	Platform   string   `json:"platform" jsonschema:"required,enum=databricks,enum=powerbi,description=Essential scope: 'databricks' (db_essential; raw/serve mappings) or 'powerbi' (pb_essential; semantic mapping)"`
+	Types      []string `json:"types,omitempty" jsonschema:"description=Optional object types: Metric and/or Attribute (default both)"`
+	Incomplete bool     `json:"incomplete,omitempty" jsonschema:"default=false,description=Only return essential objects with a missing mapping"`
+	Scope      string   `json:"scope,omitempty" jsonschema:"description=Project or folder to search in (e.g. Retail or Retail/Public Objects/Metrics; see list-projects). Defaults to the server scope; * for all projects"`
+	Offset     int      `json:"offset,omitempty" jsonschema:"default=0,description=Skip first N objects for pagination"`
+	Cursor     string   `json:"cursor,omitempty" jsonschema:"description=nextCursor from the previous page (continues at the next position: equivalent to offset+100)"`
+}
+
+// MappingFlag is a platform mapping flag of an essential object (raw/serve for Databricks, semantic for Power BI)
+type MappingFlag struct {
+	Name  string `json:"name,omitempty"`
+	Value any    `json:"value,omitempty"`
+}
+
+// EssentialObject is an object flagged as essential for the platform, with its mapping completeness
+type EssentialObject struct {
+	Type          string        `json:"type,omitempty"`
+	GUID          string        `json:"guid,omitempty"`
+	Name          string        `json:"name,omitempty"`
+	Status        string        `json:"status,omitempty"`
+	Priority      int           `json:"priority,omitempty"`
+	Mapping       []MappingFlag `json:"mapping,omitempty"`
+	Missing       []string      `json:"missing,omitempty"`
+	Complete      bool          `json:"complete,omitempty"`
+	SemanticName  string        `json:"semanticName,omitempty"`
+	SemanticModel string        `json:"semanticModel,omitempty"`
+	ReportCount   int           `json:"reportCount,omitempty"`
+	Reports       []ReportRef   `json:"reports,omitempty"`
+}
+
+// EssentialObjectsResult is the cutover checklist of a platform
+type EssentialObjectsResult struct {
+	Platform    string            `json:"platform"`
+	Total       int               `json:"total"`
+	Complete    int               `json:"complete"`
+	Incomplete  int               `json:"incomplete"`
+	Objects     []EssentialObject `json:"objects"`
+	MoreResults bool              `json:"moreResults"`
+}
+
+// EssentialObjectsOutput defines the output of the essential-objects tool
+type EssentialObjectsOutput struct {
+	Result     EssentialObjectsResult `json:"result"`
+	NextCursor string                 `json:"nextCursor,omitempty"`
+	Truncated  []TruncatedList        `json:"truncated,omitempty"`
+}
+
+const essentialObjectsQuery = `
+// Essential Metrics/Attributes of a platform with their mapping completeness
+// $platform: 'databricks' (db_essential; raw + serve) or 'powerbi' (pb_essential; semantic)
+// $types: object types to list
+// $incomplete: only list objects with a missing mapping
+// $scope: optional location prefixes (project/folder scope), null for all projects
+// $offset: pagination offset (0, 100, 200, ...)
+//
+// Flags are 'Y' in the parity matrix; 'YES' and booleans are accepted as well.
+// updated_ mapping values (ADO backlog sync) take precedence.
+
+MATCH (n:MSTRObject)
+WHERE n.type IN $types
+  AND n.guid IS NOT NULL
+  AND toUpper(toString(CASE $platform WHEN 'databricks' THEN n.db_essential ELSE n.pb_essential END)) IN ['Y', 'YES', 'TRUE']
+  AND ($scope IS NULL OR any(p IN $scope WHERE replace(toLower(n.location), '\\', '/') + '/' STARTS WITH p))
+
+// Mapping flags required by the platform
+WITH n, CASE $platform
+  WHEN 'databricks' THEN [
+    {name: 'raw', value: COALESCE(n.updated_db_raw, n.db_raw)},
+    {name: 'serve', value: COALESCE(n.updated_db_serve, n.db_serve)}
+  ]
+  ELSE [{name: 'semantic', value: n.pb_semantic}]
+END as flags
+WITH n, flags, [f IN flags WHERE NOT toUpper(toString(f.value)) IN ['Y', 'YES', 'TRUE'] OR f.value IS NULL | f.name] as missing
+
+// Incomplete objects first (the cutover backlog), then alphabetical
+ORDER BY size(missing) = 0 ASC, n.name ASC, n.guid ASC
+WITH collect({node: n, flags: flags, missing: missing}) as essential
+WITH essential,
+     size([e IN essential WHERE size(e.missing) = 0]) as complete,
+     [e IN essential WHERE NOT $incomplete OR size(e.missing) > 0][$offset..$offset + 101] as page
+
+// Prioritized reports that make each object essential (live BFS, like the upstream traces)
+CALL {
+  WITH page
+  UNWIND page as e
+  WITH e, e.node as n
+  CALL {
+    WITH n
+    OPTIONAL MATCH path = (report)-[:DEPENDS_ON*1..10]->(n)
+    WHERE report.type IN ['Report', 'GridReport', 'Document']
+      AND report.priority_level IS NOT NULL
+      AND ALL(mid IN nodes(path)[1..-1] WHERE mid.type IN ['Prompt', 'Filter'])
+    WITH DISTINCT report
+    ORDER BY report.priority_level ASC, report.name ASC
+    WITH [r IN collect({
+      name: report.name,
+      guid: report.guid,
+      priority: report.priority_level,
+      area: report.usage_area
+    }) WHERE r.guid IS NOT NULL] as reports
+    RETURN reports[0..10] as reports, size(reports) as reportCount
+  }
+  RETURN collect({
+    type: n.type,
+    guid: n.guid,
+    name: n.name,
+    status: COALESCE(n.updated_parity_status, n.parity_status, 'No Status'),
+    priority: n.inherited_priority_level,
+    mapping: e.flags,
+    missing: e.missing,
+    complete: size(e.missing) = 0,
+    semanticName: n.pb_semantic_name,
+    semanticModel: n.pb_semantic_model,
+    reportCount: reportCount,
+    reports: reports
+  }) as fetched
+}
+
+RETURN {
+  platform: $platform,
+  total: size(essential),
+  complete: complete,
+  incomplete: size(essential) - complete,
+  objects: fetched[0..100],
+  moreResults: size(fetched) > 100
+} as result
+`
+
+// EssentialObjectsSpec returns the MCP tool definition for essential-objects
+func EssentialObjectsSpec() mcp.Tool {
+	return mcp.NewTool("essential-objects",
+		mcp.WithDescription(
+			"List the essential Metrics/Attributes of a target platform (db_essential for Databricks, pb_essential for Power BI) "+
+				"with their mapping completeness and the prioritized reports that use them.\n\n"+
+				"COMPLETENESS:\n"+
+				"- databricks: raw and serve mappings (updated_ values take precedence)\n"+
+				"- powerbi: semantic mapping, with the semantic name and model\n"+
+				"Each object lists its missing mappings; total/complete/incomplete count the whole essential set.\n\n"+
+				"USE FOR:\n"+
+				"- The must-have-by-cutover checklist: essential-objects(platform=\"databricks\", incomplete=true)\n"+
+				"- Explaining why an object is essential (up to 10 prioritized reports by priority, plus reportCount)\n\n"+
+				"DO NOT USE FOR:\n"+
+				"- Full report lineage of one object (use trace-metric or trace-attribute upstream)\n"+
+				"- Power BI model coverage beyond essential objects (use semantic-model-coverage)\n\n"+
+				"PAGINATION: Returns 100 objects, incomplete first. If moreResults=true, call again with cursor=nextCursor (or offset+100).",
+		),
+		mcp.WithInputSchema[EssentialObjectsInput](),
+		mcp.WithOutputSchema[EssentialObjectsOutput](),
+		mcp.WithTitleAnnotation("Essential objects per platform"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(true),
+	)
+}
+
+// EssentialObjectsHandler returns the handler function for the essential-objects tool
+func EssentialObjectsHandler(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleEssentialObjects(ctx, deps, request)
+	}
+}
+
+func handleEssentialObjects(ctx context.Context, deps *tools.ToolDependencies, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if deps.DBService == nil {
+		errMessage := "Database service is not initialized"
+		slog.ErrorContext(ctx, errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	// Parse input
+	var input EssentialObjectsInput
+	if err := request.BindArguments(&input); err != nil {
+		slog.ErrorContext(ctx, "error binding arguments", "error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid input: %v", err)), nil
+	}
+
+	// Validate required field
+	if input.Platform == "" {
+		return mcp.NewToolResultError("platform parameter is required (must be 'databricks' or 'powerbi')"), nil
+	}
+	if input.Platform != platformDatabricks && input.Platform != platformPowerBI {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid platform '%s': must be 'databricks' or 'powerbi'", input.Platform)), nil
+	}
+
+	types := essentialObjectTypes
+	if len(input.Types) > 0 {
+		for _, t := range input.Types {
+			if !slices.Contains(essentialObjectTypes, t) {
+				return mcp.NewToolResultError(fmt.Sprintf("Invalid type '%s': must be 'Metric' or 'Attribute'", t)), nil
+			}
+		}
+		types = input.Types
+	}
+
+	params := map[string]any{
+		"platform":   input.Platform,
+		"types":      types,
+		"incomplete": input.Incomplete,
+		"scope":      scopeParam(deps, input.Scope),
+		"offset":     input.Offset,
+	}
+
+	// Incomplete objects come first: pages by position
+	pages, err := newOffsetPager("essential-objects", params, input.Cursor)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	slog.InfoContext(ctx, "executing essential-objects query", "platform", input.Platform, "types", types, "incomplete", input.Incomplete, "offset", params["offset"])
+
+	reportProgress(ctx, stageTraversing)
+	records, err := deps.DBService.ExecuteReadQuery(ctx, essentialObjectsQuery, params)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to execute essential-objects query", "error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Query execution failed: %v", err)), nil
+	}
+
+	pages.attachNextCursor(ctx, records, "result", "objects")
+
+	reportProgress(ctx, stageFormatting)
+	response, err := deps.DBService.Neo4jRecordsToJSON(records)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to format essential-objects results to JSON", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(response), nil
+}
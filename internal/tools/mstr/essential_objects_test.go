@@ -0,0 +1,60 @@
+package mstr_test
+
+import (
+	"testing"
+
+	db "github.com/brunogc-cit/flow-microstrategy-mcp/internal/database/mocks"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools/mstr"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestEssentialObjectsHandler(t *testing.T) {
+	t.Run("lists both types by default", func(t *testing.T) {
+		params := scopedParams(t, "", mstr.EssentialObjectsHandler, map[string]any{"platform": "databricks"})
+		assert.Equal(t, map[string]any{
+			"platform":   "databricks",
+			"types":      []string{"Metric", "Attribute"},
+			"incomplete": false,
+			"scope":      nil,
+			"offset":     0,
+		}, params)
+	})
+
+	t.Run("passes types and incomplete filter", func(t *testing.T) {
+		params := scopedParams(t, "Retail", mstr.EssentialObjectsHandler, map[string]any{
+			"platform":   "powerbi",
+			"types":      []any{"Metric"},
+			"incomplete": true,
+		})
+		assert.Equal(t, "powerbi", params["platform"])
+		assert.Equal(t, []string{"Metric"}, params["types"])
+		assert.Equal(t, true, params["incomplete"])
+		assert.Equal(t, []string{"/retail/", "retail/"}, params["scope"])
+	})
+
+	for name, tc := range map[string]struct {
+		args     map[string]any
+		expected string
+	}{
+		"missing platform": {map[string]any{}, "platform parameter is required"},
+		"unknown platform": {map[string]any{"platform": "snowflake"}, "Invalid platform 'snowflake'"},
+		"invalid type":     {map[string]any{"platform": "databricks", "types": []any{"Report"}}, "Invalid type 'Report'"},
+	} {
+		t.Run("rejects "+name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			result := callTool(t, mstr.EssentialObjectsHandler(&tools.ToolDependencies{DBService: db.NewMockService(ctrl)}), tc.args)
+			assert.True(t, result.IsError)
+			assert.Contains(t, resultText(t, result), tc.expected)
+		})
+	}
+
+	t.Run("nil database service", func(t *testing.T) {
+		result := callTool(t, mstr.EssentialObjectsHandler(&tools.ToolDependencies{}), map[string]any{"platform": "databricks"})
+		assert.True(t, result.IsError)
+		assert.Contains(t, resultText(t, result), "Database service is not initialized")
+	})
+}
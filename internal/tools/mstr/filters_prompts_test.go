@@ -0,0 +1,88 @@
+package mstr_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	db "github.com/brunogc-cit/flow-microstrategy-mcp/internal/database/mocks"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools/mstr"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/neo4j/neo4j-go-driver/v6/neo4j"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestFilterAndPromptHandlers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type handlerFactory func(*tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	handlers := []struct {
+		name     string
+		factory  handlerFactory
+		args     map[string]any
+		required string
+	}{
+		{name: "search-filters", factory: mstr.SearchFiltersHandler, args: map[string]any{"query": "Last Year"}, required: "query"},
+		{name: "search-prompts", factory: mstr.SearchPromptsHandler, args: map[string]any{"query": "Week"}, required: "query"},
+		{name: "get-filter", factory: mstr.GetFilterHandler, args: map[string]any{"guid": "ABC"}, required: "guid"},
+		{name: "get-prompt", factory: mstr.GetPromptHandler, args: map[string]any{"guid": "ABC"}, required: "guid"},
+	}
+
+	for _, h := range handlers {
+		t.Run(h.name+" success", func(t *testing.T) {
+			mockDB := db.NewMockService(ctrl)
+			mockDB.EXPECT().
+				ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return([]*neo4j.Record{{Keys: []string{"result"}, Values: []any{map[string]any{}}}}, nil)
+			mockDB.EXPECT().
+				Neo4jRecordsToJSON(gomock.Any()).
+				Return("[]", nil)
+
+			result := callTool(t, h.factory(&tools.ToolDependencies{DBService: mockDB}), h.args)
+			assert.False(t, result.IsError)
+		})
+
+		t.Run(h.name+" missing "+h.required, func(t *testing.T) {
+			result := callTool(t, h.factory(&tools.ToolDependencies{DBService: db.NewMockService(ctrl)}), map[string]any{})
+			assert.True(t, result.IsError)
+		})
+
+		t.Run(h.name+" query failure", func(t *testing.T) {
+			mockDB := db.NewMockService(ctrl)
+			mockDB.EXPECT().
+				ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(nil, errors.New("connection refused"))
+
+			result := callTool(t, h.factory(&tools.ToolDependencies{DBService: mockDB}), h.args)
+			assert.True(t, result.IsError)
+		})
+	}
+
+	t.Run("get-filter not found", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return([]*neo4j.Record{}, nil)
+
+		result := callTool(t, mstr.GetFilterHandler(&tools.ToolDependencies{DBService: mockDB}), map[string]any{"guid": "ABC"})
+		assert.True(t, result.IsError)
+	})
+
+	// A report reaching the object through several Prompt/Filter paths takes a single page slot
+	for _, h := range handlers[2:] {
+		t.Run(h.name+" pages distinct reports", func(t *testing.T) {
+			mockDB := db.NewMockService(ctrl)
+			mockDB.EXPECT().
+				ExecuteReadQuery(gomock.Any(), gomock.Regex(`WITH DISTINCT n, qualifies, \w+, report\s+ORDER BY report.name ASC, report.guid ASC\s+SKIP CASE WHEN \$after IS NULL THEN \$offset ELSE 0 END`), gomock.Any()).
+				Return([]*neo4j.Record{{Keys: []string{"result"}, Values: []any{map[string]any{}}}}, nil)
+			mockDB.EXPECT().Neo4jRecordsToJSON(gomock.Any()).Return("[]", nil)
+
+			result := callTool(t, h.factory(&tools.ToolDependencies{DBService: mockDB}), h.args)
+			assert.False(t, result.IsError)
+		})
+	}
+}
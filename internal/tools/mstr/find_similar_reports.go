@@ -0,0 +1,499 @@
+package mstr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/neo4j/neo4j-go-driver/v6/neo4j"
+)
+
+const (
+	defaultSimilarityThreshold = 0.8
+	maxSimilarityResults       = 100
+	similarityBatchSize        = 500 // reports compared between progress notifications
+)
+
+// FindSimilarReportsInput defines the input parameters for the find-similar-reports tool
+type FindSimilarReportsInput struct {
+	GUID      string   `json:"guid,omitempty" jsonschema:"description=Full GUID of a Report (prioritized or not) to compare against all prioritized reports. Omit to cluster all prioritized reports"`
+	Name      string   `json:"name,omitempty" jsonschema:"description=Name of the Report to compare when the GUID is not known (alternative to guid). Several matches return the candidates to pick from"`
+	Threshold *float64 `json:"threshold,omitempty" jsonschema:"default=0.8,minimum=0,maximum=1,description=Minimum Jaccard similarity (0-1) of the metric/attribute sets for two reports to be considered similar. 0 keeps every report sharing at least one object"`
+	Scope     string   `json:"scope,omitempty" jsonschema:"description=Project or folder to search in (e.g. Retail or Retail/Public Objects/Metrics; see list-projects). Defaults to the server scope; * for all projects"`
+	Offset    int      `json:"offset,omitempty" jsonschema:"default=0,description=Skip first N results (clusters or similar reports) for pagination"`
+	Cursor    string   `json:"cursor,omitempty" jsonschema:"description=nextCursor from the previous page (preferred over offset)"`
+}
+
+// FindSimilarReportsOutput defines the output of the find-similar-reports tool: report and similar
+// with guid (or name), reportsCompared, clusterCount and clusters without, ambiguous when the name
+// matches several reports
+type FindSimilarReportsOutput struct {
+	Report          *SimilarReport  `json:"report,omitempty"`
+	Threshold       float64         `json:"threshold,omitempty"`
+	Similar         []SimilarReport `json:"similar,omitempty"`
+	ReportsCompared int             `json:"reportsCompared,omitempty"`
+	ClusterCount    int             `json:"clusterCount,omitempty"`
+	Clusters        []ReportCluster `json:"clusters,omitempty"`
+	MoreResults     bool            `json:"moreResults,omitempty"`
+	NextCursor      string          `json:"nextCursor,omitempty"`
+	Ambiguous       *AmbiguousName  `json:"ambiguous,omitempty"`
+	Truncated       []TruncatedList `json:"truncated,omitempty"`
+}
+
+// findSimilarReportsQuery fetches the metric/attribute set of every prioritized report.
+// Similarity is computed in Go: gds.nodeSimilarity requires projecting an in-memory graph,
+// which is not available in read-only deployments, while the fetched sets are small enough
+// to compare pairwise.
+const findSimilarReportsQuery = `
+// Fetch metric/attribute dependency sets for prioritized reports
+// $scope: optional location prefixes (project/folder scope), null for all projects
+//
+// LIVE TRAVERSAL: Follows outgoing DEPENDS_ON relationships from each report,
+// only passing through [Prompt, Filter] intermediate nodes (canonical dashboard pattern),
+// mirroring the upstream traversal used by trace-metric and trace-attribute.
+
+MATCH (report:MSTRObject)
+WHERE report.type IN ['Report', 'GridReport', 'Document']
+  AND report.priority_level IS NOT NULL
+  AND report.guid IS NOT NULL
+  AND ($scope IS NULL OR any(p IN $scope WHERE replace(toLower(report.location), '\\', '/') + '/' STARTS WITH p))
+
+MATCH path = (report)-[:DEPENDS_ON*1..10]->(obj)
+WHERE obj.type IN ['Metric', 'Attribute']
+  AND ALL(mid IN nodes(path)[1..-1] WHERE mid.type IN ['Prompt', 'Filter'])
+
+WITH report, collect(DISTINCT obj.guid) as objects
+RETURN
+  report.guid as guid,
+  report.name as name,
+  report.type as type,
+  report.priority_level as priority,
+  report.usage_area as area,
+  objects
+ORDER BY guid
+`
+
+// findSimilarReferenceQuery fetches the metric/attribute set of the reference report, which does not
+// need a priority_level nor to be in scope: it is compared against the prioritized reports in scope
+const findSimilarReferenceQuery = `
+// Fetch the metric/attribute dependency set of one report
+// $guid: Full GUID of the Report, Grid Report or Document
+//
+// LIVE TRAVERSAL: Same Prompt/Filter path filter as the prioritized reports.
+
+MATCH (report:MSTRObject {guid: $guid})
+WHERE report.type IN ['Report', 'GridReport', 'Document']
+
+OPTIONAL MATCH path = (report)-[:DEPENDS_ON*1..10]->(obj)
+WHERE obj.type IN ['Metric', 'Attribute']
+  AND ALL(mid IN nodes(path)[1..-1] WHERE mid.type IN ['Prompt', 'Filter'])
+
+WITH report, collect(DISTINCT obj.guid) as objects
+RETURN
+  report.guid as guid,
+  report.name as name,
+  report.type as type,
+  report.priority_level as priority,
+  report.usage_area as area,
+  objects
+`
+
+// reportDependencySet is a report together with the GUIDs of the metrics/attributes it uses
+type reportDependencySet struct {
+	GUID     string
+	Name     string
+	Type     string
+	Priority any
+	Area     any
+	Objects  map[string]struct{}
+}
+
+// SimilarReport describes a report and its similarity to a reference report
+type SimilarReport struct {
+	GUID          string  `json:"guid,omitempty"`
+	Name          string  `json:"name,omitempty"`
+	Type          string  `json:"type,omitempty"`
+	Priority      any     `json:"priority,omitempty"`
+	Area          any     `json:"area,omitempty"`
+	ObjectCount   int     `json:"objectCount,omitempty"`
+	SharedObjects int     `json:"sharedObjects,omitempty"`
+	Similarity    float64 `json:"similarity,omitempty"`
+}
+
+// ReportCluster is a group of reports connected by pairwise similarities above the threshold
+type ReportCluster struct {
+	Size          int             `json:"size"`
+	MinSimilarity float64         `json:"minSimilarity"`
+	MaxSimilarity float64         `json:"maxSimilarity"`
+	SharedObjects int             `json:"sharedObjects"`
+	Reports       []SimilarReport `json:"reports"`
+}
+
+// FindSimilarReportsSpec returns the MCP tool definition for find-similar-reports
+func FindSimilarReportsSpec() mcp.Tool {
+	return mcp.NewTool("find-similar-reports",
+		mcp.WithDescription(
+			"Find consolidation candidates among PRIORITIZED reports using Jaccard similarity of their metric/attribute sets.\n\n"+
+				"MODES:\n"+
+				"- With guid (or name): list prioritized reports similar to the given report (prioritized or not), most similar first\n"+
+				"- Without guid or name: cluster all prioritized reports whose pairwise similarity is above the threshold\n\n"+
+				"USE FOR:\n"+
+				"- Consolidating near-copy reports before rebuilding them in Power BI: find-similar-reports(threshold=0.9)\n"+
+				"- Checking whether a report duplicates others: find-similar-reports(guid=\"A1B2C3D4...\")\n\n"+
+				"DO NOT USE FOR:\n"+
+				"- Finding which reports use a metric or attribute (use trace-metric/trace-attribute upstream instead)\n\n"+
+				"NOTE: Only reports with priority_level are compared against; the given report can be any report. Dependencies are collected through Prompt/Filter objects, like the upstream traces.\n\n"+
+				"NAMES: When name matches several reports, the user is asked to pick one if the client supports it; "+
+				"otherwise 'ambiguous' lists the candidates: call again with the guid of the intended one.\n\n"+
+				"PAGINATION: Returns 100 results. If moreResults=true, call again with cursor=nextCursor (or offset+100).",
+		),
+		mcp.WithInputSchema[FindSimilarReportsInput](),
+		mcp.WithOutputSchema[FindSimilarReportsOutput](),
+		mcp.WithTitleAnnotation("Find similar reports for consolidation"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(true),
+	)
+}
+
+// FindSimilarReportsHandler returns the handler function for the find-similar-reports tool
+func FindSimilarReportsHandler(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleFindSimilarReports(ctx, deps, request)
+	}
+}
+
+func handleFindSimilarReports(ctx context.Context, deps *tools.ToolDependencies, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if deps.DBService == nil {
+		errMessage := "Database service is not initialized"
+		slog.ErrorContext(ctx, errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	// Parse input
+	var input FindSimilarReportsInput
+	if err := request.BindArguments(&input); err != nil {
+		slog.ErrorContext(ctx, "error binding arguments", "error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid input: %v", err)), nil
+	}
+
+	threshold := defaultSimilarityThreshold
+	if input.Threshold != nil {
+		threshold = *input.Threshold
+	}
+	if threshold < 0 || threshold > 1 {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid threshold %v: must be between 0 and 1", threshold)), nil
+	}
+	if input.Offset < 0 {
+		return mcp.NewToolResultError("offset must not be negative"), nil
+	}
+	if input.GUID != "" && input.Name != "" {
+		return mcp.NewToolResultError("only one of guid or name parameters is supported"), nil
+	}
+
+	params := map[string]any{
+		"guid":      input.GUID,
+		"name":      input.Name,
+		"threshold": threshold,
+		"scope":     scopeParam(deps, input.Scope),
+		"offset":    input.Offset,
+	}
+	pages, err := newPager("find-similar-reports", params, input.Cursor)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	offset := params["offset"].(int)
+
+	if input.Name != "" {
+		guid, ambiguous, err := resolveName(ctx, deps, "Report", input.Name, input.Scope)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if ambiguous != nil {
+			return ambiguousNameResult(ctx, ambiguous), nil
+		}
+		input.GUID = guid
+	}
+
+	slog.InfoContext(ctx, "executing find-similar-reports query", "guid", input.GUID, "threshold", threshold, "offset", offset)
+
+	reportProgress(ctx, stageTraversing)
+	var reference *reportDependencySet
+	if input.GUID != "" {
+		records, err := deps.DBService.ExecuteReadQuery(ctx, findSimilarReferenceQuery, map[string]any{"guid": input.GUID})
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to execute find-similar-reports reference query", "error", err)
+			return mcp.NewToolResultError(fmt.Sprintf("Query execution failed: %v", err)), nil
+		}
+		if len(records) == 0 {
+			return mcp.NewToolResultError(fmt.Sprintf("Report with GUID %s not found", input.GUID)), nil
+		}
+		references, err := processReportDependencySets(records)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to process find-similar-reports reference", "error", err)
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		reference = &references[0]
+	}
+
+	records, err := deps.DBService.ExecuteReadQuery(ctx, findSimilarReportsQuery, map[string]any{"scope": scopeParam(deps, input.Scope)})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to execute find-similar-reports query", "error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Query execution failed: %v", err)), nil
+	}
+
+	reports, err := processReportDependencySets(records)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to process find-similar-reports results", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	reportProgress(ctx, stageComparing)
+	var response map[string]any
+	var listKey string
+	var more bool
+	if reference != nil {
+		similar := findReportsSimilarTo(*reference, reports, threshold)
+		var page []SimilarReport
+		page, more = paginate(similar, offset, maxSimilarityResults)
+		listKey = "similar"
+		response = map[string]any{
+			"report":      toSimilarReport(*reference, 0, 0),
+			"threshold":   threshold,
+			"similar":     page,
+			"moreResults": more,
+		}
+	} else {
+		clusters := clusterSimilarReports(ctx, reports, threshold)
+		var page []ReportCluster
+		page, more = paginate(clusters, offset, maxSimilarityResults)
+		listKey = "clusters"
+		response = map[string]any{
+			"threshold":       threshold,
+			"reportsCompared": len(reports),
+			"clusterCount":    len(clusters),
+			"clusters":        page,
+			"moreResults":     more,
+		}
+	}
+
+	if cursor := pages.nextCursor(ctx, more, listKey); cursor != "" {
+		response["nextCursor"] = cursor
+	}
+
+	reportProgress(ctx, stageFormatting)
+	jsonData, err := json.MarshalIndent(response, "", "  ")
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to format find-similar-reports results to JSON", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(string(jsonData)), nil
+}
+
+// processReportDependencySets converts the query records into report dependency sets
+func processReportDependencySets(records []*neo4j.Record) ([]reportDependencySet, error) {
+	reports := make([]reportDependencySet, 0, len(records))
+	for _, record := range records {
+		guid, _, err := neo4j.GetRecordValue[string](record, "guid")
+		if err != nil {
+			return nil, fmt.Errorf("invalid 'guid' column in record: %w", err)
+		}
+		name, _, _ := neo4j.GetRecordValue[string](record, "name")
+		reportType, _, _ := neo4j.GetRecordValue[string](record, "type")
+		priority, _ := record.Get("priority")
+		area, _ := record.Get("area")
+
+		rawObjects, ok := record.Get("objects")
+		if !ok {
+			return nil, fmt.Errorf("missing 'objects' column in record")
+		}
+		objectList, ok := rawObjects.([]any)
+		if !ok {
+			return nil, fmt.Errorf("invalid 'objects' column in record")
+		}
+		objects := make(map[string]struct{}, len(objectList))
+		for _, o := range objectList {
+			if s, ok := o.(string); ok {
+				objects[s] = struct{}{}
+			}
+		}
+
+		reports = append(reports, reportDependencySet{
+			GUID:     guid,
+			Name:     name,
+			Type:     reportType,
+			Priority: priority,
+			Area:     area,
+			Objects:  objects,
+		})
+	}
+	return reports, nil
+}
+
+// jaccard returns the Jaccard similarity of two sets and the size of their intersection
+func jaccard(a, b map[string]struct{}) (float64, int) {
+	if len(a) == 0 && len(b) == 0 {
+		return 0, 0
+	}
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	shared := 0
+	for k := range a {
+		if _, ok := b[k]; ok {
+			shared++
+		}
+	}
+	union := len(a) + len(b) - shared
+	return float64(shared) / float64(union), shared
+}
+
+// findReportsSimilarTo returns all reports whose similarity with the reference is at least threshold,
+// most similar first.
+func findReportsSimilarTo(reference reportDependencySet, reports []reportDependencySet, threshold float64) []SimilarReport {
+	similar := make([]SimilarReport, 0)
+	for _, r := range reports {
+		if r.GUID == reference.GUID {
+			continue
+		}
+		score, shared := jaccard(reference.Objects, r.Objects)
+		if score >= threshold && shared > 0 {
+			similar = append(similar, toSimilarReport(r, score, shared))
+		}
+	}
+	sort.SliceStable(similar, func(i, j int) bool {
+		if similar[i].Similarity != similar[j].Similarity {
+			return similar[i].Similarity > similar[j].Similarity
+		}
+		return similar[i].Name < similar[j].Name
+	})
+	return similar
+}
+
+// clusterSimilarReports groups reports into connected components of the similarity graph,
+// where an edge exists between two reports whose similarity is at least threshold.
+// Singleton components are dropped; clusters are ordered by size, then by maximum similarity.
+// Progress is reported per batch of similarityBatchSize reports compared.
+func clusterSimilarReports(ctx context.Context, reports []reportDependencySet, threshold float64) []ReportCluster {
+	parent := make([]int, len(reports))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	type edge struct {
+		i, j  int
+		score float64
+	}
+	edges := make([]edge, 0)
+	for i := 0; i < len(reports); i++ {
+		if i > 0 && i%similarityBatchSize == 0 {
+			reportBatchProgress(ctx, stageComparing, i, len(reports))
+		}
+		for j := i + 1; j < len(reports); j++ {
+			score, shared := jaccard(reports[i].Objects, reports[j].Objects)
+			if shared == 0 || score < threshold {
+				continue
+			}
+			edges = append(edges, edge{i: i, j: j, score: score})
+			if ri, rj := find(i), find(j); ri != rj {
+				parent[rj] = ri
+			}
+		}
+	}
+
+	members := make(map[int][]int)
+	for i := range reports {
+		root := find(i)
+		members[root] = append(members[root], i)
+	}
+
+	clusters := make([]ReportCluster, 0)
+	clusterIndex := make(map[int]int)
+	for root, idx := range members {
+		if len(idx) < 2 {
+			continue
+		}
+		shared := make(map[string]struct{}, len(reports[idx[0]].Objects))
+		for k := range reports[idx[0]].Objects {
+			shared[k] = struct{}{}
+		}
+		clusterReports := make([]SimilarReport, 0, len(idx))
+		for _, i := range idx {
+			for k := range shared {
+				if _, ok := reports[i].Objects[k]; !ok {
+					delete(shared, k)
+				}
+			}
+			clusterReports = append(clusterReports, toSimilarReport(reports[i], 0, 0))
+		}
+		sort.SliceStable(clusterReports, func(a, b int) bool {
+			return clusterReports[a].Name < clusterReports[b].Name
+		})
+		clusterIndex[root] = len(clusters)
+		clusters = append(clusters, ReportCluster{
+			Size:          len(idx),
+			MinSimilarity: 1,
+			SharedObjects: len(shared),
+			Reports:       clusterReports,
+		})
+	}
+
+	for _, e := range edges {
+		c := &clusters[clusterIndex[find(e.i)]]
+		if e.score < c.MinSimilarity {
+			c.MinSimilarity = e.score
+		}
+		if e.score > c.MaxSimilarity {
+			c.MaxSimilarity = e.score
+		}
+	}
+
+	sort.SliceStable(clusters, func(i, j int) bool {
+		if clusters[i].Size != clusters[j].Size {
+			return clusters[i].Size > clusters[j].Size
+		}
+		if clusters[i].MaxSimilarity != clusters[j].MaxSimilarity {
+			return clusters[i].MaxSimilarity > clusters[j].MaxSimilarity
+		}
+		return clusters[i].Reports[0].Name < clusters[j].Reports[0].Name
+	})
+	return clusters
+}
+
+func toSimilarReport(r reportDependencySet, score float64, shared int) SimilarReport {
+	return SimilarReport{
+		GUID:          r.GUID,
+		Name:          r.Name,
+		Type:          r.Type,
+		Priority:      r.Priority,
+		Area:          r.Area,
+		ObjectCount:   len(r.Objects),
+		SharedObjects: shared,
+		Similarity:    score,
+	}
+}
+
+// paginate returns the page of items starting at offset and whether more items exist after it
+func paginate[T any](items []T, offset, limit int) ([]T, bool) {
+	if offset >= len(items) {
+		return []T{}, false
+	}
+	end := offset + limit
+	if end >= len(items) {
+		return items[offset:], false
+	}
+	return items[offset:end], true
+}
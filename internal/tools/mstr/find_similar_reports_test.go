@@ -0,0 +1,170 @@
+package mstr_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	db "github.com/brunogc-cit/flow-microstrategy-mcp/internal/database/mocks"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools/mstr"
+	"github.com/neo4j/neo4j-go-driver/v6/neo4j"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func reportRecord(guid, name string, objects ...string) *neo4j.Record {
+	objs := make([]any, 0, len(objects))
+	for _, o := range objects {
+		objs = append(objs, o)
+	}
+	return &neo4j.Record{
+		Keys:   []string{"guid", "name", "type", "priority", "area", "objects"},
+		Values: []any{guid, name, "Report", int64(1), "Retail", objs},
+	}
+}
+
+func similarityRecords() []*neo4j.Record {
+	return []*neo4j.Record{
+		reportRecord("R1", "Sales Daily", "M1", "M2", "A1", "A2"),
+		reportRecord("R2", "Sales Daily Copy", "M1", "M2", "A1", "A2"),
+		reportRecord("R3", "Sales Daily v2", "M1", "M2", "A1", "A2", "A3"),
+		reportRecord("R4", "Stock Overview", "M9", "A9"),
+	}
+}
+
+// expectSimilarTo expects the reference report query for guid, answering with reference (none when nil),
+// then the prioritized reports query
+func expectSimilarTo(mockDB *db.MockService, guid string, reference *neo4j.Record) {
+	referenceRecords := []*neo4j.Record{}
+	if reference != nil {
+		referenceRecords = append(referenceRecords, reference)
+	}
+	mockDB.EXPECT().
+		ExecuteReadQuery(gomock.Any(), gomock.Regex("dependency set of one report"), gomock.Eq(map[string]any{"guid": guid})).
+		Return(referenceRecords, nil)
+	if reference != nil {
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Regex("dependency sets for prioritized reports"), gomock.Any()).
+			Return(similarityRecords(), nil)
+	}
+}
+
+func TestFindSimilarReportsHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("clusters near-copy reports", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(similarityRecords(), nil)
+
+		handler := mstr.FindSimilarReportsHandler(&tools.ToolDependencies{DBService: mockDB})
+		result := callTool(t, handler, map[string]any{"threshold": 0.75})
+		require.False(t, result.IsError, resultText(t, result))
+
+		var response struct {
+			ClusterCount int                  `json:"clusterCount"`
+			Clusters     []mstr.ReportCluster `json:"clusters"`
+			MoreResults  bool                 `json:"moreResults"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(resultText(t, result)), &response))
+		require.Equal(t, 1, response.ClusterCount)
+		cluster := response.Clusters[0]
+		assert.Equal(t, 3, cluster.Size)
+		assert.Equal(t, 4, cluster.SharedObjects)
+		assert.InDelta(t, 0.8, cluster.MinSimilarity, 0.0001)
+		assert.InDelta(t, 1.0, cluster.MaxSimilarity, 0.0001)
+		assert.False(t, response.MoreResults)
+	})
+
+	t.Run("ranks reports similar to a given report", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		expectSimilarTo(mockDB, "R3", reportRecord("R3", "Sales Daily v2", "M1", "M2", "A1", "A2", "A3"))
+
+		handler := mstr.FindSimilarReportsHandler(&tools.ToolDependencies{DBService: mockDB})
+		result := callTool(t, handler, map[string]any{"guid": "R3", "threshold": 0.5})
+		require.False(t, result.IsError, resultText(t, result))
+
+		var response struct {
+			Similar []mstr.SimilarReport `json:"similar"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(resultText(t, result)), &response))
+		require.Len(t, response.Similar, 2)
+		assert.Equal(t, "Sales Daily", response.Similar[0].Name)
+		assert.Equal(t, 4, response.Similar[0].SharedObjects)
+		assert.InDelta(t, 0.8, response.Similar[0].Similarity, 0.0001)
+	})
+
+	t.Run("compares a report without priority against the prioritized ones", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		expectSimilarTo(mockDB, "R9", &neo4j.Record{
+			Keys:   []string{"guid", "name", "type", "priority", "area", "objects"},
+			Values: []any{"R9", "Sales Weekly", "GridReport", nil, nil, []any{"M1", "M2", "A1", "A2"}},
+		})
+
+		handler := mstr.FindSimilarReportsHandler(&tools.ToolDependencies{DBService: mockDB})
+		result := callTool(t, handler, map[string]any{"guid": "R9"})
+		require.False(t, result.IsError, resultText(t, result))
+
+		var response mstr.FindSimilarReportsOutput
+		require.NoError(t, json.Unmarshal([]byte(resultText(t, result)), &response))
+		require.NotNil(t, response.Report)
+		assert.Equal(t, "Sales Weekly", response.Report.Name)
+		require.Len(t, response.Similar, 3)
+		assert.InDelta(t, 1.0, response.Similar[0].Similarity, 0.0001)
+	})
+
+	t.Run("unknown report guid", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		expectSimilarTo(mockDB, "UNKNOWN", nil)
+
+		handler := mstr.FindSimilarReportsHandler(&tools.ToolDependencies{DBService: mockDB})
+		result := callTool(t, handler, map[string]any{"guid": "UNKNOWN"})
+		assert.True(t, result.IsError)
+	})
+
+	t.Run("zero threshold keeps every overlapping report", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		expectSimilarTo(mockDB, "R3", similarityRecords()[2])
+
+		handler := mstr.FindSimilarReportsHandler(&tools.ToolDependencies{DBService: mockDB})
+		result := callTool(t, handler, map[string]any{"guid": "R3", "threshold": 0})
+		require.False(t, result.IsError, resultText(t, result))
+
+		var response struct {
+			Threshold *float64             `json:"threshold"`
+			Similar   []mstr.SimilarReport `json:"similar"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(resultText(t, result)), &response))
+		require.NotNil(t, response.Threshold)
+		assert.Zero(t, *response.Threshold)
+		assert.Len(t, response.Similar, 2)
+	})
+
+	t.Run("invalid threshold", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		handler := mstr.FindSimilarReportsHandler(&tools.ToolDependencies{DBService: mockDB})
+		result := callTool(t, handler, map[string]any{"threshold": 1.5})
+		assert.True(t, result.IsError)
+	})
+
+	t.Run("database query execution failure", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("connection refused"))
+
+		handler := mstr.FindSimilarReportsHandler(&tools.ToolDependencies{DBService: mockDB})
+		result := callTool(t, handler, map[string]any{})
+		assert.True(t, result.IsError)
+	})
+
+	t.Run("nil database service", func(t *testing.T) {
+		handler := mstr.FindSimilarReportsHandler(&tools.ToolDependencies{DBService: nil})
+		result := callTool(t, handler, map[string]any{})
+		assert.True(t, result.IsError)
+	})
+}
@@ -0,0 +1,261 @@
+package mstr
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools"
+	"github.com/neo4j/neo4j-go-driver/v6/neo4j"
+)
+
+// Search modes accepted by search-metrics and search-attributes
+const (
+	searchModeExact = "exact"
+	searchModeFuzzy = "fuzzy"
+)
+
+const (
+	// fuzzyPrefixScore is the similarity of a query term that is a prefix of a name term ("ret" -> "retail")
+	fuzzyPrefixScore = 0.9
+	// fuzzyAbbreviationScore is the similarity of a query term whose letters appear in order in a name term,
+	// starting with the same letter ("amt" -> "amount", "qty" -> "quantity")
+	fuzzyAbbreviationScore = 0.7
+	// fuzzyMinTermScore is the minimum edit-distance similarity for a term to match
+	fuzzyMinTermScore = 0.6
+)
+
+// maxFuzzyCandidates bounds the names ranked in Go when the full-text index is not available
+const maxFuzzyCandidates = 20000
+
+// errTooManyFuzzyCandidates is returned when fuzzy search without the full-text index would rank more
+// than maxFuzzyCandidates names
+var errTooManyFuzzyCandidates = fmt.Errorf("fuzzy search without the full-text index %s ranks at most %d names", SearchIndexName, maxFuzzyCandidates)
+
+// validateSearchMode checks the mode input of the search tools
+func validateSearchMode(mode string) error {
+	switch mode {
+	case "", searchModeExact, searchModeFuzzy:
+		return nil
+	}
+	return fmt.Errorf("invalid mode %q: expected %q or %q", mode, searchModeExact, searchModeFuzzy)
+}
+
+// executeFuzzySearch runs a typo-tolerant, multi-term search. With the full-text index, the query is
+// translated into Lucene fuzzy terms; otherwise every candidate name (at most maxFuzzyCandidates) is
+// scored in Go and the page of best matches is fetched by GUID. GUID-like queries use the regular search.
+// The returned facetsSource counts the same match set.
+func executeFuzzySearch(ctx context.Context, deps *tools.ToolDependencies, queries searchQueries, params map[string]any) ([]*neo4j.Record, facetsSource, error) {
+	query, _ := params["query"].(string)
+	if guidLikePattern.MatchString(query) {
+		return executeRankedSearch(ctx, deps, queries, params)
+	}
+	terms := parseSearchTerms(query)
+
+	if useSearchIndex(deps, query) {
+		ftParams := make(map[string]any, len(params)+2)
+		for k, v := range params {
+			ftParams[k] = v
+		}
+		ftParams["index"] = SearchIndexName
+		ftParams["ftQuery"] = buildFuzzyFullTextQuery(terms)
+		records, err := deps.DBService.ExecuteReadQuery(ctx, queries.fullText, ftParams)
+		if err == nil {
+			return records, facetsSource{query: queries.fullTextFacets, params: ftParams}, nil
+		}
+		slog.WarnContext(ctx, "full-text fuzzy search failed, falling back to Go ranking", "index", SearchIndexName, "error", err)
+		if strings.Contains(err.Error(), SearchIndexName) {
+			deps.SearchIndex.Store(false)
+		}
+	}
+
+	records, err := deps.DBService.ExecuteReadQuery(ctx, queries.candidates, map[string]any{
+		"status": params["status"],
+		"scope":  params["scope"],
+		"limit":  maxFuzzyCandidates + 1,
+	})
+	if err != nil {
+		return nil, facetsSource{}, err
+	}
+	if len(records) > maxFuzzyCandidates {
+		return nil, facetsSource{}, fmt.Errorf("%w: narrow the search with scope or status, use mode=exact, or create the index with --create-search-index", errTooManyFuzzyCandidates)
+	}
+
+	reportProgress(ctx, stageRanking)
+	type scored struct {
+		guid  string
+		name  string
+		score float64
+	}
+	matches := make([]scored, 0)
+	for _, record := range records {
+		guid, _, err := neo4j.GetRecordValue[string](record, "guid")
+		if err != nil {
+			return nil, facetsSource{}, fmt.Errorf("invalid 'guid' column in record: %w", err)
+		}
+		name, _, _ := neo4j.GetRecordValue[string](record, "name")
+		if score := fuzzyScore(name, terms); score > 0 {
+			matches = append(matches, scored{guid: guid, name: name, score: score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		return matches[i].name < matches[j].name
+	})
+
+	// Fetch one extra result so the page query can report moreResults
+	offset, _ := params["offset"].(int)
+	page, _ := paginate(matches, offset, 101)
+	guids := make([]string, len(page))
+	scores := make([]float64, len(page))
+	for i, m := range page {
+		guids[i] = m.guid
+		scores[i] = m.score
+	}
+	records, err = deps.DBService.ExecuteReadQuery(ctx, queries.page, map[string]any{"guids": guids, "scores": scores})
+
+	allGUIDs := make([]string, len(matches))
+	for i, m := range matches {
+		allGUIDs[i] = m.guid
+	}
+	return records, facetsSource{query: searchFacetsByGUIDsQuery, params: map[string]any{"guids": allGUIDs}}, err
+}
+
+// parseSearchTerms splits a query into comma-separated alternatives (any may match, as in the dashboard
+// search term) made of lower-case terms (all must match, in any order).
+func parseSearchTerms(query string) [][]string {
+	alternatives := make([][]string, 0)
+	for _, alternative := range strings.Split(query, ",") {
+		if terms := tokenizeName(alternative); len(terms) > 0 {
+			alternatives = append(alternatives, terms)
+		}
+	}
+	return alternatives
+}
+
+// tokenizeName splits a name into lower-case alphanumeric terms
+func tokenizeName(name string) []string {
+	return strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// fuzzyScore returns the similarity (0-1) of a name with the best matching alternative.
+// An alternative scores the mean similarity of its terms and only matches when every term matches.
+func fuzzyScore(name string, alternatives [][]string) float64 {
+	nameTerms := tokenizeName(name)
+	best := 0.0
+	for _, terms := range alternatives {
+		total := 0.0
+		for _, term := range terms {
+			termBest := 0.0
+			for _, nameTerm := range nameTerms {
+				termBest = math.Max(termBest, termSimilarity(term, nameTerm))
+			}
+			if termBest == 0 {
+				total = 0
+				break
+			}
+			total += termBest
+		}
+		best = math.Max(best, total/float64(len(terms)))
+	}
+	return math.Round(best*1000) / 1000
+}
+
+// termSimilarity compares a query term with a name term: exact, prefix, abbreviation, then edit distance.
+// Returns 0 when the terms do not match.
+func termSimilarity(term, nameTerm string) float64 {
+	switch {
+	case term == nameTerm:
+		return 1
+	case strings.HasPrefix(nameTerm, term) && len([]rune(term)) >= 2:
+		return fuzzyPrefixScore
+	case isAbbreviation(term, nameTerm):
+		return fuzzyAbbreviationScore
+	}
+	a, b := []rune(term), []rune(nameTerm)
+	longest := max(len(a), len(b))
+	distance := levenshtein(a, b)
+	if distance > maxEditDistance(len(a)) {
+		return 0
+	}
+	similarity := 1 - float64(distance)/float64(longest)
+	if similarity < fuzzyMinTermScore {
+		return 0
+	}
+	return similarity
+}
+
+// maxEditDistance is the number of typos tolerated for a term of the given length
+func maxEditDistance(length int) int {
+	switch {
+	case length <= 2:
+		return 0
+	case length <= 5:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// isAbbreviation reports whether the letters of term appear in order in nameTerm, starting with the same letter
+func isAbbreviation(term, nameTerm string) bool {
+	a, b := []rune(term), []rune(nameTerm)
+	if len(a) < 2 || len(a) >= len(b) || a[0] != b[0] {
+		return false
+	}
+	i := 0
+	for _, r := range b {
+		if i < len(a) && a[i] == r {
+			i++
+		}
+	}
+	return i == len(a)
+}
+
+// levenshtein returns the edit distance between a and b
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+// buildFuzzyFullTextQuery converts search alternatives into a Lucene query where every term of an
+// alternative must match, either as a prefix or within the tolerated edit distance.
+func buildFuzzyFullTextQuery(alternatives [][]string) string {
+	clauses := make([]string, 0, len(alternatives))
+	for _, terms := range alternatives {
+		parts := make([]string, len(terms))
+		for i, term := range terms {
+			escaped := luceneSpecialChars.ReplaceAllString(term, `\$1`)
+			if edits := maxEditDistance(len([]rune(term))); edits > 0 {
+				parts[i] = fmt.Sprintf("(%s* OR %s~%d)", escaped, escaped, edits)
+			} else {
+				parts[i] = escaped + "*"
+			}
+		}
+		clauses = append(clauses, "("+strings.Join(parts, " AND ")+")")
+	}
+	return strings.Join(clauses, " OR ")
+}
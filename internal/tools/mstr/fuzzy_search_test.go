@@ -0,0 +1,129 @@
+package mstr_test
+
+import (
+	"context"
+	"testing"
+
+	db "github.com/brunogc-cit/flow-microstrategy-mcp/internal/database/mocks"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools/mstr"
+	"github.com/neo4j/neo4j-go-driver/v6/neo4j"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func nameRecords(names map[string]string) []*neo4j.Record {
+	records := make([]*neo4j.Record, 0, len(names))
+	for guid, name := range names {
+		records = append(records, &neo4j.Record{Keys: []string{"guid", "name"}, Values: []any{guid, name}})
+	}
+	return records
+}
+
+// fuzzyPage runs a fuzzy search-metrics call without the full-text index and returns the GUIDs/scores
+// passed to the page query.
+func fuzzyPage(t *testing.T, query string, names map[string]string) ([]string, []float64) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var guids []string
+	var scores []float64
+	mockDB := db.NewMockService(ctrl)
+	gomock.InOrder(
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Eq(map[string]any{"status": nil, "scope": nil, "limit": 20001})).
+			Return(nameRecords(names), nil),
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, params map[string]any) ([]*neo4j.Record, error) {
+				guids = params["guids"].([]string)
+				scores = params["scores"].([]float64)
+				return []*neo4j.Record{}, nil
+			}),
+	)
+	mockDB.EXPECT().Neo4jRecordsToJSON(gomock.Any()).Return("[]", nil)
+
+	result := callTool(t, mstr.SearchMetricsHandler(&tools.ToolDependencies{DBService: mockDB}), map[string]any{"query": query, "mode": "fuzzy"})
+	require.False(t, result.IsError, resultText(t, result))
+	return guids, scores
+}
+
+func TestFuzzySearch(t *testing.T) {
+	names := map[string]string{
+		"G1": "Retail Sales",
+		"G2": "Sales Retail LY",
+		"G3": "Net Sales Amount",
+		"G4": "Wholesale Margin",
+		"G5": "Retail Stock",
+	}
+
+	t.Run("tolerates typos and term order", func(t *testing.T) {
+		guids, scores := fuzzyPage(t, "Retial Sales", names)
+		assert.Equal(t, []string{"G1", "G2"}, guids)
+		assert.Equal(t, scores[0], scores[1])
+		assert.Less(t, scores[0], 1.0)
+	})
+
+	t.Run("ranks exact matches first", func(t *testing.T) {
+		guids, scores := fuzzyPage(t, "retail sales", names)
+		assert.Equal(t, []string{"G1", "G2"}, guids)
+		assert.Equal(t, 1.0, scores[0])
+	})
+
+	t.Run("matches abbreviations and prefixes", func(t *testing.T) {
+		guids, _ := fuzzyPage(t, "net amt", names)
+		assert.Equal(t, []string{"G3"}, guids)
+
+		guids, _ = fuzzyPage(t, "whole marg", names)
+		assert.Equal(t, []string{"G4"}, guids)
+	})
+
+	t.Run("comma-separated alternatives match any", func(t *testing.T) {
+		guids, _ := fuzzyPage(t, "retail stock, wholesale", names)
+		assert.Equal(t, []string{"G5", "G4"}, guids)
+	})
+
+	t.Run("uses fuzzy Lucene terms with the full-text index", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Cond(func(x any) bool {
+				return x.(map[string]any)["ftQuery"] == "((retial* OR retial~2) AND (sales* OR sales~1)) OR (ly*)"
+			})).
+			Return([]*neo4j.Record{}, nil)
+		mockDB.EXPECT().Neo4jRecordsToJSON(gomock.Any()).Return("[]", nil)
+
+		deps := &tools.ToolDependencies{DBService: mockDB, SearchIndex: searchIndexFlag(true)}
+		result := callTool(t, mstr.SearchMetricsHandler(deps), map[string]any{"query": "Retial Sales, LY", "mode": "fuzzy"})
+		assert.False(t, result.IsError)
+	})
+
+	t.Run("refuses to rank too many names without the index", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		records := make([]*neo4j.Record, 20001)
+		for i := range records {
+			records[i] = &neo4j.Record{Keys: []string{"guid", "name"}, Values: []any{"G", "Retail Sales"}}
+		}
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).Return(records, nil)
+
+		result := callTool(t, mstr.SearchAttributesHandler(&tools.ToolDependencies{DBService: mockDB}), map[string]any{"query": "retial", "mode": "fuzzy"})
+		assert.True(t, result.IsError)
+		assert.Contains(t, resultText(t, result), "--create-search-index")
+		assert.NotContains(t, resultText(t, result), "Query execution failed")
+	})
+
+	t.Run("rejects unknown modes", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		result := callTool(t, mstr.SearchAttributesHandler(&tools.ToolDependencies{DBService: db.NewMockService(ctrl)}), map[string]any{"query": "x", "mode": "regex"})
+		assert.True(t, result.IsError)
+	})
+}
@@ -0,0 +1,225 @@
+package mstr
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	defaultHierarchyDepth = 2
+	maxHierarchyDepth     = 5
+)
+
+// GetAttributeHierarchyInput defines the input parameters for the get-attribute-hierarchy tool
+type GetAttributeHierarchyInput struct {
+	GUID  string `json:"guid" jsonschema:"required,description=Full GUID of the Attribute at the centre of the hierarchy"`
+	Depth int    `json:"depth,omitempty" jsonschema:"default=2,minimum=1,maximum=5,description=Number of parent/child levels to walk in each direction (1-5)"`
+	Scope string `json:"scope,omitempty" jsonschema:"description=Project or folder of the parents and children to return (e.g. Retail; see list-projects). Defaults to the server scope; * for all projects"`
+}
+
+// HierarchyAttribute is an attribute of the hierarchy: level > 0 for parents, < 0 for children, 0 for the requested one
+type HierarchyAttribute struct {
+	GUID      string     `json:"guid,omitempty"`
+	Name      string     `json:"name,omitempty"`
+	Level     int        `json:"level,omitempty"`
+	Role      string     `json:"role,omitempty"`
+	Status    string     `json:"status,omitempty"`
+	EDWTable  string     `json:"edwTable,omitempty"`
+	EDWColumn string     `json:"edwColumn,omitempty"`
+	Tables    []TableRef `json:"tables,omitempty"`
+}
+
+// HierarchyRelationship is a parent/child edge between two attributes of the hierarchy (by GUID)
+type HierarchyRelationship struct {
+	Child  string `json:"child,omitempty"`
+	Parent string `json:"parent,omitempty"`
+}
+
+// GetAttributeHierarchyResult is the parent/child attribute graph around an Attribute
+type GetAttributeHierarchyResult struct {
+	Attribute     AttributeDetails        `json:"attribute"`
+	Depth         int                     `json:"depth"`
+	Attributes    []HierarchyAttribute    `json:"attributes"`
+	Relationships []HierarchyRelationship `json:"relationships"`
+}
+
+// GetAttributeHierarchyOutput defines the output of the get-attribute-hierarchy tool
+type GetAttributeHierarchyOutput struct {
+	Result    GetAttributeHierarchyResult `json:"result"`
+	Truncated []TruncatedList             `json:"truncated,omitempty"`
+}
+
+const getAttributeHierarchyQuery = `
+// Navigate the parent/child attribute graph around an Attribute
+// $guid: Full GUID of the Attribute
+// $depth: Levels to walk in each direction (1-5)
+// $scope: optional location prefixes (project/folder scope) of the parents and children, null for all projects
+//
+// An Attribute DEPENDS_ON its parent attributes (e.g. Date -> Week): outgoing
+// Attribute-to-Attribute edges lead to parents, incoming ones come from children.
+// Only Attribute intermediates are followed so the walk stays within the hierarchy.
+
+MATCH (n:Attribute {guid: $guid})
+
+// Parents (coarser grain), with the shortest distance as level
+OPTIONAL MATCH up = (n)-[:DEPENDS_ON*1..5]->(parent:Attribute)
+WHERE length(up) <= $depth
+  AND ALL(mid IN nodes(up)[1..-1] WHERE mid:Attribute)
+  AND ($scope IS NULL OR any(p IN $scope WHERE replace(toLower(parent.location), '\\', '/') + '/' STARTS WITH p))
+WITH n, parent, min(length(up)) as parentLevel
+WITH n, [p IN collect({node: parent, level: parentLevel}) WHERE p.node IS NOT NULL] as parents
+
+// Children (finer grain), with the shortest distance as negative level
+OPTIONAL MATCH down = (child:Attribute)-[:DEPENDS_ON*1..5]->(n)
+WHERE length(down) <= $depth
+  AND ALL(mid IN nodes(down)[1..-1] WHERE mid:Attribute)
+  AND ($scope IS NULL OR any(p IN $scope WHERE replace(toLower(child.location), '\\', '/') + '/' STARTS WITH p))
+WITH n, parents, child, min(length(down)) as childLevel
+WITH n, parents, [c IN collect({node: child, level: -childLevel}) WHERE c.node IS NOT NULL] as children
+
+WITH n, [{node: n, level: 0}] + parents + children as members
+WITH n, members, [m IN members | m.node] as memberNodes
+
+// Parent/child edges between attributes of the neighbourhood
+CALL {
+  WITH memberNodes
+  UNWIND memberNodes as c
+  MATCH (c)-[:DEPENDS_ON]->(p:Attribute)
+  WHERE p IN memberNodes
+  RETURN collect(DISTINCT {
+    child: c.guid,
+    parent: p.guid
+  }) as relationships
+}
+
+// Tables every attribute of the neighbourhood depends on directly (its forms read from them)
+CALL {
+  WITH members
+  UNWIND members as m
+  WITH m.node as a, m.level as level
+  OPTIONAL MATCH (a)-[:DEPENDS_ON]->(t)
+  WHERE t.type IN ['LogicalTable', 'Table']
+  WITH a, level, [tbl IN collect(DISTINCT {
+    name: t.name,
+    guid: t.guid,
+    physicalTable: t.physical_table_name
+  }) WHERE tbl.guid IS NOT NULL] as tables
+  ORDER BY level DESC, a.name ASC
+  RETURN collect({
+    guid: a.guid,
+    name: a.name,
+    level: level,
+    role: CASE WHEN level > 0 THEN 'parent' WHEN level < 0 THEN 'child' ELSE 'self' END,
+    status: COALESCE(a.updated_parity_status, a.parity_status, 'No Status'),
+    edwTable: COALESCE(a.updated_edw_table, a.edw_table),
+    edwColumn: a.edw_column,
+    tables: tables
+  }) as attributes
+}
+
+RETURN {
+  attribute: {
+    type: 'Attribute',
+    guid: n.guid,
+    name: n.name,
+    forms_json: n.forms_json
+  },
+  depth: $depth,
+  attributes: attributes,
+  relationships: relationships
+} as result
+`
+
+// GetAttributeHierarchySpec returns the MCP tool definition for get-attribute-hierarchy
+func GetAttributeHierarchySpec() mcp.Tool {
+	return mcp.NewTool("get-attribute-hierarchy",
+		mcp.WithDescription(
+			"Return the parent/child attribute graph around an Attribute, with the tables each attribute reads from.\n\n"+
+				"LEVELS:\n"+
+				"- level > 0: parent attributes (coarser grain, e.g. Week for Date)\n"+
+				"- level < 0: child attributes (finer grain)\n"+
+				"- level = 0: the requested attribute\n\n"+
+				"USE FOR:\n"+
+				"- Designing Power BI hierarchies: get-attribute-hierarchy(guid=\"29B18CBE4323BD3D4D33AD9E718D4E79\")\n"+
+				"- Choosing the grain of a dbt dimension and the tables it reads from\n"+
+				"- Understanding nested attribute chains such as Date -> Week -> Table\n\n"+
+				"DO NOT USE FOR:\n"+
+				"- Searching attributes by name (use search-attributes first to get the GUID)\n"+
+				"- Report or source table lineage (use trace-attribute instead)",
+		),
+		mcp.WithInputSchema[GetAttributeHierarchyInput](),
+		mcp.WithOutputSchema[GetAttributeHierarchyOutput](),
+		mcp.WithTitleAnnotation("Navigate attribute parent/child hierarchy"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(true),
+	)
+}
+
+// GetAttributeHierarchyHandler returns the handler function for the get-attribute-hierarchy tool
+func GetAttributeHierarchyHandler(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetAttributeHierarchy(ctx, deps, request)
+	}
+}
+
+func handleGetAttributeHierarchy(ctx context.Context, deps *tools.ToolDependencies, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if deps.DBService == nil {
+		errMessage := "Database service is not initialized"
+		slog.ErrorContext(ctx, errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	// Parse input
+	var input GetAttributeHierarchyInput
+	if err := request.BindArguments(&input); err != nil {
+		slog.ErrorContext(ctx, "error binding arguments", "error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid input: %v", err)), nil
+	}
+
+	// Validate required fields
+	if input.GUID == "" {
+		return mcp.NewToolResultError("guid parameter is required"), nil
+	}
+	depth := input.Depth
+	if depth == 0 {
+		depth = defaultHierarchyDepth
+	}
+	if depth < 1 || depth > maxHierarchyDepth {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid depth %d: must be between 1 and %d", input.Depth, maxHierarchyDepth)), nil
+	}
+
+	params := map[string]any{
+		"guid":  input.GUID,
+		"depth": depth,
+		"scope": scopeParam(deps, input.Scope),
+	}
+
+	slog.InfoContext(ctx, "executing get-attribute-hierarchy query", "guid", input.GUID, "depth", depth)
+
+	reportProgress(ctx, stageTraversing)
+	records, err := deps.DBService.ExecuteReadQuery(ctx, getAttributeHierarchyQuery, params)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to execute get-attribute-hierarchy query", "error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Query execution failed: %v", err)), nil
+	}
+
+	// Check if attribute was found
+	if len(records) == 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("Attribute with GUID %s not found", input.GUID)), nil
+	}
+
+	reportProgress(ctx, stageFormatting)
+	response, err := deps.DBService.Neo4jRecordsToJSON(records)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to format get-attribute-hierarchy results to JSON", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(response), nil
+}
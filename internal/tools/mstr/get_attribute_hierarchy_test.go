@@ -0,0 +1,59 @@
+package mstr_test
+
+import (
+	"testing"
+
+	db "github.com/brunogc-cit/flow-microstrategy-mcp/internal/database/mocks"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools/mstr"
+	"github.com/neo4j/neo4j-go-driver/v6/neo4j"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetAttributeHierarchyHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("uses default depth", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Eq(map[string]any{"guid": "ABC", "depth": 2, "scope": nil})).
+			Return([]*neo4j.Record{{Keys: []string{"result"}, Values: []any{map[string]any{}}}}, nil)
+		mockDB.EXPECT().
+			Neo4jRecordsToJSON(gomock.Any()).
+			Return("[]", nil)
+
+		handler := mstr.GetAttributeHierarchyHandler(&tools.ToolDependencies{DBService: mockDB})
+		result := callTool(t, handler, map[string]any{"guid": "ABC"})
+		assert.False(t, result.IsError)
+	})
+
+	t.Run("attribute not found", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return([]*neo4j.Record{}, nil)
+
+		handler := mstr.GetAttributeHierarchyHandler(&tools.ToolDependencies{DBService: mockDB})
+		result := callTool(t, handler, map[string]any{"guid": "ABC", "depth": 3})
+		assert.True(t, result.IsError)
+	})
+
+	t.Run("missing guid", func(t *testing.T) {
+		handler := mstr.GetAttributeHierarchyHandler(&tools.ToolDependencies{DBService: db.NewMockService(ctrl)})
+		result := callTool(t, handler, map[string]any{})
+		assert.True(t, result.IsError)
+	})
+
+	t.Run("depth out of range", func(t *testing.T) {
+		handler := mstr.GetAttributeHierarchyHandler(&tools.ToolDependencies{DBService: db.NewMockService(ctrl)})
+		result := callTool(t, handler, map[string]any{"guid": "ABC", "depth": 9})
+		assert.True(t, result.IsError)
+	})
+
+	t.Run("scopes parents and children", func(t *testing.T) {
+		params := scopedParams(t, "Retail", mstr.GetAttributeHierarchyHandler, map[string]any{"guid": "ABC"})
+		assert.Equal(t, []string{"/retail/", "retail/"}, params["scope"])
+	})
+}
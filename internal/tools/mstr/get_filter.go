@@ -0,0 +1,210 @@
+package mstr
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// GetFilterInput defines the input parameters for the get-filter tool
+type GetFilterInput struct {
+	GUID   string `json:"guid" jsonschema:"required,description=Full GUID of the Filter"`
+	Scope  string `json:"scope,omitempty" jsonschema:"description=Project or folder to search in (e.g. Retail or Retail/Public Objects/Metrics; see list-projects). Defaults to the server scope; * for all projects"`
+	Offset int    `json:"offset,omitempty" jsonschema:"default=0,description=Skip first N reports for pagination"`
+	Cursor string `json:"cursor,omitempty" jsonschema:"description=nextCursor from the previous page (preferred over offset: stable and cheap for deep pages)"`
+}
+
+// FilterDetails is the full definition of a Filter
+type FilterDetails struct {
+	Type            string `json:"type,omitempty"`
+	GUID            string `json:"guid,omitempty"`
+	Name            string `json:"name,omitempty"`
+	Location        string `json:"location,omitempty"`
+	Expression      string `json:"expression,omitempty"`
+	ExpressionsJSON string `json:"expressions_json,omitempty"`
+}
+
+// PromptRef is a Prompt embedded in a filter expression
+type PromptRef struct {
+	Name       string `json:"name,omitempty"`
+	GUID       string `json:"guid,omitempty"`
+	PromptType string `json:"promptType,omitempty"`
+}
+
+// GetFilterResult is a Filter with the objects it qualifies on, its prompts and the reports using it
+type GetFilterResult struct {
+	Filter      FilterDetails `json:"filter"`
+	Qualifies   []ObjectRef   `json:"qualifies"`
+	Prompts     []PromptRef   `json:"prompts"`
+	Reports     []ReportRef   `json:"reports"`
+	MoreResults bool          `json:"moreResults"`
+}
+
+// GetFilterOutput defines the output of the get-filter tool
+type GetFilterOutput struct {
+	Result     GetFilterResult `json:"result"`
+	NextCursor string          `json:"nextCursor,omitempty"`
+	Truncated  []TruncatedList `json:"truncated,omitempty"`
+}
+
+const getFilterQuery = `
+// Get the full definition of a Filter and the prioritized reports using it
+// $guid: Full GUID of the Filter
+// $offset: Pagination offset for reports
+// $scope: optional location prefixes (project/folder scope), null for all projects
+// $after: {name, guid} of the last report of the previous page (cursor), or null
+//
+// LIVE TRAVERSAL: Qualified objects are direct DEPENDS_ON targets (Metrics/Attributes).
+// Reports are found through incoming DEPENDS_ON relationships, only passing through
+// [Prompt, Filter] intermediate nodes (canonical dashboard pattern).
+
+MATCH (n:MSTRObject {guid: $guid})
+WHERE n.type = 'Filter'
+
+// Metrics/Attributes the filter qualifies on
+OPTIONAL MATCH (n)-[:DEPENDS_ON]->(q)
+WHERE q.type IN ['Metric', 'Attribute']
+WITH n, [obj IN collect(DISTINCT {
+  name: q.name,
+  guid: q.guid,
+  type: q.type,
+  status: COALESCE(q.updated_parity_status, q.parity_status, 'No Status')
+}) WHERE obj.guid IS NOT NULL] as qualifies
+
+// Prompts embedded in the filter expression
+OPTIONAL MATCH (n)-[:DEPENDS_ON]->(p)
+WHERE p.type = 'Prompt'
+WITH n, qualifies, [pr IN collect(DISTINCT {
+  name: p.name,
+  guid: p.guid,
+  promptType: p.prompt_type
+}) WHERE pr.guid IS NOT NULL] as prompts
+
+// Prioritized reports using this filter (live BFS traversal)
+OPTIONAL MATCH path = (report)-[:DEPENDS_ON*1..10]->(n)
+WHERE report.type IN ['Report', 'GridReport', 'Document']
+  AND report.priority_level IS NOT NULL
+  AND ALL(mid IN nodes(path)[1..-1] WHERE mid.type IN ['Prompt', 'Filter'])
+  AND ($scope IS NULL OR any(p IN $scope WHERE replace(toLower(report.location), '\\', '/') + '/' STARTS WITH p))
+  AND ($after IS NULL OR report.name > $after.name OR (report.name = $after.name AND report.guid > $after.guid))  // keyset pagination after a cursor
+
+// One row per report, whatever the number of paths reaching it, before cutting the page
+WITH DISTINCT n, qualifies, prompts, report
+ORDER BY report.name ASC, report.guid ASC
+SKIP CASE WHEN $after IS NULL THEN $offset ELSE 0 END
+LIMIT 101
+
+// Collect paginated reports (filter out null results from OPTIONAL MATCH)
+WITH n, qualifies, prompts, [r IN collect(DISTINCT {
+  name: report.name,
+  guid: report.guid,
+  type: report.type,
+  priority: report.priority_level,
+  area: report.usage_area
+}) WHERE r.guid IS NOT NULL] as fetched
+
+RETURN {
+  filter: {
+    type: 'Filter',
+    guid: n.guid,
+    name: n.name,
+    location: n.location,
+    expression: COALESCE(n.expression, n.formula),
+    expressions_json: n.expressions_json
+  },
+  qualifies: qualifies,
+  prompts: prompts,
+  reports: fetched[0..100],
+  moreResults: size(fetched) > 100
+} as result
+`
+
+// GetFilterSpec returns the MCP tool definition for get-filter
+func GetFilterSpec() mcp.Tool {
+	return mcp.NewTool("get-filter",
+		mcp.WithDescription(
+			"Get the full definition of a Filter: its expression, the Metrics/Attributes it qualifies on, embedded Prompts, "+
+				"and the PRIORITIZED reports that use it.\n\n"+
+				"USE FOR:\n"+
+				"- Mapping a MicroStrategy filter to Power BI slicers, report filters or RLS rules\n"+
+				"- Impact analysis: which prioritized reports depend on this filter?\n\n"+
+				"DO NOT USE FOR:\n"+
+				"- Searching filters by name (use search-filters first to get the GUID)\n"+
+				"- Prompt definitions (use get-prompt instead)\n\n"+
+				"PAGINATION: Returns 100 reports. If moreResults=true, call again with cursor=nextCursor (or offset+100).",
+		),
+		mcp.WithInputSchema[GetFilterInput](),
+		mcp.WithOutputSchema[GetFilterOutput](),
+		mcp.WithTitleAnnotation("Get Filter definition and usage"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(true),
+	)
+}
+
+// GetFilterHandler returns the handler function for the get-filter tool
+func GetFilterHandler(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetFilter(ctx, deps, request)
+	}
+}
+
+func handleGetFilter(ctx context.Context, deps *tools.ToolDependencies, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if deps.DBService == nil {
+		errMessage := "Database service is not initialized"
+		slog.ErrorContext(ctx, errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	// Parse input
+	var input GetFilterInput
+	if err := request.BindArguments(&input); err != nil {
+		slog.ErrorContext(ctx, "error binding arguments", "error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid input: %v", err)), nil
+	}
+
+	// Validate required fields
+	if input.GUID == "" {
+		return mcp.NewToolResultError("guid parameter is required"), nil
+	}
+
+	params := map[string]any{
+		"guid":   input.GUID,
+		"offset": input.Offset,
+		"scope":  scopeParam(deps, input.Scope),
+	}
+
+	slog.InfoContext(ctx, "executing get-filter query", "guid", input.GUID, "offset", input.Offset)
+
+	pages, err := newPager("get-filter", params, input.Cursor)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	reportProgress(ctx, stageTraversing)
+	records, err := deps.DBService.ExecuteReadQuery(ctx, getFilterQuery, params)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to execute get-filter query", "error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Query execution failed: %v", err)), nil
+	}
+
+	// Check if filter was found
+	if len(records) == 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("Filter with GUID %s not found", input.GUID)), nil
+	}
+
+	pages.attachNextCursor(ctx, records, "result", "reports")
+
+	reportProgress(ctx, stageFormatting)
+	response, err := deps.DBService.Neo4jRecordsToJSON(records)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to format get-filter results to JSON", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(response), nil
+}
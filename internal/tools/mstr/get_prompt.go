@@ -0,0 +1,211 @@
+package mstr
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// GetPromptInput defines the input parameters for the get-prompt tool
+type GetPromptInput struct {
+	GUID   string `json:"guid" jsonschema:"required,description=Full GUID of the Prompt"`
+	Scope  string `json:"scope,omitempty" jsonschema:"description=Project or folder to search in (e.g. Retail or Retail/Public Objects/Metrics; see list-projects). Defaults to the server scope; * for all projects"`
+	Offset int    `json:"offset,omitempty" jsonschema:"default=0,description=Skip first N reports for pagination"`
+	Cursor string `json:"cursor,omitempty" jsonschema:"description=nextCursor from the previous page (preferred over offset: stable and cheap for deep pages)"`
+}
+
+// PromptDetails is the full definition of a Prompt
+type PromptDetails struct {
+	Type            string `json:"type,omitempty"`
+	GUID            string `json:"guid,omitempty"`
+	Name            string `json:"name,omitempty"`
+	Location        string `json:"location,omitempty"`
+	PromptType      string `json:"promptType,omitempty"`
+	Title           string `json:"title,omitempty"`
+	Instruction     string `json:"instruction,omitempty"`
+	Required        any    `json:"required,omitempty"`
+	DefaultAnswers  any    `json:"defaultAnswers,omitempty"`
+	ExpressionsJSON string `json:"expressions_json,omitempty"`
+}
+
+// GetPromptResult is a Prompt with the objects it qualifies on, the filters embedding it and the reports using it
+type GetPromptResult struct {
+	Prompt      PromptDetails `json:"prompt"`
+	Qualifies   []ObjectRef   `json:"qualifies"`
+	Filters     []ObjectRef   `json:"filters"`
+	Reports     []ReportRef   `json:"reports"`
+	MoreResults bool          `json:"moreResults"`
+}
+
+// GetPromptOutput defines the output of the get-prompt tool
+type GetPromptOutput struct {
+	Result     GetPromptResult `json:"result"`
+	NextCursor string          `json:"nextCursor,omitempty"`
+	Truncated  []TruncatedList `json:"truncated,omitempty"`
+}
+
+const getPromptQuery = `
+// Get the full definition of a Prompt and the prioritized reports using it
+// $guid: Full GUID of the Prompt
+// $offset: Pagination offset for reports
+// $scope: optional location prefixes (project/folder scope), null for all projects
+// $after: {name, guid} of the last report of the previous page (cursor), or null
+//
+// LIVE TRAVERSAL: Qualified objects are direct DEPENDS_ON targets (Metrics/Attributes);
+// embedding Filters are direct DEPENDS_ON sources.
+// Reports are found through incoming DEPENDS_ON relationships, only passing through
+// [Prompt, Filter] intermediate nodes (canonical dashboard pattern).
+
+MATCH (n:MSTRObject {guid: $guid})
+WHERE n.type = 'Prompt'
+
+// Metrics/Attributes the prompt qualifies on (answer source)
+OPTIONAL MATCH (n)-[:DEPENDS_ON]->(q)
+WHERE q.type IN ['Metric', 'Attribute']
+WITH n, [obj IN collect(DISTINCT {
+  name: q.name,
+  guid: q.guid,
+  type: q.type,
+  status: COALESCE(q.updated_parity_status, q.parity_status, 'No Status')
+}) WHERE obj.guid IS NOT NULL] as qualifies
+
+// Filters that embed this prompt
+OPTIONAL MATCH (f)-[:DEPENDS_ON]->(n)
+WHERE f.type = 'Filter'
+WITH n, qualifies, [flt IN collect(DISTINCT {
+  name: f.name,
+  guid: f.guid
+}) WHERE flt.guid IS NOT NULL] as filters
+
+// Prioritized reports using this prompt (live BFS traversal)
+OPTIONAL MATCH path = (report)-[:DEPENDS_ON*1..10]->(n)
+WHERE report.type IN ['Report', 'GridReport', 'Document']
+  AND report.priority_level IS NOT NULL
+  AND ALL(mid IN nodes(path)[1..-1] WHERE mid.type IN ['Prompt', 'Filter'])
+  AND ($scope IS NULL OR any(p IN $scope WHERE replace(toLower(report.location), '\\', '/') + '/' STARTS WITH p))
+  AND ($after IS NULL OR report.name > $after.name OR (report.name = $after.name AND report.guid > $after.guid))  // keyset pagination after a cursor
+
+// One row per report, whatever the number of paths reaching it, before cutting the page
+WITH DISTINCT n, qualifies, filters, report
+ORDER BY report.name ASC, report.guid ASC
+SKIP CASE WHEN $after IS NULL THEN $offset ELSE 0 END
+LIMIT 101
+
+// Collect paginated reports (filter out null results from OPTIONAL MATCH)
+WITH n, qualifies, filters, [r IN collect(DISTINCT {
+  name: report.name,
+  guid: report.guid,
+  type: report.type,
+  priority: report.priority_level,
+  area: report.usage_area
+}) WHERE r.guid IS NOT NULL] as fetched
+
+RETURN {
+  prompt: {
+    type: 'Prompt',
+    guid: n.guid,
+    name: n.name,
+    location: n.location,
+    promptType: n.prompt_type,
+    title: n.title,
+    instruction: n.instruction,
+    required: n.required,
+    defaultAnswers: n.default_answers,
+    expressions_json: n.expressions_json
+  },
+  qualifies: qualifies,
+  filters: filters,
+  reports: fetched[0..100],
+  moreResults: size(fetched) > 100
+} as result
+`
+
+// GetPromptSpec returns the MCP tool definition for get-prompt
+func GetPromptSpec() mcp.Tool {
+	return mcp.NewTool("get-prompt",
+		mcp.WithDescription(
+			"Get the full definition of a Prompt: its type, title, default answers, the Metrics/Attributes it qualifies on, "+
+				"the Filters embedding it, and the PRIORITIZED reports that use it.\n\n"+
+				"USE FOR:\n"+
+				"- Mapping a MicroStrategy prompt to Power BI slicers or parameters\n"+
+				"- Impact analysis: which prioritized reports depend on this prompt?\n\n"+
+				"DO NOT USE FOR:\n"+
+				"- Searching prompts by name (use search-prompts first to get the GUID)\n"+
+				"- Filter definitions (use get-filter instead)\n\n"+
+				"PAGINATION: Returns 100 reports. If moreResults=true, call again with cursor=nextCursor (or offset+100).",
+		),
+		mcp.WithInputSchema[GetPromptInput](),
+		mcp.WithOutputSchema[GetPromptOutput](),
+		mcp.WithTitleAnnotation("Get Prompt definition and usage"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(true),
+	)
+}
+
+// GetPromptHandler returns the handler function for the get-prompt tool
+func GetPromptHandler(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetPrompt(ctx, deps, request)
+	}
+}
+
+func handleGetPrompt(ctx context.Context, deps *tools.ToolDependencies, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if deps.DBService == nil {
+		errMessage := "Database service is not initialized"
+		slog.ErrorContext(ctx, errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	// Parse input
+	var input GetPromptInput
+	if err := request.BindArguments(&input); err != nil {
+		slog.ErrorContext(ctx, "error binding arguments", "error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid input: %v", err)), nil
+	}
+
+	// Validate required fields
+	if input.GUID == "" {
+		return mcp.NewToolResultError("guid parameter is required"), nil
+	}
+
+	params := map[string]any{
+		"guid":   input.GUID,
+		"offset": input.Offset,
+		"scope":  scopeParam(deps, input.Scope),
+	}
+
+	slog.InfoContext(ctx, "executing get-prompt query", "guid", input.GUID, "offset", input.Offset)
+
+	pages, err := newPager("get-prompt", params, input.Cursor)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	reportProgress(ctx, stageTraversing)
+	records, err := deps.DBService.ExecuteReadQuery(ctx, getPromptQuery, params)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to execute get-prompt query", "error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Query execution failed: %v", err)), nil
+	}
+
+	// Check if prompt was found
+	if len(records) == 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("Prompt with GUID %s not found", input.GUID)), nil
+	}
+
+	pages.attachNextCursor(ctx, records, "result", "reports")
+
+	reportProgress(ctx, stageFormatting)
+	response, err := deps.DBService.Neo4jRecordsToJSON(records)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to format get-prompt results to JSON", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(response), nil
+}
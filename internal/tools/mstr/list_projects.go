@@ -0,0 +1,176 @@
+package mstr
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	defaultFolderDepth = 1
+	maxFolderDepth     = 5
+)
+
+// ListProjectsInput defines the input parameters for the list-projects tool
+type ListProjectsInput struct {
+	Scope  string `json:"scope,omitempty" jsonschema:"description=Project or folder to list the subfolders of (e.g. Retail). Defaults to the server scope; * for all projects"`
+	Depth  int    `json:"depth,omitempty" jsonschema:"default=1,minimum=1,maximum=5,description=Folder levels below the scope to group by (1 = projects when unscoped)"`
+	Offset int    `json:"offset,omitempty" jsonschema:"default=0,description=Skip first N results for pagination"`
+	Cursor string `json:"cursor,omitempty" jsonschema:"description=nextCursor from the previous page (continues at the next position: equivalent to offset+100)"`
+}
+
+// TypeCount is the number of objects of a type
+type TypeCount struct {
+	Type  string `json:"type,omitempty"`
+	Count int    `json:"count,omitempty"`
+}
+
+// ProjectFolder is a project or folder with its object counts
+type ProjectFolder struct {
+	Folder             string      `json:"folder,omitempty"`
+	ObjectCount        int         `json:"objectCount,omitempty"`
+	PrioritizedReports int         `json:"prioritizedReports,omitempty"`
+	Types              []TypeCount `json:"types,omitempty"`
+}
+
+// ListProjectsOutput defines the output of the list-projects tool
+type ListProjectsOutput struct {
+	Results     []ProjectFolder `json:"results"`
+	MoreResults bool            `json:"moreResults"`
+	NextCursor  string          `json:"nextCursor,omitempty"`
+	Truncated   []TruncatedList `json:"truncated,omitempty"`
+}
+
+const listProjectsQuery = `
+// List projects/folders (leading segments of the location path) with object counts
+// $scope: optional location prefixes (project/folder scope), null for all projects
+// $level: number of leading location segments identifying a folder (scope depth + requested depth)
+// $offset: pagination offset (0, 100, 200, ...)
+
+MATCH (n:MSTRObject)
+WHERE n.location IS NOT NULL
+  AND ($scope IS NULL OR any(p IN $scope WHERE replace(toLower(n.location), '\\', '/') + '/' STARTS WITH p))
+
+// Split the location on either separator, ignoring empty segments (leading separator)
+WITH n, [s IN split(replace(n.location, '\\', '/'), '/') WHERE trim(s) <> ''] as segments
+WHERE size(segments) > 0
+WITH n, reduce(path = head(segments), s IN segments[1..$level] | path + '/' + s) as folder
+
+// Counts per folder and type
+WITH folder, n.type as type, count(*) as objects,
+     sum(CASE WHEN n.type IN ['Report', 'GridReport', 'Document'] AND n.priority_level IS NOT NULL THEN 1 ELSE 0 END) as prioritized
+ORDER BY objects DESC, type ASC
+WITH folder, sum(objects) as objectCount, sum(prioritized) as prioritizedReports,
+     collect({type: type, count: objects}) as types
+ORDER BY folder ASC
+SKIP $offset
+LIMIT 101  // Fetch 101 to determine if more results exist
+
+WITH collect({
+  folder: folder,
+  objectCount: objectCount,
+  prioritizedReports: prioritizedReports,
+  types: types
+}) as fetched
+
+// Return first 100; moreResults=true if 101st exists
+RETURN
+  fetched[0..100] as results,
+  size(fetched) > 100 as moreResults
+`
+
+// ListProjectsSpec returns the MCP tool definition for list-projects
+func ListProjectsSpec() mcp.Tool {
+	return mcp.NewTool("list-projects",
+		mcp.WithDescription(
+			"List the MicroStrategy projects (first segment of the object location) or their folders, with object counts per type "+
+				"and prioritized report counts.\n\n"+
+				"USE FOR:\n"+
+				"- Discovering which projects share the graph before scoping other tools: list-projects()\n"+
+				"- Drilling into a project's folders: list-projects(scope=\"Retail\", depth=2)\n"+
+				"- Choosing the scope parameter of the search, trace and coverage tools (pass the returned folder as scope)\n\n"+
+				"DO NOT USE FOR:\n"+
+				"- Finding objects by name (use search-metrics or search-attributes with scope instead)\n\n"+
+				"PAGINATION: Returns 100 results. If moreResults=true, call again with cursor=nextCursor (or offset+100).",
+		),
+		mcp.WithInputSchema[ListProjectsInput](),
+		mcp.WithOutputSchema[ListProjectsOutput](),
+		mcp.WithTitleAnnotation("List projects and folders"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(true),
+	)
+}
+
+// ListProjectsHandler returns the handler function for the list-projects tool
+func ListProjectsHandler(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleListProjects(ctx, deps, request)
+	}
+}
+
+func handleListProjects(ctx context.Context, deps *tools.ToolDependencies, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if deps.DBService == nil {
+		errMessage := "Database service is not initialized"
+		slog.ErrorContext(ctx, errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	// Parse input
+	var input ListProjectsInput
+	if err := request.BindArguments(&input); err != nil {
+		slog.ErrorContext(ctx, "error binding arguments", "error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid input: %v", err)), nil
+	}
+
+	depth := input.Depth
+	if depth == 0 {
+		depth = defaultFolderDepth
+	}
+	if depth < 1 || depth > maxFolderDepth {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid depth %d: must be between 1 and %d", input.Depth, maxFolderDepth)), nil
+	}
+
+	// Folders are counted from the root: a scope of "Retail/Public Objects" is two levels deep
+	level := depth
+	if scope := normalizeScope(deps, input.Scope); scope != "" {
+		level += strings.Count(scope, "/") + 1
+	}
+
+	params := map[string]any{
+		"scope":  scopeParam(deps, input.Scope),
+		"level":  level,
+		"offset": input.Offset,
+	}
+
+	// Folders have no GUID: pages by position
+	pages, err := newOffsetPager("list-projects", params, input.Cursor)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	slog.InfoContext(ctx, "executing list-projects query", "scope", input.Scope, "depth", depth, "offset", params["offset"])
+
+	reportProgress(ctx, stageSearching)
+	records, err := deps.DBService.ExecuteReadQuery(ctx, listProjectsQuery, params)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to execute list-projects query", "error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Query execution failed: %v", err)), nil
+	}
+
+	pages.attachNextCursor(ctx, records, "results")
+
+	reportProgress(ctx, stageFormatting)
+	response, err := deps.DBService.Neo4jRecordsToJSON(records)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to format list-projects results to JSON", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(response), nil
+}
@@ -0,0 +1,166 @@
+package mstr
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ListTransformationsInput defines the input parameters for the list-transformations tool
+type ListTransformationsInput struct {
+	Query  string `json:"query,omitempty" jsonschema:"description=Optional GUID (full or partial 8+ chars) or name search term. Omit to list all transformations"`
+	Scope  string `json:"scope,omitempty" jsonschema:"description=Project or folder to search in (e.g. Retail or Retail/Public Objects/Metrics; see list-projects). Defaults to the server scope; * for all projects"`
+	Offset int    `json:"offset,omitempty" jsonschema:"default=0,description=Skip first N results for pagination"`
+	Cursor string `json:"cursor,omitempty" jsonschema:"description=nextCursor from the previous page (preferred over offset: stable and cheap for deep pages)"`
+}
+
+// TransformationSummary is a Transformation with its member attributes, mapping tables and metric count
+type TransformationSummary struct {
+	Type             string   `json:"type,omitempty"`
+	GUID             string   `json:"guid,omitempty"`
+	Name             string   `json:"name,omitempty"`
+	Location         string   `json:"location,omitempty"`
+	MemberAttributes []string `json:"memberAttributes,omitempty"`
+	MappingTables    []string `json:"mappingTables,omitempty"`
+	MetricCount      int      `json:"metricCount,omitempty"`
+}
+
+// ListTransformationsOutput defines the output of the list-transformations tool
+type ListTransformationsOutput struct {
+	Results     []TransformationSummary `json:"results"`
+	MoreResults bool                    `json:"moreResults"`
+	NextCursor  string                  `json:"nextCursor,omitempty"`
+	Truncated   []TruncatedList         `json:"truncated,omitempty"`
+}
+
+const listTransformationsQuery = `
+// List Transformation (time-series) objects with their members, mapping tables and usage
+// $query: optional GUID (full/partial) or name search term (null lists all)
+// $scope: optional location prefixes (project/folder scope), null for all projects
+// $offset: pagination offset (0, 100, 200, ...)
+// $after: {name, guid} of the last item of the previous page (cursor), or null
+//
+// Member attributes and mapping tables are direct DEPENDS_ON targets of the Transformation;
+// metrics applying it are direct DEPENDS_ON sources.
+
+WITH $query as query,
+     COALESCE($query =~ '^[A-Fa-f0-9]{8,}$', false) as isGuidLike
+
+MATCH (n:MSTRObject)
+WHERE n.type = 'Transformation'
+  AND n.guid IS NOT NULL
+  AND (
+    query IS NULL
+    OR (isGuidLike AND (n.guid = query OR n.guid STARTS WITH toUpper(query)))
+    OR (NOT isGuidLike AND toLower(n.name) CONTAINS toLower(query))
+  )
+  AND ($scope IS NULL OR any(p IN $scope WHERE replace(toLower(n.location), '\\', '/') + '/' STARTS WITH p))
+  AND ($after IS NULL OR n.name > $after.name OR (n.name = $after.name AND n.guid > $after.guid))  // keyset pagination after a cursor
+
+WITH n
+ORDER BY n.name ASC, n.guid ASC
+SKIP CASE WHEN $after IS NULL THEN $offset ELSE 0 END
+LIMIT 101  // Fetch 101 to determine if more results exist
+
+WITH n,
+     [(n)-[:DEPENDS_ON]->(a) WHERE a.type = 'Attribute' | a.name] as memberAttributes,
+     [(n)-[:DEPENDS_ON]->(t) WHERE t.type IN ['LogicalTable', 'Table'] | COALESCE(t.physical_table_name, t.name)] as mappingTables,
+     size([(m)-[:DEPENDS_ON]->(n) WHERE m.type IN ['Metric', 'DerivedMetric'] | m.guid]) as metricCount
+
+WITH collect({
+  type: 'Transformation',
+  guid: n.guid,
+  name: n.name,
+  location: n.location,
+  memberAttributes: memberAttributes,
+  mappingTables: mappingTables,
+  metricCount: metricCount
+}) as fetched
+
+// Return first 100; moreResults=true if 101st exists
+RETURN
+  fetched[0..100] as results,
+  size(fetched) > 100 as moreResults
+`
+
+// ListTransformationsSpec returns the MCP tool definition for list-transformations
+func ListTransformationsSpec() mcp.Tool {
+	return mcp.NewTool("list-transformations",
+		mcp.WithDescription(
+			"List Transformation (time-series) objects with their member attributes, mapping tables and how many metrics apply them.\n\n"+
+				"USE FOR:\n"+
+				"- Cataloguing time shifts (Last Year, Last Week, Year To Date) to design a date-intelligence strategy once\n"+
+				"- Finding a transformation by name: list-transformations(query=\"Last Year\")\n\n"+
+				"DO NOT USE FOR:\n"+
+				"- Member mappings and the metrics applying a transformation (use trace-transformation with the GUID instead)\n\n"+
+				"PAGINATION: Returns 100 results. If moreResults=true, call again with cursor=nextCursor (or offset+100).",
+		),
+		mcp.WithInputSchema[ListTransformationsInput](),
+		mcp.WithOutputSchema[ListTransformationsOutput](),
+		mcp.WithTitleAnnotation("List Transformations"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(true),
+	)
+}
+
+// ListTransformationsHandler returns the handler function for the list-transformations tool
+func ListTransformationsHandler(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleListTransformations(ctx, deps, request)
+	}
+}
+
+func handleListTransformations(ctx context.Context, deps *tools.ToolDependencies, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if deps.DBService == nil {
+		errMessage := "Database service is not initialized"
+		slog.ErrorContext(ctx, errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	// Parse input
+	var input ListTransformationsInput
+	if err := request.BindArguments(&input); err != nil {
+		slog.ErrorContext(ctx, "error binding arguments", "error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid input: %v", err)), nil
+	}
+
+	params := map[string]any{
+		"offset": input.Offset,
+		"scope":  scopeParam(deps, input.Scope),
+	}
+
+	// Handle query filter - nil if empty, otherwise the search term
+	if input.Query != "" {
+		params["query"] = input.Query
+	} else {
+		params["query"] = nil
+	}
+
+	pages, err := newPager("list-transformations", params, input.Cursor)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	reportProgress(ctx, stageSearching)
+	records, err := deps.DBService.ExecuteReadQuery(ctx, listTransformationsQuery, params)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to execute list-transformations query", "error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Query execution failed: %v", err)), nil
+	}
+
+	pages.attachNextCursor(ctx, records, "results")
+
+	reportProgress(ctx, stageFormatting)
+	response, err := deps.DBService.Neo4jRecordsToJSON(records)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to format list-transformations results to JSON", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(response), nil
+}
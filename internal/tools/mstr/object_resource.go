@@ -0,0 +1,237 @@
+package mstr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Object card formats of the mstr:// resources
+const (
+	objectCardJSON     = "json"
+	objectCardMarkdown = "markdown"
+)
+
+// objectCardDependencyLimit caps the immediate dependencies and dependents listed on a card
+const objectCardDependencyLimit = 50
+
+// objectResourceKind is one mstr://{kind}/{guid} resource template
+type objectResourceKind struct {
+	kind  string
+	title string
+	types []string
+}
+
+var objectResourceKinds = []objectResourceKind{
+	{kind: "metric", title: "Metric", types: []string{"Metric", "DerivedMetric"}},
+	{kind: "attribute", title: "Attribute", types: []string{"Attribute"}},
+	{kind: "report", title: "Report", types: []string{"Report", "GridReport", "Document"}},
+}
+
+const objectCardQuery = `
+// Object card of an MSTR resource: properties, mappings and immediate dependencies
+// $guid: object GUID
+// $types: object types accepted by the resource template
+// $limit: maximum dependencies/dependents listed (counts cover all)
+
+MATCH (n:MSTRObject {guid: $guid})
+WHERE n.type IN $types
+
+WITH n,
+     [(n)-[:DEPENDS_ON]->(d) | {type: d.type, guid: d.guid, name: d.name}] as dependencies,
+     [(d)-[:DEPENDS_ON]->(n) | {type: d.type, guid: d.guid, name: d.name}] as dependents
+
+RETURN {
+  type: n.type,
+  guid: n.guid,
+  name: n.name,
+  location: n.location,
+  description: n.description,
+  status: COALESCE(n.updated_parity_status, n.parity_status, 'No Status'),
+  priority: COALESCE(n.priority_level, n.inherited_priority_level),
+  formula: n.formula,
+  notes: COALESCE(n.updated_parity_notes, n.parity_notes),
+  ado_link: COALESCE(n.updated_ado_link, n.ado_link),
+  mapping: {
+    raw: COALESCE(n.updated_db_raw, n.db_raw),
+    serve: COALESCE(n.updated_db_serve, n.db_serve),
+    semantic: n.pb_semantic,
+    semanticName: n.pb_semantic_name,
+    semanticModel: n.pb_semantic_model,
+    edwTable: COALESCE(n.updated_edw_table, n.edw_table),
+    edwColumn: n.edw_column,
+    adeTable: COALESCE(n.updated_ade_db_table, n.ade_db_table),
+    adeColumn: n.ade_db_column,
+    dbEssential: n.db_essential,
+    pbEssential: n.pb_essential
+  },
+  reportCount: COALESCE(n.lineage_used_by_reports_count, 0),
+  tableCount: COALESCE(n.lineage_source_tables_count, 0),
+  dependencyCount: size(dependencies),
+  dependencies: dependencies[0..$limit],
+  dependentCount: size(dependents),
+  dependents: dependents[0..$limit]
+} as card
+`
+
+// ObjectResourceTemplates returns the mstr://metric, mstr://attribute and mstr://report resource templates
+func ObjectResourceTemplates(deps *tools.ToolDependencies) []server.ServerResourceTemplate {
+	templates := make([]server.ServerResourceTemplate, 0, len(objectResourceKinds))
+	for _, k := range objectResourceKinds {
+		templates = append(templates, server.ServerResourceTemplate{
+			Template: mcp.NewResourceTemplate(
+				fmt.Sprintf("mstr://%s/{guid}{?format}", k.kind),
+				fmt.Sprintf("MicroStrategy %s", k.title),
+				mcp.WithTemplateDescription(fmt.Sprintf(
+					"%s card by GUID: parity status, priority, Databricks/Power BI mappings and immediate dependencies. "+
+						"Add ?format=markdown for a readable card (default JSON).", k.title)),
+				mcp.WithTemplateMIMEType("application/json"),
+			),
+			Handler: objectResourceHandler(deps, k),
+		})
+	}
+	return templates
+}
+
+func objectResourceHandler(deps *tools.ToolDependencies, kind objectResourceKind) server.ResourceTemplateHandlerFunc {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		return handleObjectResource(ctx, deps, kind, request)
+	}
+}
+
+func handleObjectResource(ctx context.Context, deps *tools.ToolDependencies, kind objectResourceKind, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	if deps.DBService == nil {
+		errMessage := "Database service is not initialized"
+		slog.ErrorContext(ctx, errMessage)
+		return nil, fmt.Errorf("%s", errMessage)
+	}
+
+	// The template variables arrive as URI template values; parse the URI directly instead
+	uri := request.Params.URI
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return nil, fmt.Errorf("invalid resource URI %q: %w", uri, err)
+	}
+	guid := strings.Trim(parsed.Path, "/")
+	if guid == "" || strings.Contains(guid, "/") {
+		return nil, fmt.Errorf("invalid resource URI %q: expected mstr://%s/{guid}", uri, kind.kind)
+	}
+	format := parsed.Query().Get("format")
+	if format == "" {
+		format = objectCardJSON
+	}
+	if format != objectCardJSON && format != objectCardMarkdown {
+		return nil, fmt.Errorf("invalid format '%s': must be 'json' or 'markdown'", format)
+	}
+
+	params := map[string]any{
+		"guid":  guid,
+		"types": kind.types,
+		"limit": objectCardDependencyLimit,
+	}
+
+	slog.InfoContext(ctx, "reading object resource", "uri", uri)
+
+	records, err := deps.DBService.ExecuteReadQuery(ctx, objectCardQuery, params)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to execute object card query", "error", err)
+		return nil, fmt.Errorf("query execution failed: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%s %s not found", kind.title, guid)
+	}
+	raw, _ := records[0].Get("card")
+	card, ok := raw.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("unexpected object card for %s %s", kind.title, guid)
+	}
+
+	if format == objectCardMarkdown {
+		return []mcp.ResourceContents{mcp.TextResourceContents{
+			URI:      uri,
+			MIMEType: "text/markdown",
+			Text:     renderObjectCard(card),
+		}}, nil
+	}
+
+	text, err := json.MarshalIndent(card, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to format object card: %w", err)
+	}
+	return []mcp.ResourceContents{mcp.TextResourceContents{
+		URI:      uri,
+		MIMEType: "application/json",
+		Text:     string(text),
+	}}, nil
+}
+
+// renderObjectCard renders an object card as markdown, skipping empty values
+func renderObjectCard(card map[string]any) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %v\n\n`%v` · `%v`\n\n", card["name"], card["type"], card["guid"])
+
+	for _, field := range []struct{ key, label string }{
+		{"location", "Location"},
+		{"description", "Description"},
+		{"status", "Status"},
+		{"priority", "Priority"},
+		{"formula", "Formula"},
+		{"notes", "Notes"},
+		{"ado_link", "ADO"},
+		{"reportCount", "Reports"},
+		{"tableCount", "Source tables"},
+	} {
+		if v, ok := card[field.key]; ok && v != nil && v != "" {
+			fmt.Fprintf(&b, "- **%s:** %v\n", field.label, v)
+		}
+	}
+
+	if mapping, ok := card["mapping"].(map[string]any); ok {
+		var rows strings.Builder
+		for _, field := range []struct{ key, label string }{
+			{"raw", "Databricks raw"},
+			{"serve", "Databricks serve"},
+			{"dbEssential", "Databricks essential"},
+			{"semantic", "Power BI semantic"},
+			{"semanticName", "Power BI semantic name"},
+			{"semanticModel", "Power BI semantic model"},
+			{"pbEssential", "Power BI essential"},
+			{"edwTable", "EDW table"},
+			{"edwColumn", "EDW column"},
+			{"adeTable", "ADE table"},
+			{"adeColumn", "ADE column"},
+		} {
+			if v, ok := mapping[field.key]; ok && v != nil && v != "" {
+				fmt.Fprintf(&rows, "| %s | %v |\n", field.label, v)
+			}
+		}
+		if rows.Len() > 0 {
+			b.WriteString("\n## Mappings\n\n| Mapping | Value |\n| --- | --- |\n")
+			b.WriteString(rows.String())
+		}
+	}
+
+	renderObjectCardList(&b, "Depends on", card["dependencies"], card["dependencyCount"])
+	renderObjectCardList(&b, "Used by", card["dependents"], card["dependentCount"])
+	return b.String()
+}
+
+func renderObjectCardList(b *strings.Builder, title string, items any, count any) {
+	list, _ := items.([]any)
+	if len(list) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "\n## %s (%v)\n\n", title, count)
+	for _, item := range list {
+		if m, ok := item.(map[string]any); ok {
+			fmt.Fprintf(b, "- %v: %v (`%v`)\n", m["type"], m["name"], m["guid"])
+		}
+	}
+}
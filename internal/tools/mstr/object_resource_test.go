@@ -0,0 +1,157 @@
+package mstr_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	db "github.com/brunogc-cit/flow-microstrategy-mcp/internal/database/mocks"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools/mstr"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/neo4j/neo4j-go-driver/v6/neo4j"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// readResource reads a resource through an MCP server exposing the object resource templates
+func readResource(t *testing.T, deps *tools.ToolDependencies, uri string) mcp.JSONRPCMessage {
+	t.Helper()
+	s := server.NewMCPServer("test", "test", server.WithResourceCapabilities(false, false))
+	s.AddResourceTemplates(mstr.ObjectResourceTemplates(deps)...)
+	message := fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"method":"resources/read","params":{"uri":%q}}`, uri)
+	return s.HandleMessage(context.Background(), json.RawMessage(message))
+}
+
+func readResourceText(t *testing.T, deps *tools.ToolDependencies, uri string) mcp.TextResourceContents {
+	t.Helper()
+	response, ok := readResource(t, deps, uri).(mcp.JSONRPCResponse)
+	require.True(t, ok, "expected a successful response")
+	result, ok := response.Result.(mcp.ReadResourceResult)
+	require.True(t, ok)
+	require.Len(t, result.Contents, 1)
+	contents, ok := result.Contents[0].(mcp.TextResourceContents)
+	require.True(t, ok)
+	return contents
+}
+
+func objectCard() map[string]any {
+	return map[string]any{
+		"type":     "Metric",
+		"guid":     "2F00974D44E1D0D24CA344ABD872806A",
+		"name":     "Retail Sales",
+		"location": `\Retail\Public Objects\Metrics`,
+		"status":   "Planned",
+		"priority": int64(1),
+		"formula":  "Sum(Sales)",
+		"mapping": map[string]any{
+			"raw":         "Y",
+			"serve":       nil,
+			"dbEssential": "Y",
+		},
+		"dependencyCount": int64(1),
+		"dependencies": []any{
+			map[string]any{"type": "Fact", "guid": "AB12CD34", "name": "Sales"},
+		},
+		"dependentCount": int64(0),
+		"dependents":     []any{},
+	}
+}
+
+func TestObjectResourceTemplates(t *testing.T) {
+	templates := mstr.ObjectResourceTemplates(&tools.ToolDependencies{})
+	uris := make([]string, 0, len(templates))
+	for _, template := range templates {
+		uris = append(uris, template.Template.URITemplate.Raw())
+	}
+	assert.Equal(t, []string{
+		"mstr://metric/{guid}{?format}",
+		"mstr://attribute/{guid}{?format}",
+		"mstr://report/{guid}{?format}",
+	}, uris)
+}
+
+func TestObjectResourceHandler(t *testing.T) {
+	t.Run("renders the card as JSON by default", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), map[string]any{
+				"guid":  "2F00974D44E1D0D24CA344ABD872806A",
+				"types": []string{"Metric", "DerivedMetric"},
+				"limit": 50,
+			}).
+			Return([]*neo4j.Record{{Keys: []string{"card"}, Values: []any{objectCard()}}}, nil)
+
+		contents := readResourceText(t, &tools.ToolDependencies{DBService: mockDB}, "mstr://metric/2F00974D44E1D0D24CA344ABD872806A")
+		assert.Equal(t, "application/json", contents.MIMEType)
+
+		var card map[string]any
+		require.NoError(t, json.Unmarshal([]byte(contents.Text), &card))
+		assert.Equal(t, "Retail Sales", card["name"])
+		assert.Equal(t, "Y", card["mapping"].(map[string]any)["raw"])
+	})
+
+	t.Run("renders the card as markdown", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return([]*neo4j.Record{{Keys: []string{"card"}, Values: []any{objectCard()}}}, nil)
+
+		contents := readResourceText(t, &tools.ToolDependencies{DBService: mockDB}, "mstr://metric/2F00974D44E1D0D24CA344ABD872806A?format=markdown")
+		assert.Equal(t, "text/markdown", contents.MIMEType)
+		assert.Contains(t, contents.Text, "# Retail Sales")
+		assert.Contains(t, contents.Text, "- **Formula:** Sum(Sales)")
+		assert.Contains(t, contents.Text, "| Databricks raw | Y |")
+		assert.NotContains(t, contents.Text, "Databricks serve")
+		assert.Contains(t, contents.Text, "## Depends on (1)")
+		assert.Contains(t, contents.Text, "- Fact: Sales (`AB12CD34`)")
+		assert.NotContains(t, contents.Text, "Used by")
+	})
+
+	t.Run("uses the report types", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var params map[string]any
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, p map[string]any) ([]*neo4j.Record, error) {
+				params = p
+				return []*neo4j.Record{{Keys: []string{"card"}, Values: []any{objectCard()}}}, nil
+			})
+
+		readResourceText(t, &tools.ToolDependencies{DBService: mockDB}, "mstr://report/ABCDEF12")
+		assert.Equal(t, []string{"Report", "GridReport", "Document"}, params["types"])
+	})
+
+	t.Run("returns an error when the object is not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).Return([]*neo4j.Record{}, nil)
+
+		response, ok := readResource(t, &tools.ToolDependencies{DBService: mockDB}, "mstr://attribute/ABCDEF12").(mcp.JSONRPCError)
+		require.True(t, ok)
+		assert.Contains(t, response.Error.Message, "Attribute ABCDEF12 not found")
+	})
+
+	t.Run("rejects an unknown format", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		response, ok := readResource(t, &tools.ToolDependencies{DBService: db.NewMockService(ctrl)}, "mstr://metric/ABCDEF12?format=xml").(mcp.JSONRPCError)
+		require.True(t, ok)
+		assert.Contains(t, response.Error.Message, "invalid format 'xml'")
+	})
+}
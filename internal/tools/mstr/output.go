@@ -0,0 +1,137 @@
+package mstr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Output types shared by the MSTR tools. Each tool publishes its own XxxOutput type as MCP output
+// schema (mcp.WithOutputSchema); WithStructuredOutput returns the JSON text as structuredContent.
+//
+// Item fields are optional (omitempty): nulls are removed and the fields/profile parameters select
+// the fields kept on each object, so only the top-level fields of an output are required.
+
+// ObjectRef is a related MSTR object (qualifying object, dependency, member, ...)
+type ObjectRef struct {
+	Type   string `json:"type,omitempty"`
+	GUID   string `json:"guid,omitempty"`
+	Name   string `json:"name,omitempty"`
+	Status string `json:"status,omitempty"`
+}
+
+// ReportRef is a prioritized report using an object
+type ReportRef struct {
+	Type     string `json:"type,omitempty"`
+	GUID     string `json:"guid,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Priority int    `json:"priority,omitempty"`
+	Area     string `json:"area,omitempty"`
+}
+
+// TableRef is a logical or physical table
+type TableRef struct {
+	Type          string `json:"type,omitempty"`
+	GUID          string `json:"guid,omitempty"`
+	Name          string `json:"name,omitempty"`
+	PhysicalTable string `json:"physicalTable,omitempty"`
+	Database      string `json:"database,omitempty"`
+}
+
+// ParityMapping holds the parity notes and Databricks/EDW/ADE/Power BI mappings of a Metric or Attribute
+// (updated_ values take precedence). The essential flags are stored as strings (Y/Yes) or booleans.
+type ParityMapping struct {
+	Notes         string `json:"notes,omitempty"`
+	Raw           string `json:"raw,omitempty"`
+	Serve         string `json:"serve,omitempty"`
+	Semantic      string `json:"semantic,omitempty"`
+	EDWTable      string `json:"edwTable,omitempty"`
+	EDWColumn     string `json:"edwColumn,omitempty"`
+	ADETable      string `json:"adeTable,omitempty"`
+	ADEColumn     string `json:"adeColumn,omitempty"`
+	SemanticName  string `json:"semanticName,omitempty"`
+	SemanticModel string `json:"semanticModel,omitempty"`
+	DBEssential   any    `json:"dbEssential,omitempty"`
+	PBEssential   any    `json:"pbEssential,omitempty"`
+	ADOLink       string `json:"ado_link,omitempty"`
+}
+
+// MetricDetails is a Metric with its parity status and mappings
+type MetricDetails struct {
+	Type     string `json:"type,omitempty"`
+	GUID     string `json:"guid,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Status   string `json:"status,omitempty"`
+	Priority int    `json:"priority,omitempty"`
+	Formula  string `json:"formula,omitempty"`
+	ParityMapping
+}
+
+// AttributeDetails is an Attribute with its parity status and mappings
+type AttributeDetails struct {
+	Type      string `json:"type,omitempty"`
+	GUID      string `json:"guid,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Status    string `json:"status,omitempty"`
+	Priority  int    `json:"priority,omitempty"`
+	FormsJSON string `json:"forms_json,omitempty"`
+	ParityMapping
+}
+
+// TruncatedList is a list of a response cut by the response budget (tools.WithResponseBudget), by its
+// dotted path from the response object
+type TruncatedList struct {
+	Path    string `json:"path"`
+	Kept    int    `json:"kept"`
+	Omitted int    `json:"omitted"`
+}
+
+// WithStructuredOutput returns the JSON text of an MSTR tool with an output schema as structuredContent.
+// The text stays the fallback for clients without structured output support. Handlers return either a
+// JSON object or the single record of a Cypher query (a one-element array), which becomes the object.
+// Nulls are removed so the content conforms to the schema even with profile=full.
+func WithStructuredOutput(tool server.ServerTool) server.ServerTool {
+	if tool.Tool.OutputSchema.Type == "" {
+		return tool
+	}
+	handler := tool.Handler
+	tool.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result, err := handler(ctx, request)
+		if err != nil || result == nil || result.IsError {
+			return result, err
+		}
+		if structured, ok := structuredContent(result); ok {
+			result.StructuredContent = structured
+		} else {
+			slog.WarnContext(ctx, "tool result is not a JSON object, structured content omitted", "tool", tool.Tool.Name)
+		}
+		return result, nil
+	}
+	return tool
+}
+
+// structuredContent decodes the first text content of a tool result into a JSON object
+func structuredContent(result *mcp.CallToolResult) (map[string]any, bool) {
+	for _, content := range result.Content {
+		text, ok := content.(mcp.TextContent)
+		if !ok {
+			continue
+		}
+		decoder := json.NewDecoder(bytes.NewReader([]byte(text.Text)))
+		decoder.UseNumber() // keep integers (e.g. counts, priorities) as written
+		var value any
+		if err := decoder.Decode(&value); err != nil {
+			return nil, false
+		}
+		if records, isList := value.([]any); isList && len(records) == 1 {
+			value = records[0]
+		}
+		object, ok := projectValue(value, nil).(map[string]any)
+		return object, ok
+	}
+	return nil, false
+}
@@ -0,0 +1,317 @@
+package mstr_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	db "github.com/brunogc-cit/flow-microstrategy-mcp/internal/database/mocks"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools/mstr"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/neo4j/neo4j-go-driver/v6/neo4j"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// recordsJSON formats records the way the database service does: one JSON object per record
+func recordsJSON(records []*neo4j.Record) (string, error) {
+	rows := make([]map[string]any, 0, len(records))
+	for _, record := range records {
+		rows = append(rows, record.AsMap())
+	}
+	data, err := json.Marshal(rows)
+	return string(data), err
+}
+
+// structuredTool wraps a tool the way the server registers MSTR tools
+func structuredTool(spec mcp.Tool, handler server.ToolHandlerFunc) server.ServerTool {
+	return mstr.WithStructuredOutput(mstr.WithFieldProjection(server.ServerTool{Tool: spec, Handler: handler}))
+}
+
+// decodeStructured decodes the structured content of a result into an output type, rejecting
+// fields the type does not declare, and validates it against the output schema
+func decodeStructured(t *testing.T, tool server.ServerTool, result *mcp.CallToolResult, output any) {
+	t.Helper()
+	require.False(t, result.IsError, resultText(t, result))
+	structured, ok := result.StructuredContent.(map[string]any)
+	require.True(t, ok, "expected structured content")
+
+	schema, err := json.Marshal(tool.Tool.OutputSchema)
+	require.NoError(t, err)
+	var root map[string]any
+	require.NoError(t, json.Unmarshal(schema, &root))
+	validateSchema(t, tool.Tool.Name, root, structured)
+
+	data, err := json.Marshal(structured)
+	require.NoError(t, err)
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	require.NoError(t, decoder.Decode(output))
+}
+
+// validateSchema checks value against the subset of JSON Schema generated for output types: type,
+// properties, required and items. Schemas without type (any) accept every value.
+func validateSchema(t *testing.T, path string, schema, value any) {
+	t.Helper()
+	rules, ok := schema.(map[string]any)
+	if !ok {
+		return
+	}
+	switch rules["type"] {
+	case "object":
+		object, ok := value.(map[string]any)
+		require.True(t, ok, "%s: expected an object, got %T", path, value)
+		required, _ := rules["required"].([]any)
+		for _, field := range required {
+			assert.Contains(t, object, field, "%s: required field", path)
+		}
+		properties, _ := rules["properties"].(map[string]any)
+		for field, fieldValue := range object {
+			if property, ok := properties[field]; ok {
+				validateSchema(t, path+"."+field, property, fieldValue)
+			}
+		}
+	case "array":
+		items, ok := value.([]any)
+		require.True(t, ok, "%s: expected an array, got %T", path, value)
+		for i, item := range items {
+			validateSchema(t, fmt.Sprintf("%s[%d]", path, i), rules["items"], item)
+		}
+	case "string":
+		assert.IsType(t, "", value, "%s: expected a string", path)
+	case "boolean":
+		assert.IsType(t, false, value, "%s: expected a boolean", path)
+	case "integer":
+		number, ok := value.(json.Number)
+		require.True(t, ok, "%s: expected an integer, got %T", path, value)
+		_, err := number.Int64()
+		assert.NoError(t, err, "%s: expected an integer", path)
+	case "number":
+		assert.IsType(t, json.Number(""), value, "%s: expected a number", path)
+	}
+}
+
+func TestWithStructuredOutput(t *testing.T) {
+	textHandler := func(text string) server.ToolHandlerFunc {
+		return func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText(text), nil
+		}
+	}
+	withSchema := mcp.NewTool("with-schema", mcp.WithOutputSchema[mstr.GetFilterOutput]())
+
+	t.Run("unwraps the single record", func(t *testing.T) {
+		tool := mstr.WithStructuredOutput(server.ServerTool{Tool: withSchema, Handler: textHandler(`[{"result":{"moreResults":false,"reports":[{"guid":"R1","priority":1,"area":null}]}}]`)})
+		result := callTool(t, tool.Handler, nil)
+		assert.Equal(t, map[string]any{"result": map[string]any{
+			"moreResults": false,
+			"reports":     []any{map[string]any{"guid": "R1", "priority": json.Number("1")}},
+		}}, result.StructuredContent)
+		assert.Contains(t, resultText(t, result), `"area":null`, "text fallback is unchanged")
+	})
+
+	t.Run("keeps objects", func(t *testing.T) {
+		tool := mstr.WithStructuredOutput(server.ServerTool{Tool: withSchema, Handler: textHandler(`{"results":[],"moreResults":true}`)})
+		result := callTool(t, tool.Handler, nil)
+		assert.Equal(t, map[string]any{"results": []any{}, "moreResults": true}, result.StructuredContent)
+	})
+
+	t.Run("omits structured content for other JSON", func(t *testing.T) {
+		tool := mstr.WithStructuredOutput(server.ServerTool{Tool: withSchema, Handler: textHandler(`[]`)})
+		result := callTool(t, tool.Handler, nil)
+		assert.Nil(t, result.StructuredContent)
+	})
+
+	t.Run("leaves errors and tools without schema unchanged", func(t *testing.T) {
+		failing := mstr.WithStructuredOutput(server.ServerTool{Tool: withSchema, Handler: func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultError("Query execution failed: boom"), nil
+		}})
+		assert.Nil(t, callTool(t, failing.Handler, nil).StructuredContent)
+
+		plain := mstr.WithStructuredOutput(server.ServerTool{Tool: mcp.NewTool("plain"), Handler: textHandler(`{"a":1}`)})
+		assert.Nil(t, callTool(t, plain.Handler, nil).StructuredContent)
+	})
+}
+
+func TestStructuredOutputConformsToSchema(t *testing.T) {
+	t.Run("trace-metric", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return([]*neo4j.Record{{
+				Keys: []string{"result"},
+				Values: []any{map[string]any{
+					"metric": map[string]any{
+						"type": "Metric", "guid": "M1", "name": "Retail Sales", "status": "Planned",
+						"priority": int64(1), "formula": "Sum(Sales)", "raw": "Y", "serve": nil, "ado_link": nil,
+						"dbEssential": true, "pbEssential": "Y",
+					},
+					"direction":    "downstream",
+					"tables":       []any{map[string]any{"type": "LogicalTable", "guid": "T1", "name": "Sales", "physicalTable": "fact_sales", "database": nil}},
+					"dependencies": []any{map[string]any{"type": "Fact", "guid": "F1", "name": "Sales", "formula": nil}},
+					"moreResults":  false,
+				}},
+			}}, nil)
+		mockDB.EXPECT().Neo4jRecordsToJSON(gomock.Any()).DoAndReturn(recordsJSON)
+
+		tool := structuredTool(mstr.TraceMetricSpec(), mstr.TraceMetricHandler(&tools.ToolDependencies{DBService: mockDB}))
+		result := callTool(t, tool.Handler, map[string]any{"guid": "M1", "direction": "downstream"})
+
+		var output mstr.TraceMetricOutput
+		decodeStructured(t, tool, result, &output)
+		assert.Equal(t, "Y", output.Result.Metric.Raw)
+		assert.Equal(t, 1, output.Result.Metric.Priority)
+		assert.Equal(t, true, output.Result.Metric.DBEssential)
+		assert.Equal(t, "Y", output.Result.Metric.PBEssential)
+		assert.Equal(t, "fact_sales", output.Result.Tables[0].PhysicalTable)
+	})
+
+	t.Run("search-filters with profile", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return([]*neo4j.Record{{
+				Keys: []string{"results", "moreResults"},
+				Values: []any{[]any{map[string]any{
+					"type": "Filter", "guid": "F1", "name": "Current Week", "location": nil,
+					"expression": "Week = Current", "qualifiesCount": int64(1), "reportCount": int64(3),
+				}}, false},
+			}}, nil)
+		mockDB.EXPECT().Neo4jRecordsToJSON(gomock.Any()).DoAndReturn(recordsJSON)
+
+		tool := structuredTool(mstr.SearchFiltersSpec(), mstr.SearchFiltersHandler(&tools.ToolDependencies{DBService: mockDB}))
+		result := callTool(t, tool.Handler, map[string]any{"query": "week", "profile": "minimal"})
+
+		var output mstr.SearchFiltersOutput
+		decodeStructured(t, tool, result, &output)
+		require.Len(t, output.Results, 1)
+		assert.Equal(t, mstr.FilterSearchResult{Type: "Filter", GUID: "F1", Name: "Current Week"}, output.Results[0])
+	})
+
+	t.Run("essential-objects", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return([]*neo4j.Record{{
+				Keys: []string{"result"},
+				Values: []any{map[string]any{
+					"platform": "databricks", "total": int64(1), "complete": int64(0), "incomplete": int64(1),
+					"objects": []any{map[string]any{
+						"type": "Metric", "guid": "M1", "name": "Margin", "status": "Planned", "priority": int64(2),
+						"mapping":  []any{map[string]any{"name": "raw", "value": "Y"}, map[string]any{"name": "serve", "value": nil}},
+						"missing":  []any{"serve"},
+						"complete": false, "semanticName": nil, "semanticModel": nil, "reportCount": int64(1),
+						"reports": []any{map[string]any{"name": "Daily Margin", "guid": "R1", "priority": int64(1), "area": nil}},
+					}},
+					"moreResults": false,
+				}},
+			}}, nil)
+		mockDB.EXPECT().Neo4jRecordsToJSON(gomock.Any()).DoAndReturn(recordsJSON)
+
+		tool := structuredTool(mstr.EssentialObjectsSpec(), mstr.EssentialObjectsHandler(&tools.ToolDependencies{DBService: mockDB}))
+		var output mstr.EssentialObjectsOutput
+		decodeStructured(t, tool, callTool(t, tool.Handler, map[string]any{"platform": "databricks"}), &output)
+		require.Len(t, output.Result.Objects, 1)
+		assert.Equal(t, 2, output.Result.Objects[0].Priority)
+		assert.Equal(t, []string{"serve"}, output.Result.Objects[0].Missing)
+		assert.Equal(t, 1, output.Result.Objects[0].Reports[0].Priority)
+	})
+
+	t.Run("trace-columns forward", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return([]*neo4j.Record{{
+				Keys:   []string{"metric", "sources"},
+				Values: []any{map[string]any{"guid": "M1", "name": "Net Sales", "type": "Metric", "dbEssential": "Y"}, []any{salesFactSource()}},
+			}}, nil)
+
+		tool := structuredTool(mstr.TraceColumnsSpec(), mstr.TraceColumnsHandler(&tools.ToolDependencies{DBService: mockDB}))
+		var output mstr.TraceColumnsOutput
+		decodeStructured(t, tool, callTool(t, tool.Handler, map[string]any{"guid": "M1"}), &output)
+		require.NotNil(t, output.Metric)
+		assert.Equal(t, 3, output.ColumnCount)
+		assert.Equal(t, "Sales Amount", output.Columns[0].Via.Name)
+	})
+
+	t.Run("trace-columns reverse", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Regex(`LIMIT \$limit`), gomock.Any()).
+			Return([]*neo4j.Record{{Keys: []string{"source"}, Values: []any{salesFactSource()}}}, nil)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Regex(`via.guid IN \$guids`), gomock.Any()).
+			Return([]*neo4j.Record{{
+				Keys:   []string{"guid", "metrics"},
+				Values: []any{"F1", []any{map[string]any{"guid": "M1", "name": "Net Sales", "type": "Metric"}}},
+			}}, nil)
+
+		tool := structuredTool(mstr.TraceColumnsSpec(), mstr.TraceColumnsHandler(&tools.ToolDependencies{DBService: mockDB}))
+		var output mstr.TraceColumnsOutput
+		decodeStructured(t, tool, callTool(t, tool.Handler, map[string]any{"column": "sales_amount"}), &output)
+		require.Len(t, output.Metrics, 1)
+		assert.Equal(t, "Net Sales", output.Metrics[0].Name)
+	})
+
+	t.Run("search-by-definition", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return([]*neo4j.Record{{
+				Keys:   []string{"type", "guid", "name", "location", "status", "definition"},
+				Values: []any{"Metric", "M1", "Net Sales", "Retail/Metrics", "Planned", map[string]any{"formula": "Sum(NetSalesValue)", "physical_table_name": nil}},
+			}}, nil)
+
+		tool := structuredTool(mstr.SearchByDefinitionSpec(), mstr.SearchByDefinitionHandler(&tools.ToolDependencies{DBService: mockDB}))
+		var output mstr.SearchByDefinitionOutput
+		decodeStructured(t, tool, callTool(t, tool.Handler, map[string]any{"query": "netsalesvalue"}), &output)
+		require.Len(t, output.Results, 1)
+		assert.Equal(t, "formula", output.Results[0].Matches[0].Field)
+	})
+
+	for name, args := range map[string]map[string]any{
+		"find-similar-reports clusters": {"threshold": 0.75},
+		"find-similar-reports by guid":  {"guid": "R3", "threshold": 0.5},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDB := db.NewMockService(ctrl)
+			if args["guid"] != nil {
+				expectSimilarTo(mockDB, "R3", similarityRecords()[2])
+			} else {
+				mockDB.EXPECT().
+					ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(similarityRecords(), nil)
+			}
+
+			tool := structuredTool(mstr.FindSimilarReportsSpec(), mstr.FindSimilarReportsHandler(&tools.ToolDependencies{DBService: mockDB}))
+			var output mstr.FindSimilarReportsOutput
+			decodeStructured(t, tool, callTool(t, tool.Handler, args), &output)
+			assert.NotZero(t, output.Threshold)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package mstr
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Progress stages reported by the MSTR tools
+const (
+	stageSearching  = "searching"
+	stageRanking    = "ranking candidates"
+	stageCounting   = "counting facets"
+	stageResolving  = "resolving name"
+	stageTraversing = "traversing"
+	stageComparing  = "comparing reports"
+	stageFormatting = "formatting"
+)
+
+type progressKey struct{}
+
+// progressReporter sends notifications/progress for a tool call whose client supplied a progress token.
+// Progress is a counter increased by every notification (the total number of steps is not known).
+type progressReporter struct {
+	server *server.MCPServer
+	token  mcp.ProgressToken
+	tool   string
+
+	mu       sync.Mutex
+	progress float64
+}
+
+// WithProgress reports the stages of an MSTR tool call (searching, traversing, comparing,
+// formatting, ...) as MCP progress notifications when the request carries a progress token.
+// Handlers report stages with reportProgress; without a token these calls do nothing.
+func WithProgress(tool server.ServerTool) server.ServerTool {
+	handler := tool.Handler
+	name := tool.Tool.Name
+	tool.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		mcpServer := server.ServerFromContext(ctx)
+		if request.Params.Meta == nil || request.Params.Meta.ProgressToken == nil || mcpServer == nil {
+			return handler(ctx, request)
+		}
+		reporter := &progressReporter{server: mcpServer, token: request.Params.Meta.ProgressToken, tool: name}
+		return handler(context.WithValue(ctx, progressKey{}, reporter), request)
+	}
+	return tool
+}
+
+// reportProgress notifies the client that a tool call reached a stage
+func reportProgress(ctx context.Context, stage string) {
+	if reporter, ok := ctx.Value(progressKey{}).(*progressReporter); ok {
+		reporter.notify(ctx, stage)
+	}
+}
+
+// reportBatchProgress notifies the client that a batch of a stage is done, e.g. "traversing (2/5)"
+func reportBatchProgress(ctx context.Context, stage string, done, total int) {
+	reportProgress(ctx, fmt.Sprintf("%s (%d/%d)", stage, done, total))
+}
+
+func (r *progressReporter) notify(ctx context.Context, message string) {
+	r.mu.Lock()
+	r.progress++
+	progress := r.progress
+	r.mu.Unlock()
+
+	err := r.server.SendNotificationToClient(ctx, "notifications/progress", map[string]any{
+		"progressToken": r.token,
+		"progress":      progress,
+		"message":       message,
+	})
+	if err != nil {
+		// Progress is best effort: the call continues without notifications
+		slog.DebugContext(ctx, "failed to send progress notification", "tool", r.tool, "message", message, "error", err)
+	}
+}
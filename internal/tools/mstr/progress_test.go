@@ -0,0 +1,120 @@
+package mstr_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	db "github.com/brunogc-cit/flow-microstrategy-mcp/internal/database/mocks"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools/mstr"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/neo4j/neo4j-go-driver/v6/neo4j"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// progressSession is an initialized client session collecting the notifications sent to it
+type progressSession struct {
+	notifications chan mcp.JSONRPCNotification
+}
+
+func (s *progressSession) Initialize()       {}
+func (s *progressSession) Initialized() bool { return true }
+func (s *progressSession) SessionID() string { return "progress-test" }
+func (s *progressSession) NotificationChannel() chan<- mcp.JSONRPCNotification {
+	return s.notifications
+}
+
+// callWithProgress calls a tool through an MCP server, with the progress token in _meta when not nil,
+// and returns the progress notifications received
+func callWithProgress(t *testing.T, tool server.ServerTool, args map[string]any, token any) []mcp.NotificationParams {
+	t.Helper()
+	s := server.NewMCPServer("test", "test", server.WithToolCapabilities(false))
+	s.AddTools(mstr.WithProgress(tool))
+
+	params := map[string]any{"name": tool.Tool.Name, "arguments": args}
+	if token != nil {
+		params["_meta"] = map[string]any{"progressToken": token}
+	}
+	message, err := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": 1, "method": "tools/call", "params": params})
+	require.NoError(t, err)
+
+	session := &progressSession{notifications: make(chan mcp.JSONRPCNotification, 100)}
+	response, ok := s.HandleMessage(s.WithContext(context.Background(), session), message).(mcp.JSONRPCResponse)
+	require.True(t, ok, "expected a successful response")
+	result, ok := response.Result.(*mcp.CallToolResult)
+	require.True(t, ok)
+	require.False(t, result.IsError, resultText(t, result))
+
+	close(session.notifications)
+	var received []mcp.NotificationParams
+	for notification := range session.notifications {
+		assert.Equal(t, "notifications/progress", notification.Method)
+		received = append(received, notification.Params)
+	}
+	return received
+}
+
+func TestWithProgress(t *testing.T) {
+	traceMetricTool := func(ctrl *gomock.Controller) server.ServerTool {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return([]*neo4j.Record{{
+				Keys:   []string{"result"},
+				Values: []any{map[string]any{"reports": []any{}, "moreResults": false}},
+			}}, nil)
+		mockDB.EXPECT().Neo4jRecordsToJSON(gomock.Any()).Return(`[{"result":{}}]`, nil)
+		return server.ServerTool{Tool: mstr.TraceMetricSpec(), Handler: mstr.TraceMetricHandler(&tools.ToolDependencies{DBService: mockDB})}
+	}
+
+	t.Run("reports stages with the progress token", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		received := callWithProgress(t, traceMetricTool(ctrl), map[string]any{"guid": "M1", "direction": "upstream"}, "trace-1")
+		require.Len(t, received, 2)
+		for i, stage := range []string{"traversing", "formatting"} {
+			assert.Equal(t, "trace-1", received[i].AdditionalFields["progressToken"])
+			assert.Equal(t, float64(i+1), received[i].AdditionalFields["progress"])
+			assert.Equal(t, stage, received[i].AdditionalFields["message"])
+		}
+	})
+
+	t.Run("sends nothing without a progress token", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		assert.Empty(t, callWithProgress(t, traceMetricTool(ctrl), map[string]any{"guid": "M1", "direction": "upstream"}, nil))
+	})
+
+	t.Run("reports batches of compared reports", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		records := make([]*neo4j.Record, 0, 1200)
+		for i := range 1200 {
+			records = append(records, reportRecord(fmt.Sprintf("R%04d", i), fmt.Sprintf("Report %04d", i), fmt.Sprintf("M%d", i)))
+		}
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).Return(records, nil)
+		tool := server.ServerTool{Tool: mstr.FindSimilarReportsSpec(), Handler: mstr.FindSimilarReportsHandler(&tools.ToolDependencies{DBService: mockDB})}
+
+		received := callWithProgress(t, tool, map[string]any{}, int64(7))
+		messages := make([]any, 0, len(received))
+		for _, params := range received {
+			messages = append(messages, params.AdditionalFields["message"])
+		}
+		assert.Equal(t, []any{
+			"traversing",
+			"comparing reports",
+			"comparing reports (500/1200)",
+			"comparing reports (1000/1200)",
+			"formatting",
+		}, messages)
+	})
+}
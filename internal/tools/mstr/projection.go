@@ -0,0 +1,260 @@
+package mstr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Output profiles accepted by the profile parameter of every MSTR tool
+const (
+	profileMinimal = "minimal"
+	profileMapping = "mapping"
+	profileFull    = "full"
+)
+
+// profileFields are the object fields returned by each profile (nil: every field)
+var profileFields = map[string][]string{
+	profileMinimal: {"type", "guid", "name", "status", "priority", "score"},
+	profileMapping: {
+		"type", "guid", "name", "status", "priority", "score", "notes",
+		"raw", "serve", "semantic", "edwTable", "edwColumn", "adeTable", "adeColumn",
+		"semanticName", "semanticModel", "dbEssential", "pbEssential",
+	},
+	profileFull: nil,
+}
+
+// projectionInput holds the output parameters added to every MSTR tool by WithFieldProjection
+type projectionInput struct {
+	Fields  []string `json:"fields,omitempty"`
+	Profile string   `json:"profile,omitempty"`
+}
+
+// projectionSchema is merged into the input schema of every MSTR tool
+var projectionSchema = map[string]any{
+	"fields": map[string]any{
+		"type":        "array",
+		"items":       map[string]any{"type": "string"},
+		"description": "Return only these fields of each MSTR object (e.g. [\"guid\",\"name\",\"status\"]); nested lists are dropped unless listed, use dotted paths for their fields (e.g. reports.guid). Overrides profile",
+	},
+	"profile": map[string]any{
+		"type":        "string",
+		"enum":        []string{profileMinimal, profileMapping, profileFull},
+		"description": "Output profile: 'minimal' (identity, status, priority), 'mapping' (minimal + Databricks/Power BI mappings) or 'full' (every field, nulls included). Default: every field, nulls removed",
+	},
+}
+
+// WithFieldProjection adds the fields/profile parameters to an MSTR tool and projects its JSON output.
+// By default null fields are removed; fields and profile select the fields kept on each MSTR object
+// (any JSON object with a guid), so new tools get compact output without changing their Cypher.
+func WithFieldProjection(tool server.ServerTool) server.ServerTool {
+	tool.Tool = projectionSpec(tool.Tool)
+	handler := tool.Handler
+	tool.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var input projectionInput
+		if err := request.BindArguments(&input); err != nil {
+			slog.ErrorContext(ctx, "error binding arguments", "error", err)
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid input: %v", err)), nil
+		}
+		fields, ok := profileFields[input.Profile]
+		if input.Profile != "" && !ok {
+			return mcp.NewToolResultError(fmt.Sprintf("invalid profile %q: expected %q, %q or %q", input.Profile, profileMinimal, profileMapping, profileFull)), nil
+		}
+		selection := profileSelection(fields)
+		if len(input.Fields) > 0 {
+			selection = pathSelection(input.Fields)
+		}
+
+		result, err := handler(ctx, request)
+		if err != nil || result == nil || result.IsError {
+			return result, err
+		}
+		if input.Profile == profileFull && len(input.Fields) == 0 {
+			return result, nil
+		}
+		projectResult(result, selection)
+		return result, nil
+	}
+	return tool
+}
+
+// projectionSpec adds the projection parameters to the tool input schema
+func projectionSpec(tool mcp.Tool) mcp.Tool {
+	if tool.RawInputSchema == nil {
+		if tool.InputSchema.Properties == nil {
+			tool.InputSchema.Properties = map[string]any{}
+		}
+		for name, property := range projectionSchema {
+			tool.InputSchema.Properties[name] = property
+		}
+		return tool
+	}
+
+	var schema map[string]any
+	if err := json.Unmarshal(tool.RawInputSchema, &schema); err != nil {
+		slog.Warn("cannot add projection parameters to tool schema", "tool", tool.Name, "error", err)
+		return tool
+	}
+	properties, _ := schema["properties"].(map[string]any)
+	if properties == nil {
+		properties = map[string]any{}
+		schema["properties"] = properties
+	}
+	for name, property := range projectionSchema {
+		properties[name] = property
+	}
+	raw, err := json.Marshal(schema)
+	if err != nil {
+		slog.Warn("cannot add projection parameters to tool schema", "tool", tool.Name, "error", err)
+		return tool
+	}
+	tool.RawInputSchema = raw
+	return tool
+}
+
+// projectResult rewrites the JSON text content of a tool result; non-JSON content is left untouched
+func projectResult(result *mcp.CallToolResult, selection *fieldSelection) {
+	for i, content := range result.Content {
+		text, ok := content.(mcp.TextContent)
+		if !ok {
+			continue
+		}
+		decoder := json.NewDecoder(bytes.NewReader([]byte(text.Text)))
+		decoder.UseNumber() // keep integers (e.g. counts, priorities) as written
+		var value any
+		if err := decoder.Decode(&value); err != nil {
+			continue
+		}
+		projected, err := json.MarshalIndent(projectValue(value, selection), "", "  ")
+		if err != nil {
+			slog.Warn("failed to project tool result", "error", err)
+			continue
+		}
+		text.Text = string(projected)
+		result.Content[i] = text
+	}
+}
+
+// fieldSelection is the set of fields kept on MSTR objects. Each field maps to the selection applied
+// to the objects nested in it, or to nil to keep the whole field.
+type fieldSelection struct {
+	fields map[string]*fieldSelection
+	// keepNested keeps the nested lists and objects not selected, projected the same way (profiles)
+	keepNested bool
+}
+
+// profileSelection selects the scalar fields of a profile and keeps nested lists. Nil fields select
+// every field.
+func profileSelection(fields []string) *fieldSelection {
+	if fields == nil {
+		return nil
+	}
+	selection := &fieldSelection{fields: make(map[string]*fieldSelection, len(fields)), keepNested: true}
+	for _, field := range fields {
+		selection.fields[field] = nil
+	}
+	return selection
+}
+
+// pathSelection selects the explicit fields of the fields parameter. Nested lists are dropped unless
+// listed; dotted paths (reports.guid) select the fields of their objects.
+func pathSelection(paths []string) *fieldSelection {
+	selection := &fieldSelection{fields: map[string]*fieldSelection{}}
+	for _, path := range paths {
+		current := selection
+		parts := strings.Split(path, ".")
+		for i, part := range parts {
+			child, seen := current.fields[part]
+			if seen && child == nil {
+				break // the whole field is already kept
+			}
+			if i == len(parts)-1 {
+				current.fields[part] = nil
+				break
+			}
+			if child == nil {
+				child = &fieldSelection{fields: map[string]*fieldSelection{}}
+				current.fields[part] = child
+			}
+			current = child
+		}
+	}
+	return selection
+}
+
+// projectValue removes null fields recursively and keeps only the selected fields of MSTR objects
+// (JSON objects with a guid). A nil selection keeps every field.
+func projectValue(value any, selection *fieldSelection) any {
+	switch v := value.(type) {
+	case map[string]any:
+		if _, isObject := v["guid"]; isObject && selection != nil {
+			return selectFields(v, selection)
+		}
+		projected := make(map[string]any, len(v))
+		for key, field := range v {
+			if field != nil {
+				projected[key] = projectValue(field, selection)
+			}
+		}
+		return projected
+	case []any:
+		projected := make([]any, len(v))
+		for i, item := range v {
+			projected[i] = projectValue(item, selection)
+		}
+		return projected
+	default:
+		return value
+	}
+}
+
+// selectFields keeps the selected fields of an object, without nulls
+func selectFields(object map[string]any, selection *fieldSelection) map[string]any {
+	projected := make(map[string]any, len(object))
+	for key, field := range object {
+		if field == nil {
+			continue
+		}
+		child, selected := selection.fields[key]
+		switch {
+		case selected && child != nil:
+			projected[key] = selectNested(field, child)
+		case selected:
+			projected[key] = projectValue(field, nil)
+		case selection.keepNested && isNested(field):
+			projected[key] = projectValue(field, selection)
+		}
+	}
+	return projected
+}
+
+// selectNested applies the selection of a dotted path to every object of a nested field, with or
+// without guid
+func selectNested(value any, selection *fieldSelection) any {
+	switch v := value.(type) {
+	case map[string]any:
+		return selectFields(v, selection)
+	case []any:
+		projected := make([]any, len(v))
+		for i, item := range v {
+			projected[i] = selectNested(item, selection)
+		}
+		return projected
+	default:
+		return value
+	}
+}
+
+func isNested(value any) bool {
+	switch value.(type) {
+	case map[string]any, []any:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,140 @@
+package mstr_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools/mstr"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const projectionOutput = `[
+  {
+    "moreResults": false,
+    "results": [
+      {
+        "type": "Metric",
+        "guid": "G1",
+        "name": "Retail Sales",
+        "status": "Planned",
+        "priority": 1,
+        "formula": "Sum(Sales)",
+        "raw": null,
+        "edwTable": "FACT_SALES",
+        "reportCount": 12,
+        "dependencies": [{"guid": "G2", "name": "Sales", "formula": null, "status": "Complete"}]
+      }
+    ],
+    "facets": null
+  }
+]`
+
+func projectedTool() server.ServerTool {
+	return mstr.WithFieldProjection(server.ServerTool{
+		Tool: mcp.NewTool("test-tool", mcp.WithInputSchema[mstr.SearchMetricsInput]()),
+		Handler: func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText(projectionOutput), nil
+		},
+	})
+}
+
+func projectedRow(t *testing.T, args map[string]any) map[string]any {
+	t.Helper()
+	result := callTool(t, projectedTool().Handler, args)
+	require.False(t, result.IsError, resultText(t, result))
+
+	var records []map[string]any
+	require.NoError(t, json.Unmarshal([]byte(resultText(t, result)), &records))
+	require.Len(t, records, 1)
+	return records[0]["results"].([]any)[0].(map[string]any)
+}
+
+func TestWithFieldProjection(t *testing.T) {
+	t.Run("adds fields and profile to the input schema", func(t *testing.T) {
+		var schema struct {
+			Properties map[string]any `json:"properties"`
+		}
+		require.NoError(t, json.Unmarshal(projectedTool().Tool.RawInputSchema, &schema))
+		assert.Contains(t, schema.Properties, "fields")
+		assert.Contains(t, schema.Properties, "profile")
+		assert.Contains(t, schema.Properties, "query")
+	})
+
+	t.Run("removes null fields by default", func(t *testing.T) {
+		result := callTool(t, projectedTool().Handler, map[string]any{})
+		var records []map[string]any
+		require.NoError(t, json.Unmarshal([]byte(resultText(t, result)), &records))
+		assert.NotContains(t, records[0], "facets")
+
+		row := records[0]["results"].([]any)[0].(map[string]any)
+		assert.NotContains(t, row, "raw")
+		assert.Equal(t, "Sum(Sales)", row["formula"])
+		assert.NotContains(t, row["dependencies"].([]any)[0], "formula")
+	})
+
+	t.Run("minimal profile keeps identity fields and nested lists", func(t *testing.T) {
+		row := projectedRow(t, map[string]any{"profile": "minimal"})
+		assert.ElementsMatch(t, []string{"type", "guid", "name", "status", "priority", "dependencies"}, keys(row))
+		assert.Equal(t, float64(1), row["priority"])
+		assert.ElementsMatch(t, []string{"guid", "name", "status"}, keys(row["dependencies"].([]any)[0].(map[string]any)))
+	})
+
+	t.Run("mapping profile adds mapping fields", func(t *testing.T) {
+		row := projectedRow(t, map[string]any{"profile": "mapping"})
+		assert.Equal(t, "FACT_SALES", row["edwTable"])
+		assert.NotContains(t, row, "formula")
+		assert.NotContains(t, row, "reportCount")
+	})
+
+	t.Run("fields override the profile", func(t *testing.T) {
+		row := projectedRow(t, map[string]any{"profile": "mapping", "fields": []any{"guid", "reportCount"}})
+		assert.ElementsMatch(t, []string{"guid", "reportCount"}, keys(row))
+	})
+
+	t.Run("fields keep listed nested lists whole", func(t *testing.T) {
+		row := projectedRow(t, map[string]any{"fields": []any{"guid", "dependencies"}})
+		assert.ElementsMatch(t, []string{"guid", "dependencies"}, keys(row))
+		assert.ElementsMatch(t, []string{"guid", "name", "status"}, keys(row["dependencies"].([]any)[0].(map[string]any)))
+	})
+
+	t.Run("dotted fields select nested fields", func(t *testing.T) {
+		row := projectedRow(t, map[string]any{"fields": []any{"name", "dependencies.guid", "dependencies.formula"}})
+		assert.ElementsMatch(t, []string{"name", "dependencies"}, keys(row))
+		assert.Equal(t, []any{map[string]any{"guid": "G2"}}, row["dependencies"])
+	})
+
+	t.Run("full profile returns the output unchanged", func(t *testing.T) {
+		result := callTool(t, projectedTool().Handler, map[string]any{"profile": "full"})
+		assert.Equal(t, projectionOutput, resultText(t, result))
+	})
+
+	t.Run("rejects unknown profiles", func(t *testing.T) {
+		result := callTool(t, projectedTool().Handler, map[string]any{"profile": "tiny"})
+		assert.True(t, result.IsError)
+		assert.Contains(t, resultText(t, result), "invalid profile")
+	})
+
+	t.Run("leaves errors untouched", func(t *testing.T) {
+		tool := mstr.WithFieldProjection(server.ServerTool{
+			Tool: mcp.NewTool("failing-tool"),
+			Handler: func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return mcp.NewToolResultError("Query execution failed: boom"), nil
+			},
+		})
+		result := callTool(t, tool.Handler, map[string]any{"profile": "minimal"})
+		assert.True(t, result.IsError)
+		assert.Equal(t, "Query execution failed: boom", resultText(t, result))
+	})
+}
+
+func keys(m map[string]any) []string {
+	result := make([]string, 0, len(m))
+	for k := range m {
+		result = append(result, k)
+	}
+	return result
+}
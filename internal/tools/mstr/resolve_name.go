@@ -0,0 +1,205 @@
+package mstr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/neo4j/neo4j-go-driver/v6/neo4j"
+)
+
+// maxNameCandidates is the number of candidates offered when a name matches several objects
+const maxNameCandidates = 10
+
+// ObjectCandidate is an object matching the name given to a tool
+type ObjectCandidate struct {
+	Type     string `json:"type,omitempty"`
+	GUID     string `json:"guid,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Location string `json:"location,omitempty"`
+	Status   string `json:"status,omitempty"`
+}
+
+// AmbiguousName is returned instead of the tool result when a name matches several objects and the
+// client cannot ask the user to pick one: call the tool again with the guid of a candidate
+type AmbiguousName struct {
+	Name           string            `json:"name,omitempty"`
+	Type           string            `json:"type,omitempty"`
+	Candidates     []ObjectCandidate `json:"candidates,omitempty"`
+	MoreCandidates bool              `json:"moreCandidates,omitempty"`
+	Message        string            `json:"message,omitempty"`
+}
+
+// resolveNameQuery finds the objects of $types whose name contains $name, exact (case-insensitive) matches first
+const resolveNameQuery = `
+// Resolve an object name to candidate GUIDs
+// $name: name (or part of the name) of the object
+// $types: object types of the candidates
+// $scope: optional location prefixes (project/folder scope), null for all projects
+// $limit: maximum number of candidates
+
+MATCH (n:MSTRObject)
+WHERE n.type IN $types
+  AND toLower(n.name) CONTAINS toLower($name)
+  AND ($scope IS NULL OR any(p IN $scope WHERE replace(toLower(n.location), '\\', '/') + '/' STARTS WITH p))
+WITH n, toLower(n.name) = toLower($name) as exact
+ORDER BY exact DESC, n.name ASC, n.location ASC, n.guid ASC
+LIMIT $limit
+RETURN
+  n.guid as guid,
+  n.name as name,
+  n.location as location,
+  COALESCE(n.updated_parity_status, n.parity_status, 'No Status') as status,
+  exact
+`
+
+// resolveNameTypes are the object types matched when resolving a name of the given type:
+// a Report name also resolves to Grid Reports and Documents
+var resolveNameTypes = map[string][]string{
+	"Metric":    {"Metric"},
+	"Attribute": {"Attribute"},
+	"Report":    {"Report", "GridReport", "Document"},
+}
+
+// resolveName returns the GUID of the object of the given type named name. Exact (case-insensitive)
+// matches are preferred over partial ones. When several objects match, the user is asked to pick one
+// (elicitation) if the client supports it; otherwise, or when the user declines, the candidates are
+// returned as an AmbiguousName for the agent to retry with a guid.
+func resolveName(ctx context.Context, deps *tools.ToolDependencies, objectType, name, scope string) (string, *AmbiguousName, error) {
+	slog.InfoContext(ctx, "resolving object name", "type", objectType, "name", name)
+
+	reportProgress(ctx, stageResolving)
+	records, err := deps.DBService.ExecuteReadQuery(ctx, resolveNameQuery, map[string]any{
+		"name":  name,
+		"types": resolveNameTypes[objectType],
+		"scope": scopeParam(deps, scope),
+		"limit": maxNameCandidates + 1,
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("name resolution failed: %w", err)
+	}
+
+	candidates := make([]ObjectCandidate, 0, len(records))
+	exactOnly := false
+	for _, record := range records {
+		guid, _, err := neo4j.GetRecordValue[string](record, "guid")
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid 'guid' column in record: %w", err)
+		}
+		exact, _, _ := neo4j.GetRecordValue[bool](record, "exact")
+		if exactOnly && !exact {
+			break
+		}
+		exactOnly = exact
+		objectName, _, _ := neo4j.GetRecordValue[string](record, "name")
+		location, _, _ := neo4j.GetRecordValue[string](record, "location")
+		status, _, _ := neo4j.GetRecordValue[string](record, "status")
+		candidates = append(candidates, ObjectCandidate{Type: objectType, GUID: guid, Name: objectName, Location: location, Status: status})
+	}
+
+	return chooseCandidate(ctx, objectType, name, candidates)
+}
+
+// chooseCandidate returns the GUID of the single candidate, the one picked by the user, or the
+// AmbiguousName listing the first maxNameCandidates candidates
+func chooseCandidate(ctx context.Context, objectType, name string, candidates []ObjectCandidate) (string, *AmbiguousName, error) {
+	switch len(candidates) {
+	case 0:
+		return "", nil, fmt.Errorf("%s named '%s' not found", objectType, name)
+	case 1:
+		return candidates[0].GUID, nil, nil
+	}
+
+	more := len(candidates) > maxNameCandidates
+	if more {
+		candidates = candidates[:maxNameCandidates]
+	}
+
+	if guid, ok := elicitCandidate(ctx, objectType, name, candidates); ok {
+		return guid, nil, nil
+	}
+
+	return "", &AmbiguousName{
+		Name:           name,
+		Type:           objectType,
+		Candidates:     candidates,
+		MoreCandidates: more,
+		Message:        fmt.Sprintf("Several objects of type %s match '%s': call the tool again with the guid of the intended one", objectType, name),
+	}, nil
+}
+
+// elicitCandidate asks the user to pick one of the candidates when the session and the client support
+// elicitation. Returns false when the client cannot be asked, or the user declined or cancelled.
+func elicitCandidate(ctx context.Context, objectType, name string, candidates []ObjectCandidate) (string, bool) {
+	mcpServer := server.ServerFromContext(ctx)
+	session := server.ClientSessionFromContext(ctx)
+	if mcpServer == nil || session == nil {
+		return "", false
+	}
+	if _, ok := session.(server.SessionWithElicitation); !ok {
+		return "", false
+	}
+	if client, ok := session.(server.SessionWithClientInfo); !ok || client.GetClientCapabilities().Elicitation == nil {
+		return "", false
+	}
+
+	guids := make([]string, len(candidates))
+	labels := make([]string, len(candidates))
+	for i, candidate := range candidates {
+		guids[i] = candidate.GUID
+		labels[i] = candidate.Name
+		if candidate.Location != "" {
+			labels[i] += " (" + candidate.Location + ")"
+		}
+	}
+
+	result, err := mcpServer.RequestElicitation(ctx, mcp.ElicitationRequest{
+		Params: mcp.ElicitationParams{
+			Message: fmt.Sprintf("Several objects of type %s match '%s'. Which one did you mean?", objectType, name),
+			RequestedSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"guid": map[string]any{
+						"type":      "string",
+						"title":     objectType,
+						"enum":      guids,
+						"enumNames": labels,
+					},
+				},
+				"required": []string{"guid"},
+			},
+		},
+	})
+	if err != nil {
+		slog.WarnContext(ctx, "elicitation failed, returning the candidates", "error", err)
+		return "", false
+	}
+	if result.Action != mcp.ElicitationResponseActionAccept {
+		slog.InfoContext(ctx, "user did not pick a candidate", "action", result.Action)
+		return "", false
+	}
+
+	content, _ := result.Content.(map[string]any)
+	guid, _ := content["guid"].(string)
+	for _, candidate := range candidates {
+		if candidate.GUID == guid {
+			return guid, true
+		}
+	}
+	slog.WarnContext(ctx, "elicitation returned an unknown candidate", "guid", guid)
+	return "", false
+}
+
+// ambiguousNameResult returns the candidates of an ambiguous name as the tool result
+func ambiguousNameResult(ctx context.Context, ambiguous *AmbiguousName) *mcp.CallToolResult {
+	jsonData, err := json.MarshalIndent(map[string]any{"ambiguous": ambiguous}, "", "  ")
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to format ambiguous name candidates to JSON", "error", err)
+		return mcp.NewToolResultError(err.Error())
+	}
+	return mcp.NewToolResultText(string(jsonData))
+}
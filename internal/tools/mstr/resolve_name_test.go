@@ -0,0 +1,285 @@
+package mstr_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	db "github.com/brunogc-cit/flow-microstrategy-mcp/internal/database/mocks"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools/mstr"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/neo4j/neo4j-go-driver/v6/neo4j"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func candidateRecord(guid, name, location string, exact bool) *neo4j.Record {
+	return &neo4j.Record{
+		Keys:   []string{"guid", "name", "location", "status", "exact"},
+		Values: []any{guid, name, location, "Complete", exact},
+	}
+}
+
+// elicitationSession is an initialized client session declaring the elicitation capability,
+// answering elicitation requests with response
+type elicitationSession struct {
+	notifications chan mcp.JSONRPCNotification
+	response      mcp.ElicitationResponse
+	requests      []mcp.ElicitationRequest
+}
+
+func (s *elicitationSession) Initialize()       {}
+func (s *elicitationSession) Initialized() bool { return true }
+func (s *elicitationSession) SessionID() string { return "elicitation-test" }
+func (s *elicitationSession) NotificationChannel() chan<- mcp.JSONRPCNotification {
+	return s.notifications
+}
+func (s *elicitationSession) GetClientInfo() mcp.Implementation { return mcp.Implementation{} }
+func (s *elicitationSession) SetClientInfo(mcp.Implementation)  {}
+func (s *elicitationSession) GetClientCapabilities() mcp.ClientCapabilities {
+	return mcp.ClientCapabilities{Elicitation: &mcp.ElicitationCapability{}}
+}
+func (s *elicitationSession) SetClientCapabilities(mcp.ClientCapabilities) {}
+
+func (s *elicitationSession) RequestElicitation(_ context.Context, request mcp.ElicitationRequest) (*mcp.ElicitationResult, error) {
+	s.requests = append(s.requests, request)
+	return &mcp.ElicitationResult{ElicitationResponse: s.response}, nil
+}
+
+// callWithSession calls a tool through an MCP server from the given client session
+func callWithSession(t *testing.T, tool server.ServerTool, args map[string]any, session server.ClientSession) *mcp.CallToolResult {
+	t.Helper()
+	s := server.NewMCPServer("test", "test", server.WithToolCapabilities(false), server.WithElicitation())
+	s.AddTools(tool)
+
+	message, err := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": 1, "method": "tools/call",
+		"params": map[string]any{"name": tool.Tool.Name, "arguments": args}})
+	require.NoError(t, err)
+
+	response, ok := s.HandleMessage(s.WithContext(context.Background(), session), message).(mcp.JSONRPCResponse)
+	require.True(t, ok, "expected a successful response")
+	result, ok := response.Result.(*mcp.CallToolResult)
+	require.True(t, ok)
+	return result
+}
+
+// expectTrace expects the trace-metric query and returns a pointer to the traced GUID
+func expectTrace(mockDB *db.MockService) *string {
+	traced := new(string)
+	mockDB.EXPECT().
+		ExecuteReadQuery(gomock.Any(), gomock.Not(gomock.Regex("Resolve an object name")), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, params map[string]any) ([]*neo4j.Record, error) {
+			*traced = params["guid"].(string)
+			return []*neo4j.Record{{Keys: []string{"result"}, Values: []any{map[string]any{"reports": []any{}}}}}, nil
+		})
+	mockDB.EXPECT().Neo4jRecordsToJSON(gomock.Any()).Return(`[{"result":{}}]`, nil)
+	return traced
+}
+
+func TestResolveName(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectCandidates := func(mockDB *db.MockService, records ...*neo4j.Record) {
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Regex("Resolve an object name"), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, params map[string]any) ([]*neo4j.Record, error) {
+				assert.Equal(t, "sales", params["name"])
+				assert.Equal(t, []string{"Metric"}, params["types"])
+				assert.Equal(t, 11, params["limit"])
+				return records, nil
+			})
+	}
+
+	t.Run("traces the single match", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		expectCandidates(mockDB, candidateRecord("M1", "Sales", "Retail/Metrics", false))
+		traced := expectTrace(mockDB)
+
+		result := callTool(t, mstr.TraceMetricHandler(&tools.ToolDependencies{DBService: mockDB}), map[string]any{"name": "sales", "direction": "upstream"})
+		require.False(t, result.IsError, resultText(t, result))
+		assert.Equal(t, "M1", *traced)
+	})
+
+	t.Run("prefers the exact match", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		expectCandidates(mockDB,
+			candidateRecord("M2", "Sales", "Retail/Metrics", true),
+			candidateRecord("M1", "Net Sales", "Retail/Metrics", false),
+			candidateRecord("M3", "Sales Amount", "Retail/Metrics", false))
+		traced := expectTrace(mockDB)
+
+		result := callTool(t, mstr.TraceMetricHandler(&tools.ToolDependencies{DBService: mockDB}), map[string]any{"name": "sales", "direction": "downstream"})
+		require.False(t, result.IsError, resultText(t, result))
+		assert.Equal(t, "M2", *traced)
+	})
+
+	t.Run("returns the candidates without elicitation", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		expectCandidates(mockDB,
+			candidateRecord("M1", "Net Sales", "Retail/Metrics", false),
+			candidateRecord("M2", "Sales Amount", "Retail/Metrics", false))
+
+		result := callTool(t, mstr.TraceMetricHandler(&tools.ToolDependencies{DBService: mockDB}), map[string]any{"name": "sales", "direction": "upstream"})
+		require.False(t, result.IsError, resultText(t, result))
+
+		var response mstr.TraceMetricOutput
+		require.NoError(t, json.Unmarshal([]byte(resultText(t, result)), &response))
+		require.NotNil(t, response.Ambiguous)
+		assert.Equal(t, "Metric", response.Ambiguous.Type)
+		assert.Equal(t, []mstr.ObjectCandidate{
+			{Type: "Metric", GUID: "M1", Name: "Net Sales", Location: "Retail/Metrics", Status: "Complete"},
+			{Type: "Metric", GUID: "M2", Name: "Sales Amount", Location: "Retail/Metrics", Status: "Complete"},
+		}, response.Ambiguous.Candidates)
+		assert.False(t, response.Ambiguous.MoreCandidates)
+	})
+
+	t.Run("every tool resolving names returns the candidates of an ambiguous name", func(t *testing.T) {
+		resolvers := []struct {
+			name    string
+			handler func(*tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error)
+			args    map[string]any
+			types   []string
+		}{
+			{name: "trace-metric", handler: mstr.TraceMetricHandler, args: map[string]any{"name": "sales", "direction": "upstream"}, types: []string{"Metric"}},
+			{name: "trace-attribute", handler: mstr.TraceAttributeHandler, args: map[string]any{"name": "sales", "direction": "downstream"}, types: []string{"Attribute"}},
+			{name: "trace-columns", handler: mstr.TraceColumnsHandler, args: map[string]any{"name": "sales"}, types: []string{"Metric"}},
+			{name: "find-similar-reports", handler: mstr.FindSimilarReportsHandler, args: map[string]any{"name": "sales"}, types: []string{"Report", "GridReport", "Document"}},
+		}
+
+		for _, tt := range resolvers {
+			t.Run(tt.name, func(t *testing.T) {
+				mockDB := db.NewMockService(ctrl)
+				mockDB.EXPECT().
+					ExecuteReadQuery(gomock.Any(), gomock.Regex("Resolve an object name"), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, params map[string]any) ([]*neo4j.Record, error) {
+						assert.Equal(t, tt.types, params["types"])
+						return []*neo4j.Record{
+							candidateRecord("G1", "Net Sales", "Retail/Objects", false),
+							candidateRecord("G2", "Sales Amount", "Retail/Objects", false),
+						}, nil
+					})
+
+				result := callTool(t, tt.handler(&tools.ToolDependencies{DBService: mockDB}), tt.args)
+				require.False(t, result.IsError, resultText(t, result))
+
+				var response struct {
+					Ambiguous *mstr.AmbiguousName `json:"ambiguous"`
+				}
+				require.NoError(t, json.Unmarshal([]byte(resultText(t, result)), &response))
+				require.NotNil(t, response.Ambiguous)
+				assert.Equal(t, tt.types[0], response.Ambiguous.Type)
+				require.Len(t, response.Ambiguous.Candidates, 2)
+				assert.Equal(t, "G1", response.Ambiguous.Candidates[0].GUID)
+				assert.Equal(t, "G2", response.Ambiguous.Candidates[1].GUID)
+			})
+		}
+	})
+
+	t.Run("reports a name without match", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		expectCandidates(mockDB)
+
+		result := callTool(t, mstr.TraceColumnsHandler(&tools.ToolDependencies{DBService: mockDB}), map[string]any{"name": "sales"})
+		assert.True(t, result.IsError)
+		assert.Contains(t, resultText(t, result), "Metric named 'sales' not found")
+	})
+
+	t.Run("rejects guid and name together", func(t *testing.T) {
+		deps := &tools.ToolDependencies{DBService: db.NewMockService(ctrl)}
+		args := map[string]any{"guid": "M1", "name": "sales", "direction": "upstream"}
+
+		for _, handler := range []func(*tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error){
+			mstr.TraceMetricHandler, mstr.TraceAttributeHandler, mstr.TraceColumnsHandler, mstr.FindSimilarReportsHandler,
+		} {
+			result := callTool(t, handler(deps), args)
+			assert.True(t, result.IsError)
+			assert.Contains(t, resultText(t, result), "one of guid")
+		}
+	})
+
+	t.Run("asks the user to pick a candidate", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		expectCandidates(mockDB,
+			candidateRecord("M1", "Net Sales", "Retail/Metrics", false),
+			candidateRecord("M2", "Sales Amount", "Retail/Metrics", false))
+		traced := expectTrace(mockDB)
+
+		session := &elicitationSession{
+			notifications: make(chan mcp.JSONRPCNotification, 10),
+			response:      mcp.ElicitationResponse{Action: mcp.ElicitationResponseActionAccept, Content: map[string]any{"guid": "M2"}},
+		}
+		tool := server.ServerTool{Tool: mstr.TraceMetricSpec(), Handler: mstr.TraceMetricHandler(&tools.ToolDependencies{DBService: mockDB})}
+		result := callWithSession(t, tool, map[string]any{"name": "sales", "direction": "upstream"}, session)
+		require.False(t, result.IsError, resultText(t, result))
+
+		assert.Equal(t, "M2", *traced)
+		require.Len(t, session.requests, 1)
+		schema := session.requests[0].Params.RequestedSchema.(map[string]any)
+		guid := schema["properties"].(map[string]any)["guid"].(map[string]any)
+		assert.Equal(t, []string{"M1", "M2"}, guid["enum"])
+		assert.Equal(t, []string{"Net Sales (Retail/Metrics)", "Sales Amount (Retail/Metrics)"}, guid["enumNames"])
+	})
+
+	t.Run("returns the candidates when the user declines", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		expectCandidates(mockDB,
+			candidateRecord("M1", "Net Sales", "Retail/Metrics", false),
+			candidateRecord("M2", "Sales Amount", "Retail/Metrics", false))
+
+		session := &elicitationSession{
+			notifications: make(chan mcp.JSONRPCNotification, 10),
+			response:      mcp.ElicitationResponse{Action: mcp.ElicitationResponseActionDecline},
+		}
+		tool := server.ServerTool{Tool: mstr.TraceMetricSpec(), Handler: mstr.TraceMetricHandler(&tools.ToolDependencies{DBService: mockDB})}
+		result := callWithSession(t, mstr.WithStructuredOutput(tool), map[string]any{"name": "sales", "direction": "upstream"}, session)
+		require.False(t, result.IsError, resultText(t, result))
+
+		require.Len(t, session.requests, 1)
+		structured, ok := result.StructuredContent.(map[string]any)
+		require.True(t, ok)
+		assert.Contains(t, structured, "ambiguous")
+	})
+
+	t.Run("resolves report names among all reports", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Regex("Resolve an object name"), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, params map[string]any) ([]*neo4j.Record, error) {
+				assert.Equal(t, "sales daily", params["name"])
+				assert.Equal(t, []string{"Report", "GridReport", "Document"}, params["types"])
+				return []*neo4j.Record{candidateRecord("R1", "Sales Daily", "Retail/Reports", true)}, nil
+			})
+		expectSimilarTo(mockDB, "R1", reportRecord("R1", "Sales Daily", "M1", "M2", "A1", "A2"))
+		handler := mstr.FindSimilarReportsHandler(&tools.ToolDependencies{DBService: mockDB})
+
+		result := callTool(t, handler, map[string]any{"name": "sales daily"})
+		require.False(t, result.IsError, resultText(t, result))
+		var response mstr.FindSimilarReportsOutput
+		require.NoError(t, json.Unmarshal([]byte(resultText(t, result)), &response))
+		require.NotNil(t, response.Report)
+		assert.Equal(t, "R1", response.Report.GUID)
+	})
+
+	t.Run("lists the candidate reports of an ambiguous name", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Regex("Resolve an object name"), gomock.Any()).
+			Return([]*neo4j.Record{
+				candidateRecord("R1", "Sales Daily", "Retail/Reports", false),
+				candidateRecord("R2", "Sales Daily Copy", "Retail/Reports", false),
+				candidateRecord("R3", "Sales Daily v2", "Retail/Reports", false),
+			}, nil)
+		handler := mstr.FindSimilarReportsHandler(&tools.ToolDependencies{DBService: mockDB})
+
+		result := callTool(t, handler, map[string]any{"name": "daily"})
+		require.False(t, result.IsError, resultText(t, result))
+		var response mstr.FindSimilarReportsOutput
+		require.NoError(t, json.Unmarshal([]byte(resultText(t, result)), &response))
+		require.NotNil(t, response.Ambiguous)
+		assert.Len(t, response.Ambiguous.Candidates, 3)
+	})
+}
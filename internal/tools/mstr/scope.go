@@ -0,0 +1,38 @@
+package mstr
+
+import (
+	"strings"
+
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools"
+)
+
+// scopeAll is the scope value that lifts the server default scope for one call
+const scopeAll = "*"
+
+// scopeParam returns the $scope query parameter for a tool call: nil for all projects, otherwise the
+// lower-case, '/'-separated location prefixes of the requested (or default) scope. Queries match with
+//
+//	($scope IS NULL OR any(p IN $scope WHERE replace(toLower(n.location), '\\', '/') + '/' STARTS WITH p))
+//
+// which accepts both '\' and '/' separators, with or without a leading separator, and only matches
+// whole folder names ("Retail" does not match "RetailUK").
+func scopeParam(deps *tools.ToolDependencies, scope string) any {
+	path := normalizeScope(deps, scope)
+	if path == "" {
+		return nil
+	}
+	return []string{"/" + path + "/", path + "/"}
+}
+
+// normalizeScope returns the lower-case, '/'-separated folder path of the requested (or default)
+// scope, without leading or trailing separators; empty for all projects
+func normalizeScope(deps *tools.ToolDependencies, scope string) string {
+	if scope == "" {
+		scope = deps.DefaultScope
+	}
+	path := strings.Trim(strings.ReplaceAll(strings.ToLower(strings.TrimSpace(scope)), "\\", "/"), "/")
+	if path == scopeAll {
+		return ""
+	}
+	return path
+}
@@ -0,0 +1,118 @@
+package mstr_test
+
+import (
+	"context"
+	"testing"
+
+	db "github.com/brunogc-cit/flow-microstrategy-mcp/internal/database/mocks"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools/mstr"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/neo4j/neo4j-go-driver/v6/neo4j"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// scopedParams calls a tool with the given server default scope and returns the params of its query
+func scopedParams(t *testing.T, defaultScope string, handler func(*tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error), args map[string]any) map[string]any {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var params map[string]any
+	mockDB := db.NewMockService(ctrl)
+	mockDB.EXPECT().
+		ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, query string, p map[string]any) ([]*neo4j.Record, error) {
+			assert.Contains(t, query, "$scope IS NULL OR")
+			params = p
+			return []*neo4j.Record{{Keys: []string{"results"}, Values: []any{[]any{}}}}, nil
+		})
+	mockDB.EXPECT().Neo4jRecordsToJSON(gomock.Any()).Return("[]", nil)
+
+	result := callTool(t, handler(&tools.ToolDependencies{DBService: mockDB, DefaultScope: defaultScope}), args)
+	require.False(t, result.IsError, resultText(t, result))
+	return params
+}
+
+func TestScope(t *testing.T) {
+	t.Run("no scope searches all projects", func(t *testing.T) {
+		params := scopedParams(t, "", mstr.SearchFiltersHandler, map[string]any{"query": "region"})
+		assert.Nil(t, params["scope"])
+	})
+
+	t.Run("normalizes separators and case", func(t *testing.T) {
+		params := scopedParams(t, "", mstr.SearchPromptsHandler, map[string]any{"query": "region", "scope": `\Retail\Public Objects\`})
+		assert.Equal(t, []string{"/retail/public objects/", "retail/public objects/"}, params["scope"])
+	})
+
+	t.Run("applies the server default scope", func(t *testing.T) {
+		params := scopedParams(t, "Retail", mstr.SearchMetricsHandler, map[string]any{"query": "sales"})
+		assert.Equal(t, []string{"/retail/", "retail/"}, params["scope"])
+	})
+
+	t.Run("explicit scope overrides the default", func(t *testing.T) {
+		params := scopedParams(t, "Retail", mstr.ListTransformationsHandler, map[string]any{"scope": "Wholesale"})
+		assert.Equal(t, []string{"/wholesale/", "wholesale/"}, params["scope"])
+	})
+
+	t.Run("star lifts the default scope", func(t *testing.T) {
+		params := scopedParams(t, "Retail", mstr.SearchFiltersHandler, map[string]any{"query": "region", "scope": "*"})
+		assert.Nil(t, params["scope"])
+	})
+}
+
+func TestDownstreamTraceScope(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		handler func(*tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error)
+	}{
+		{name: "trace-metric", handler: mstr.TraceMetricHandler},
+		{name: "trace-attribute", handler: mstr.TraceAttributeHandler},
+	} {
+		t.Run(tt.name+" keeps the tables without location in the default scope", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDB := db.NewMockService(ctrl)
+			mockDB.EXPECT().
+				ExecuteReadQuery(gomock.Any(), gomock.Regex(`\$scope IS NULL OR t.location IS NULL OR any\(p IN \$scope WHERE replace\(toLower\(t.location\)`), gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ string, params map[string]any) ([]*neo4j.Record, error) {
+					assert.Equal(t, []string{"/retail/", "retail/"}, params["scope"])
+					return []*neo4j.Record{{Keys: []string{"result"}, Values: []any{map[string]any{"tables": []any{}}}}}, nil
+				})
+			mockDB.EXPECT().Neo4jRecordsToJSON(gomock.Any()).Return("[]", nil)
+
+			result := callTool(t, tt.handler(&tools.ToolDependencies{DBService: mockDB, DefaultScope: "Retail"}), map[string]any{"guid": "ABC", "direction": "downstream"})
+			require.False(t, result.IsError, resultText(t, result))
+		})
+	}
+}
+
+func TestListProjectsHandler(t *testing.T) {
+	t.Run("groups by project without scope", func(t *testing.T) {
+		params := scopedParams(t, "", mstr.ListProjectsHandler, map[string]any{})
+		assert.Equal(t, map[string]any{"scope": nil, "level": 1, "offset": 0}, params)
+	})
+
+	t.Run("depth is relative to the scope", func(t *testing.T) {
+		params := scopedParams(t, "", mstr.ListProjectsHandler, map[string]any{"scope": "Retail/Public Objects", "depth": 2})
+		assert.Equal(t, 4, params["level"])
+	})
+
+	t.Run("uses the server default scope", func(t *testing.T) {
+		params := scopedParams(t, "Retail", mstr.ListProjectsHandler, map[string]any{})
+		assert.Equal(t, 2, params["level"])
+		assert.Equal(t, []string{"/retail/", "retail/"}, params["scope"])
+	})
+
+	t.Run("rejects out of range depth", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		result := callTool(t, mstr.ListProjectsHandler(&tools.ToolDependencies{DBService: db.NewMockService(ctrl)}), map[string]any{"depth": 9})
+		assert.True(t, result.IsError)
+		assert.Contains(t, resultText(t, result), "Invalid depth 9")
+	})
+}
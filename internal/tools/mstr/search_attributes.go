@@ -2,6 +2,7 @@ package mstr
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 
@@ -13,73 +14,30 @@ import (
 type SearchAttributesInput struct {
 	Query  string   `json:"query" jsonschema:"required,description=GUID (full or partial 8+ chars) or name search term"`
 	Status []string `json:"status,omitempty" jsonschema:"description=Filter by parity status: Complete, Planned, Not Planned"`
+	Facets bool     `json:"facets,omitempty" jsonschema:"default=false,description=Also return counts per status, priority and semantic model for the full match set"`
+	Mode   string   `json:"mode,omitempty" jsonschema:"enum=exact,enum=fuzzy,default=exact,description=Name matching: 'exact' (case-insensitive contains) or 'fuzzy' (typo-tolerant terms in any order; comma-separated alternatives)"`
+	SortBy string   `json:"sortBy,omitempty" jsonschema:"enum=name,enum=reportCount,enum=tableCount,enum=priority,enum=status,description=Sort order: name (A-Z), reportCount or tableCount (most first), priority (highest first) or status (No Status, Not Planned, Planned, Complete). Default: relevance with the search index, otherwise name"`
+	Scope  string   `json:"scope,omitempty" jsonschema:"description=Project or folder to search in (e.g. Retail or Retail/Public Objects/Metrics; see list-projects). Defaults to the server scope; * for all projects"`
 	Offset int      `json:"offset,omitempty" jsonschema:"default=0,description=Skip first N results for pagination"`
+	Cursor string   `json:"cursor,omitempty" jsonschema:"description=nextCursor from the previous page (preferred over offset: stable and cheap for deep pages)"`
 }
 
-const searchAttributesQuery = `
-// Search for Attributes by GUID or name
-// $query: GUID (full/partial) or name search term
-// $status: optional parity status filter (applies to effective status)
-// $offset: pagination offset (0, 100, 200, ...)
-//
-// Design Decision: Returns ALL Attributes matching the query, including those without
-// parity mapping. Objects not in the parity matrix get status "No Status".
-// The updated_parity_status property (from ADO backlog sync) takes precedence
-// over the computed parity_status.
-
-// Determine if query looks like a GUID (hex chars, 8+ length)
-WITH $query as query,
-     $query =~ '^[A-Fa-f0-9]{8,}$' as isGuidLike
-
-MATCH (n:Attribute)
-WHERE n.guid IS NOT NULL
-  AND (
-    // GUID match: exact or partial (starts with)
-    (isGuidLike AND (n.guid = query OR n.guid STARTS WITH toUpper(query)))
-    OR
-    // Name match: case-insensitive contains
-    (NOT isGuidLike AND toLower(n.name) CONTAINS toLower(query))
-  )
-  AND ($status IS NULL OR COALESCE(n.updated_parity_status, n.parity_status) IN $status)
-
-// Compute effective values (updated_ properties take precedence)
-WITH n,
-     COALESCE(n.updated_parity_status, n.parity_status, 'No Status') as effectiveStatus
-
-ORDER BY n.name ASC
-SKIP $offset
-LIMIT 101  // Fetch 101 to determine if more results exist
-
-// Collect results with all properties (updated_ values take precedence)
-WITH collect({
-  type: 'Attribute',
-  guid: n.guid,
-  name: n.name,
-  status: effectiveStatus,
-  priority: n.inherited_priority_level,
-  forms_json: n.forms_json,
-  notes: COALESCE(n.updated_parity_notes, n.parity_notes),
-  raw: COALESCE(n.updated_db_raw, n.db_raw),
-  serve: COALESCE(n.updated_db_serve, n.db_serve),
-  semantic: n.pb_semantic,
-  edwTable: COALESCE(n.updated_edw_table, n.edw_table),
-  edwColumn: n.edw_column,
-  adeTable: COALESCE(n.updated_ade_db_table, n.ade_db_table),
-  adeColumn: n.ade_db_column,
-  semanticName: n.pb_semantic_name,
-  semanticModel: n.pb_semantic_model,
-  dbEssential: n.db_essential,
-  pbEssential: n.pb_essential,
-  reportCount: COALESCE(n.lineage_used_by_reports_count, 0),
-  tableCount: COALESCE(n.lineage_source_tables_count, 0),
-  ado_link: COALESCE(n.updated_ado_link, n.ado_link)
-}) as fetched
-
-// Return first 100; moreResults=true if 101st exists
-RETURN 
-  fetched[0..100] as results,
-  size(fetched) > 100 as moreResults
-`
+// AttributeSearchResult is an Attribute found by search-attributes
+type AttributeSearchResult struct {
+	AttributeDetails
+	ReportCount int     `json:"reportCount,omitempty"`
+	TableCount  int     `json:"tableCount,omitempty"`
+	Score       float64 `json:"score,omitempty"`
+}
+
+// SearchAttributesOutput defines the output of the search-attributes tool
+type SearchAttributesOutput struct {
+	Results     []AttributeSearchResult `json:"results"`
+	MoreResults bool                    `json:"moreResults"`
+	NextCursor  string                  `json:"nextCursor,omitempty"`
+	Facets      *SearchFacets           `json:"facets,omitempty"`
+	Truncated   []TruncatedList         `json:"truncated,omitempty"`
+}
 
 // SearchAttributesSpec returns the MCP tool definition for search-attributes
 func SearchAttributesSpec() mcp.Tool {
@@ -96,9 +54,18 @@ func SearchAttributesSpec() mcp.Tool {
 				"- Partial GUIDs with less than 8 characters (too ambiguous)\n"+
 				"- Lineage tracing (use trace-attribute with the GUID instead)\n"+
 				"- Searching Metrics (use search-metrics instead)\n\n"+
-				"PAGINATION: Returns 100 results. If moreResults=true, call again with offset+100.",
+				"FUZZY MODE: mode=\"fuzzy\" tolerates typos and abbreviations, matches terms in any order and accepts comma-separated alternatives, "+
+				"ranked by similarity: search-attributes(query=\"Retial Sales, Net Amt\", mode=\"fuzzy\")\n\n"+
+				"RANKING: When the full-text search index exists, name searches are ordered by relevance (score, best first); "+
+				"otherwise results are alphabetical with score=null.\n\n"+
+				"FACETS: facets=true adds counts per status, priority and semantic model over ALL matches (not just the page). "+
+				"Use them to narrow the query (e.g. add status) instead of paging blindly.\n\n"+
+				"SORTING: sortBy=reportCount|tableCount|priority|status surfaces the most impactful objects first: "+
+				"search-attributes(query=\"store\", sortBy=\"reportCount\")\n\n"+
+				"PAGINATION: Returns 100 results. If moreResults=true, call again with cursor=nextCursor (or offset+100).",
 		),
 		mcp.WithInputSchema[SearchAttributesInput](),
+		mcp.WithOutputSchema[SearchAttributesOutput](),
 		mcp.WithTitleAnnotation("Search for Attributes by GUID or name"),
 		mcp.WithReadOnlyHintAnnotation(true),
 		mcp.WithIdempotentHintAnnotation(true),
@@ -117,14 +84,14 @@ func SearchAttributesHandler(deps *tools.ToolDependencies) func(context.Context,
 func handleSearchAttributes(ctx context.Context, deps *tools.ToolDependencies, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	if deps.DBService == nil {
 		errMessage := "Database service is not initialized"
-		slog.Error(errMessage)
+		slog.ErrorContext(ctx, errMessage)
 		return mcp.NewToolResultError(errMessage), nil
 	}
 
 	// Parse input
 	var input SearchAttributesInput
 	if err := request.BindArguments(&input); err != nil {
-		slog.Error("error binding arguments", "error", err)
+		slog.ErrorContext(ctx, "error binding arguments", "error", err)
 		return mcp.NewToolResultError(fmt.Sprintf("Invalid input: %v", err)), nil
 	}
 
@@ -132,11 +99,22 @@ func handleSearchAttributes(ctx context.Context, deps *tools.ToolDependencies, r
 	if input.Query == "" {
 		return mcp.NewToolResultError("query parameter is required"), nil
 	}
+	if err := validateSearchMode(input.Mode); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if err := validateSortBy(input.SortBy, searchSortByOptions); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if input.Mode == searchModeFuzzy && input.SortBy != "" {
+		return mcp.NewToolResultError("sortBy is not supported with mode=fuzzy: fuzzy results are ranked by similarity"), nil
+	}
 
 	// Build parameters
 	params := map[string]any{
 		"query":  input.Query,
+		"sortBy": sortByParam(input.SortBy),
 		"offset": input.Offset,
+		"scope":  scopeParam(deps, input.Scope),
 	}
 
 	// Handle status filter - nil if empty, otherwise the array
@@ -146,15 +124,27 @@ func handleSearchAttributes(ctx context.Context, deps *tools.ToolDependencies, r
 		params["status"] = nil
 	}
 
-	records, err := deps.DBService.ExecuteReadQuery(ctx, searchAttributesQuery, params)
+	pages, err := newPager("search-attributes:"+input.Mode, params, input.Cursor)
 	if err != nil {
-		slog.Error("failed to execute search-attributes query", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	reportProgress(ctx, stageSearching)
+	records, err := executeSearch(ctx, deps, searchAttributesQueries, input.Mode, input.Facets, params)
+	if errors.Is(err, errTooManyFuzzyCandidates) {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to execute search-attributes query", "error", err)
 		return mcp.NewToolResultError(fmt.Sprintf("Query execution failed: %v", err)), nil
 	}
 
+	pages.attachNextCursor(ctx, records, "results")
+
+	reportProgress(ctx, stageFormatting)
 	response, err := deps.DBService.Neo4jRecordsToJSON(records)
 	if err != nil {
-		slog.Error("failed to format search-attributes results to JSON", "error", err)
+		slog.ErrorContext(ctx, "failed to format search-attributes results to JSON", "error", err)
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
@@ -0,0 +1,311 @@
+package mstr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/neo4j/neo4j-go-driver/v6/neo4j"
+)
+
+const (
+	maxDefinitionResults = 100
+	// definitionSnippetRadius is the number of characters kept on each side of the first match
+	definitionSnippetRadius = 60
+)
+
+// definitionFields are the definition properties searched by search-by-definition, in output order
+var definitionFields = []string{
+	"formula",
+	"expressions_json",
+	"forms_json",
+	"edw_table",
+	"updated_edw_table",
+	"edw_column",
+	"physical_table_name",
+}
+
+// SearchByDefinitionInput defines the input parameters for the search-by-definition tool
+type SearchByDefinitionInput struct {
+	Query  string   `json:"query" jsonschema:"required,description=Text to find in formulas, expressions, forms, EDW table/column or physical table names (case-insensitive)"`
+	Types  []string `json:"types,omitempty" jsonschema:"description=Optional object types to search, e.g. Metric, Attribute, Fact, LogicalTable"`
+	Scope  string   `json:"scope,omitempty" jsonschema:"description=Project or folder to search in (e.g. Retail or Retail/Public Objects/Metrics; see list-projects). Defaults to the server scope; * for all projects"`
+	Offset int      `json:"offset,omitempty" jsonschema:"default=0,description=Skip first N results for pagination"`
+	Cursor string   `json:"cursor,omitempty" jsonschema:"description=nextCursor from the previous page (preferred over offset)"`
+}
+
+// SearchByDefinitionOutput defines the output of the search-by-definition tool
+type SearchByDefinitionOutput struct {
+	Results     []DefinitionSearchResult `json:"results"`
+	MoreResults bool                     `json:"moreResults"`
+	NextCursor  string                   `json:"nextCursor,omitempty"`
+	Truncated   []TruncatedList          `json:"truncated,omitempty"`
+}
+
+const searchByDefinitionQuery = `
+// Search objects by definition content
+// $query: search text (case-insensitive contains)
+// $types: optional object type filter
+// $scope: optional location prefixes (project/folder scope), null for all projects
+// $offset: pagination offset (0, 100, 200, ...)
+//
+// Searched properties: formula, expressions_json, forms_json, edw_table, updated_edw_table,
+// edw_column, physical_table_name. Snippets are built from the returned properties.
+
+WITH toLower($query) as query
+
+MATCH (n:MSTRObject)
+WHERE n.guid IS NOT NULL
+  AND ($types IS NULL OR n.type IN $types)
+  AND (
+    toLower(COALESCE(n.formula, '')) CONTAINS query
+    OR toLower(COALESCE(n.expressions_json, '')) CONTAINS query
+    OR toLower(COALESCE(n.forms_json, '')) CONTAINS query
+    OR toLower(COALESCE(n.edw_table, '')) CONTAINS query
+    OR toLower(COALESCE(n.updated_edw_table, '')) CONTAINS query
+    OR toLower(COALESCE(n.edw_column, '')) CONTAINS query
+    OR toLower(COALESCE(n.physical_table_name, '')) CONTAINS query
+  )
+  AND ($scope IS NULL OR any(p IN $scope WHERE replace(toLower(n.location), '\\', '/') + '/' STARTS WITH p))
+
+WITH n
+ORDER BY n.type ASC, n.name ASC, n.guid ASC
+SKIP $offset
+LIMIT 101  // Fetch 101 to determine if more results exist
+
+RETURN
+  n.type as type,
+  n.guid as guid,
+  n.name as name,
+  n.location as location,
+  COALESCE(n.updated_parity_status, n.parity_status, 'No Status') as status,
+  {
+    formula: n.formula,
+    expressions_json: n.expressions_json,
+    forms_json: n.forms_json,
+    edw_table: n.edw_table,
+    updated_edw_table: n.updated_edw_table,
+    edw_column: n.edw_column,
+    physical_table_name: n.physical_table_name
+  } as definition
+`
+
+// DefinitionMatch is a definition property containing the search text
+type DefinitionMatch struct {
+	Field       string `json:"field"`
+	Snippet     string `json:"snippet"`
+	Occurrences int    `json:"occurrences"`
+}
+
+// DefinitionSearchResult is an object whose definition contains the search text
+type DefinitionSearchResult struct {
+	Type     string            `json:"type,omitempty"`
+	GUID     string            `json:"guid,omitempty"`
+	Name     string            `json:"name,omitempty"`
+	Location any               `json:"location,omitempty"`
+	Status   any               `json:"status,omitempty"`
+	Matches  []DefinitionMatch `json:"matches"`
+}
+
+// SearchByDefinitionSpec returns the MCP tool definition for search-by-definition
+func SearchByDefinitionSpec() mcp.Tool {
+	return mcp.NewTool("search-by-definition",
+		mcp.WithDescription(
+			"Find objects whose DEFINITION contains a text: formula, expressions_json, forms_json, EDW table/column "+
+				"or physical table name. Each result lists the matching fields with a snippet, the match wrapped in **.\n\n"+
+				"USE FOR:\n"+
+				"- Which metrics use a fact or metric in their formula: search-by-definition(query=\"NetSalesValue\", types=[\"Metric\"])\n"+
+				"- What reads a table or view: search-by-definition(query=\"vwLookupFinancialYearWeek\")\n\n"+
+				"DO NOT USE FOR:\n"+
+				"- Searching by name or GUID (use search-metrics/search-attributes instead)\n"+
+				"- Column-level lineage of a metric (use trace-columns instead)\n\n"+
+				"PAGINATION: Returns 100 results. If moreResults=true, call again with cursor=nextCursor (or offset+100).",
+		),
+		mcp.WithInputSchema[SearchByDefinitionInput](),
+		mcp.WithOutputSchema[SearchByDefinitionOutput](),
+		mcp.WithTitleAnnotation("Search objects by definition content"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(true),
+	)
+}
+
+// SearchByDefinitionHandler returns the handler function for the search-by-definition tool
+func SearchByDefinitionHandler(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleSearchByDefinition(ctx, deps, request)
+	}
+}
+
+func handleSearchByDefinition(ctx context.Context, deps *tools.ToolDependencies, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if deps.DBService == nil {
+		errMessage := "Database service is not initialized"
+		slog.ErrorContext(ctx, errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	// Parse input
+	var input SearchByDefinitionInput
+	if err := request.BindArguments(&input); err != nil {
+		slog.ErrorContext(ctx, "error binding arguments", "error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid input: %v", err)), nil
+	}
+
+	// Validate required field
+	if strings.TrimSpace(input.Query) == "" {
+		return mcp.NewToolResultError("query parameter is required"), nil
+	}
+
+	params := map[string]any{
+		"query":  input.Query,
+		"offset": input.Offset,
+		"scope":  scopeParam(deps, input.Scope),
+	}
+
+	// Handle types filter - nil if empty, otherwise the array
+	if len(input.Types) > 0 {
+		params["types"] = input.Types
+	} else {
+		params["types"] = nil
+	}
+
+	// Ordered by type then name: pages by position
+	pages, err := newOffsetPager("search-by-definition", params, input.Cursor)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	slog.InfoContext(ctx, "executing search-by-definition query", "query", input.Query, "types", input.Types, "offset", params["offset"])
+
+	reportProgress(ctx, stageSearching)
+	records, err := deps.DBService.ExecuteReadQuery(ctx, searchByDefinitionQuery, params)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to execute search-by-definition query", "error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Query execution failed: %v", err)), nil
+	}
+
+	// The page is cut on the query rows: hits dropped for lack of a highlighted match do not shift the next offset
+	more := len(records) > maxDefinitionResults
+	page, err := processDefinitionRecords(records[:min(len(records), maxDefinitionResults)], input.Query)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to process search-by-definition results", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	output := SearchByDefinitionOutput{Results: page, MoreResults: more, NextCursor: pages.nextCursor(ctx, more, "results")}
+	reportProgress(ctx, stageFormatting)
+	jsonData, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to format search-by-definition results to JSON", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(string(jsonData)), nil
+}
+
+// processDefinitionRecords converts the query records into results with highlighted snippets. Records
+// without a highlighted match are dropped: Cypher toLower can fold some characters differently than Go
+func processDefinitionRecords(records []*neo4j.Record, query string) ([]DefinitionSearchResult, error) {
+	results := make([]DefinitionSearchResult, 0, len(records))
+	for _, record := range records {
+		guid, _, err := neo4j.GetRecordValue[string](record, "guid")
+		if err != nil {
+			return nil, fmt.Errorf("invalid 'guid' column in record: %w", err)
+		}
+		definition, _, err := neo4j.GetRecordValue[map[string]any](record, "definition")
+		if err != nil {
+			return nil, fmt.Errorf("invalid 'definition' column in record: %w", err)
+		}
+		objectType, _, _ := neo4j.GetRecordValue[string](record, "type")
+		name, _, _ := neo4j.GetRecordValue[string](record, "name")
+		location, _ := record.Get("location")
+		status, _ := record.Get("status")
+
+		matches := make([]DefinitionMatch, 0)
+		for _, field := range definitionFields {
+			value := stringValue(definition[field])
+			snippet, occurrences := highlightSnippet(value, query, definitionSnippetRadius)
+			if occurrences == 0 {
+				continue
+			}
+			matches = append(matches, DefinitionMatch{Field: field, Snippet: snippet, Occurrences: occurrences})
+		}
+		if len(matches) == 0 {
+			continue
+		}
+
+		results = append(results, DefinitionSearchResult{
+			Type:     objectType,
+			GUID:     guid,
+			Name:     name,
+			Location: location,
+			Status:   status,
+			Matches:  matches,
+		})
+	}
+	return results, nil
+}
+
+// highlightSnippet returns the text around the first case-insensitive occurrence of query,
+// with every occurrence inside the snippet wrapped in **, and the total number of occurrences.
+// Text cut from either end is replaced by "...".
+func highlightSnippet(text, query string, radius int) (string, int) {
+	if text == "" || query == "" {
+		return "", 0
+	}
+	// Fold case rune-by-rune so byte offsets in lower match the original text
+	lower := strings.Map(foldRune, text)
+	needle := strings.Map(foldRune, query)
+	occurrences := strings.Count(lower, needle)
+	if occurrences == 0 {
+		return "", 0
+	}
+
+	first := strings.Index(lower, needle)
+	start := clampToRune(text, max(first-radius, 0))
+	end := clampToRune(text, min(first+len(needle)+radius, len(text)))
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("...")
+	}
+	for pos := start; pos < end; {
+		idx := strings.Index(lower[pos:end], needle)
+		if idx < 0 {
+			b.WriteString(text[pos:end])
+			break
+		}
+		b.WriteString(text[pos : pos+idx])
+		b.WriteString("**")
+		b.WriteString(text[pos+idx : pos+idx+len(needle)])
+		b.WriteString("**")
+		pos += idx + len(needle)
+	}
+	if end < len(text) {
+		b.WriteString("...")
+	}
+	return b.String(), occurrences
+}
+
+// foldRune lower-cases r only when that keeps its UTF-8 length, so folded strings keep byte offsets
+func foldRune(r rune) rune {
+	l := []rune(strings.ToLower(string(r)))
+	if len(l) == 1 && utf8.RuneLen(l[0]) == utf8.RuneLen(r) {
+		return l[0]
+	}
+	return r
+}
+
+// clampToRune moves i back to the start of the UTF-8 rune containing it
+func clampToRune(s string, i int) int {
+	for i > 0 && i < len(s) && !utf8.RuneStart(s[i]) {
+		i--
+	}
+	return i
+}
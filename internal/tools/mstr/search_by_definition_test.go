@@ -0,0 +1,113 @@
+package mstr_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	db "github.com/brunogc-cit/flow-microstrategy-mcp/internal/database/mocks"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools/mstr"
+	"github.com/neo4j/neo4j-go-driver/v6/neo4j"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSearchByDefinitionHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("highlights matching fields", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Eq(map[string]any{"query": "netsalesvalue", "types": []string{"Metric"}, "offset": 0, "scope": nil})).
+			Return([]*neo4j.Record{{
+				Keys: []string{"type", "guid", "name", "location", "status", "definition"},
+				Values: []any{"Metric", "M1", "Net Sales LY", nil, "Planned", map[string]any{
+					"formula":             "Sum(NetSalesValue) {~+} - NetSalesValue",
+					"physical_table_name": nil,
+				}},
+			}}, nil)
+
+		result := callTool(t, mstr.SearchByDefinitionHandler(&tools.ToolDependencies{DBService: mockDB}), map[string]any{"query": "netsalesvalue", "types": []string{"Metric"}})
+		require.False(t, result.IsError, resultText(t, result))
+
+		var response struct {
+			Results     []mstr.DefinitionSearchResult `json:"results"`
+			MoreResults bool                          `json:"moreResults"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(resultText(t, result)), &response))
+		require.Len(t, response.Results, 1)
+		require.Len(t, response.Results[0].Matches, 1)
+		assert.Equal(t, mstr.DefinitionMatch{
+			Field:       "formula",
+			Snippet:     "Sum(**NetSalesValue**) {~+} - **NetSalesValue**",
+			Occurrences: 2,
+		}, response.Results[0].Matches[0])
+		assert.False(t, response.MoreResults)
+	})
+
+	t.Run("truncates long values around the first match", func(t *testing.T) {
+		long := ""
+		for i := 0; i < 20; i++ {
+			long += "padding "
+		}
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Eq(map[string]any{"query": "vwLookup", "types": nil, "offset": 0, "scope": nil})).
+			Return([]*neo4j.Record{{
+				Keys:   []string{"type", "guid", "name", "location", "status", "definition"},
+				Values: []any{"LogicalTable", "T1", "LU_WEEK", nil, "No Status", map[string]any{"physical_table_name": long + "dbo.vwLookupFinancialYearWeek" + long}},
+			}}, nil)
+
+		result := callTool(t, mstr.SearchByDefinitionHandler(&tools.ToolDependencies{DBService: mockDB}), map[string]any{"query": "vwLookup"})
+		require.False(t, result.IsError)
+
+		var response struct {
+			Results []mstr.DefinitionSearchResult `json:"results"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(resultText(t, result)), &response))
+		snippet := response.Results[0].Matches[0].Snippet
+		assert.Contains(t, snippet, "dbo.**vwLookup**FinancialYearWeek")
+		assert.True(t, len(snippet) < len(long))
+		assert.Equal(t, "...", snippet[:3])
+		assert.Equal(t, "...", snippet[len(snippet)-3:])
+	})
+
+	t.Run("drops hits without a highlighted match", func(t *testing.T) {
+		records := make([]*neo4j.Record, 101)
+		for i := range records {
+			// Cypher toLower folds the Kelvin sign K (U+212A) to k, Go keeps it: no highlighted match
+			formula := "Sum(\u212Aelvin)"
+			if i%2 == 0 {
+				formula = "Sum(Kelvin)"
+			}
+			records[i] = &neo4j.Record{
+				Keys:   []string{"type", "guid", "name", "location", "status", "definition"},
+				Values: []any{"Metric", fmt.Sprintf("M%03d", i), fmt.Sprintf("Metric %03d", i), nil, "Planned", map[string]any{"formula": formula}},
+			}
+		}
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Regex(`ORDER BY n.type ASC, n.name ASC, n.guid ASC`), gomock.Any()).
+			Return(records, nil)
+
+		result := callTool(t, mstr.SearchByDefinitionHandler(&tools.ToolDependencies{DBService: mockDB}), map[string]any{"query": "kelvin"})
+		require.False(t, result.IsError, resultText(t, result))
+
+		var output mstr.SearchByDefinitionOutput
+		require.NoError(t, json.Unmarshal([]byte(resultText(t, result)), &output))
+		assert.Len(t, output.Results, 50, "the 100 rows of the page, without the hits that cannot be highlighted")
+		for _, r := range output.Results {
+			assert.NotEmpty(t, r.Matches, r.GUID)
+		}
+		assert.True(t, output.MoreResults, "the 101st row is past the page")
+		assert.NotEmpty(t, output.NextCursor)
+	})
+
+	t.Run("missing query", func(t *testing.T) {
+		result := callTool(t, mstr.SearchByDefinitionHandler(&tools.ToolDependencies{DBService: db.NewMockService(ctrl)}), map[string]any{})
+		assert.True(t, result.IsError)
+	})
+}
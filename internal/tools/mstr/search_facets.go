@@ -0,0 +1,121 @@
+package mstr
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools"
+	"github.com/neo4j/neo4j-go-driver/v6/neo4j"
+)
+
+// searchFacets counts a match set (n) per effective status, priority and semantic model.
+// Appended to the match part of the search queries, so facets cover all matches, not just the page.
+const searchFacets = `
+// Facet counts over the full match set (not just the page)
+WITH collect({
+  status: COALESCE(n.updated_parity_status, n.parity_status, 'No Status'),
+  priority: n.inherited_priority_level,
+  semanticModel: n.pb_semantic_model
+}) as rows
+
+CALL {
+  WITH rows
+  UNWIND rows as r
+  WITH r.status as value, count(*) as count
+  ORDER BY count DESC, value ASC
+  RETURN collect({value: value, count: count}) as status
+}
+CALL {
+  WITH rows
+  UNWIND rows as r
+  WITH r.priority as value, count(*) as count
+  ORDER BY value ASC
+  RETURN collect({value: value, count: count}) as priority
+}
+CALL {
+  WITH rows
+  UNWIND rows as r
+  WITH r.semanticModel as value, count(*) as count
+  ORDER BY count DESC, value ASC
+  RETURN collect({value: value, count: count}) as semanticModel
+}
+
+RETURN {
+  total: size(rows),
+  status: status,
+  priority: priority,
+  semanticModel: semanticModel
+} as facets
+`
+
+// FacetCount is the number of matches with a facet value (absent for objects without one)
+type FacetCount struct {
+	Value any `json:"value,omitempty"`
+	Count int `json:"count"`
+}
+
+// SearchFacets counts the full match set of a search per effective status, priority and semantic model
+type SearchFacets struct {
+	Total         int          `json:"total"`
+	Status        []FacetCount `json:"status"`
+	Priority      []FacetCount `json:"priority"`
+	SemanticModel []FacetCount `json:"semanticModel"`
+}
+
+// searchFacetsByGUIDsQuery counts the matches of a fuzzy search ranked in Go
+const searchFacetsByGUIDsQuery = `
+// Facets for a match set given by GUID
+// $guids: GUIDs of every match
+
+UNWIND $guids as guid
+MATCH (n:MSTRObject {guid: guid})
+` + searchFacets
+
+// searchQueries are the per-type queries used by the search tools
+type searchQueries struct {
+	// contains matches by GUID or name CONTAINS ($query, $status, $offset), alphabetical
+	contains       string
+	containsFacets string
+	// fullText is the relevance-ranked full-text query ($index, $ftQuery), used when the index is available
+	fullText       string
+	fullTextFacets string
+	// candidates returns guid/name for every object of the type ($status), for fuzzy ranking in Go
+	candidates string
+	// page returns the search results for $guids, in order, with their $scores
+	page string
+}
+
+// facetsSource is the query counting the match set of an executed search
+type facetsSource struct {
+	query  string
+	params map[string]any
+}
+
+// executeSearch runs a search in the requested mode and, when withFacets is set, adds a "facets"
+// column with counts over the same match set to the result record.
+func executeSearch(ctx context.Context, deps *tools.ToolDependencies, queries searchQueries, mode string, withFacets bool, params map[string]any) ([]*neo4j.Record, error) {
+	var records []*neo4j.Record
+	var source facetsSource
+	var err error
+	if mode == searchModeFuzzy {
+		records, source, err = executeFuzzySearch(ctx, deps, queries, params)
+	} else {
+		records, source, err = executeRankedSearch(ctx, deps, queries, params)
+	}
+	if err != nil || !withFacets {
+		return records, err
+	}
+
+	reportProgress(ctx, stageCounting)
+	facetRecords, err := deps.DBService.ExecuteReadQuery(ctx, source.query, source.params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute facets: %w", err)
+	}
+	if len(records) != 1 || len(facetRecords) != 1 {
+		return records, nil
+	}
+	facets, _ := facetRecords[0].Get("facets")
+	records[0].Keys = append(records[0].Keys, "facets")
+	records[0].Values = append(records[0].Values, facets)
+	return records, nil
+}
@@ -0,0 +1,129 @@
+package mstr_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	db "github.com/brunogc-cit/flow-microstrategy-mcp/internal/database/mocks"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools/mstr"
+	"github.com/neo4j/neo4j-go-driver/v6/neo4j"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func facetsRecord() []*neo4j.Record {
+	return []*neo4j.Record{{
+		Keys:   []string{"facets"},
+		Values: []any{map[string]any{"total": int64(3)}},
+	}}
+}
+
+func TestSearchFacets(t *testing.T) {
+	t.Run("adds facets over the same match set", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDB := db.NewMockService(ctrl)
+		var facetsParams map[string]any
+		gomock.InOrder(
+			mockDB.EXPECT().
+				ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return([]*neo4j.Record{{Keys: []string{"metrics", "moreResults"}, Values: []any{[]any{}, false}}}, nil),
+			mockDB.EXPECT().
+				ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, query string, params map[string]any) ([]*neo4j.Record, error) {
+					assert.Contains(t, query, "as facets")
+					assert.True(t, strings.Contains(query, "CONTAINS"), "facets must use the CONTAINS match")
+					facetsParams = params
+					return facetsRecord(), nil
+				}),
+		)
+		mockDB.EXPECT().
+			Neo4jRecordsToJSON(gomock.Any()).
+			DoAndReturn(func(records []*neo4j.Record) (string, error) {
+				require.Len(t, records, 1)
+				facets, ok := records[0].Get("facets")
+				assert.True(t, ok)
+				assert.Equal(t, map[string]any{"total": int64(3)}, facets)
+				return "[]", nil
+			})
+
+		result := callTool(t, mstr.SearchMetricsHandler(&tools.ToolDependencies{DBService: mockDB}), map[string]any{"query": "sales", "facets": true})
+		require.False(t, result.IsError, resultText(t, result))
+		assert.Equal(t, "sales", facetsParams["query"])
+	})
+
+	t.Run("uses the full-text match set when the index is available", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDB := db.NewMockService(ctrl)
+		gomock.InOrder(
+			mockDB.EXPECT().
+				ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return([]*neo4j.Record{{Keys: []string{"attributes"}, Values: []any{[]any{}}}}, nil),
+			mockDB.EXPECT().
+				ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, query string, params map[string]any) ([]*neo4j.Record, error) {
+					assert.Contains(t, query, "db.index.fulltext.queryNodes")
+					assert.Equal(t, mstr.SearchIndexName, params["index"])
+					return facetsRecord(), nil
+				}),
+		)
+		mockDB.EXPECT().Neo4jRecordsToJSON(gomock.Any()).Return("[]", nil)
+
+		deps := &tools.ToolDependencies{DBService: mockDB, SearchIndex: searchIndexFlag(true)}
+		result := callTool(t, mstr.SearchAttributesHandler(deps), map[string]any{"query": "store", "facets": true})
+		require.False(t, result.IsError, resultText(t, result))
+	})
+
+	t.Run("counts every fuzzy match, not just the page", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDB := db.NewMockService(ctrl)
+		var facetGUIDs []string
+		gomock.InOrder(
+			mockDB.EXPECT().
+				ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Eq(map[string]any{"status": nil, "scope": nil, "limit": 20001})).
+				Return(nameRecords(map[string]string{"G1": "Retail Sales", "G2": "Sales LY", "G3": "Margin"}), nil),
+			mockDB.EXPECT().
+				ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return([]*neo4j.Record{{Keys: []string{"metrics"}, Values: []any{[]any{}}}}, nil),
+			mockDB.EXPECT().
+				ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ string, params map[string]any) ([]*neo4j.Record, error) {
+					facetGUIDs = params["guids"].([]string)
+					return facetsRecord(), nil
+				}),
+		)
+		mockDB.EXPECT().Neo4jRecordsToJSON(gomock.Any()).Return("[]", nil)
+
+		result := callTool(t, mstr.SearchMetricsHandler(&tools.ToolDependencies{DBService: mockDB}), map[string]any{"query": "sales", "mode": "fuzzy", "facets": true, "offset": 1})
+		require.False(t, result.IsError, resultText(t, result))
+		assert.ElementsMatch(t, []string{"G1", "G2"}, facetGUIDs)
+	})
+
+	t.Run("reports facet query failures", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDB := db.NewMockService(ctrl)
+		gomock.InOrder(
+			mockDB.EXPECT().
+				ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return([]*neo4j.Record{{Keys: []string{"metrics"}, Values: []any{[]any{}}}}, nil),
+			mockDB.EXPECT().
+				ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(nil, errors.New("boom")),
+		)
+
+		result := callTool(t, mstr.SearchMetricsHandler(&tools.ToolDependencies{DBService: mockDB}), map[string]any{"query": "sales", "facets": true})
+		assert.True(t, result.IsError)
+		assert.Contains(t, resultText(t, result), "facets")
+	})
+}
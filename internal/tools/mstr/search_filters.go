@@ -0,0 +1,176 @@
+package mstr
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// SearchFiltersInput defines the input parameters for the search-filters tool
+type SearchFiltersInput struct {
+	Query  string `json:"query" jsonschema:"required,description=GUID (full or partial 8+ chars) or name search term"`
+	Scope  string `json:"scope,omitempty" jsonschema:"description=Project or folder to search in (e.g. Retail or Retail/Public Objects/Metrics; see list-projects). Defaults to the server scope; * for all projects"`
+	Offset int    `json:"offset,omitempty" jsonschema:"default=0,description=Skip first N results for pagination"`
+	Cursor string `json:"cursor,omitempty" jsonschema:"description=nextCursor from the previous page (preferred over offset: stable and cheap for deep pages)"`
+}
+
+// FilterSearchResult is a Filter found by search-filters
+type FilterSearchResult struct {
+	Type           string `json:"type,omitempty"`
+	GUID           string `json:"guid,omitempty"`
+	Name           string `json:"name,omitempty"`
+	Location       string `json:"location,omitempty"`
+	Expression     string `json:"expression,omitempty"`
+	QualifiesCount int    `json:"qualifiesCount,omitempty"`
+	ReportCount    int    `json:"reportCount,omitempty"`
+}
+
+// SearchFiltersOutput defines the output of the search-filters tool
+type SearchFiltersOutput struct {
+	Results     []FilterSearchResult `json:"results"`
+	MoreResults bool                 `json:"moreResults"`
+	NextCursor  string               `json:"nextCursor,omitempty"`
+	Truncated   []TruncatedList      `json:"truncated,omitempty"`
+}
+
+const searchFiltersQuery = `
+// Search for Filters by GUID or name
+// $query: GUID (full/partial) or name search term
+// $scope: optional location prefixes (project/folder scope), null for all projects
+// $offset: pagination offset (0, 100, 200, ...)
+// $after: {name, guid} of the last result of the previous page (cursor), or null
+
+// Determine if query looks like a GUID (hex chars, 8+ length)
+WITH $query as query,
+     $query =~ '^[A-Fa-f0-9]{8,}$' as isGuidLike
+
+MATCH (n:MSTRObject)
+WHERE n.type = 'Filter'
+  AND n.guid IS NOT NULL
+  AND (
+    // GUID match: exact or partial (starts with)
+    (isGuidLike AND (n.guid = query OR n.guid STARTS WITH toUpper(query)))
+    OR
+    // Name match: case-insensitive contains
+    (NOT isGuidLike AND toLower(n.name) CONTAINS toLower(query))
+  )
+  AND ($scope IS NULL OR any(p IN $scope WHERE replace(toLower(n.location), '\\', '/') + '/' STARTS WITH p))
+
+// Keyset pagination on (name, guid) after a cursor
+WITH n
+WHERE ($after IS NULL OR n.name > $after.name OR (n.name = $after.name AND n.guid > $after.guid))
+ORDER BY n.name ASC, n.guid ASC
+SKIP CASE WHEN $after IS NULL THEN $offset ELSE 0 END
+LIMIT 101  // Fetch 101 to determine if more results exist
+
+// Count qualified objects and prioritized reports (directly or through other Prompts/Filters)
+CALL {
+  WITH n
+  OPTIONAL MATCH path = (r)-[:DEPENDS_ON*1..10]->(n)
+  WHERE r.type IN ['Report', 'GridReport', 'Document']
+    AND r.priority_level IS NOT NULL
+    AND ALL(mid IN nodes(path)[1..-1] WHERE mid.type IN ['Prompt', 'Filter'])
+  RETURN count(DISTINCT r) as reportCount
+}
+
+WITH n, reportCount,
+     size([(n)-[:DEPENDS_ON]->(q) WHERE q.type IN ['Metric', 'Attribute'] | q.guid]) as qualifiesCount
+
+WITH collect({
+  type: 'Filter',
+  guid: n.guid,
+  name: n.name,
+  location: n.location,
+  expression: COALESCE(n.expression, n.formula),
+  qualifiesCount: qualifiesCount,
+  reportCount: reportCount
+}) as fetched
+
+// Return first 100; moreResults=true if 101st exists
+RETURN
+  fetched[0..100] as results,
+  size(fetched) > 100 as moreResults
+`
+
+// SearchFiltersSpec returns the MCP tool definition for search-filters
+func SearchFiltersSpec() mcp.Tool {
+	return mcp.NewTool("search-filters",
+		mcp.WithDescription(
+			"Find Filters by GUID or name. Accepts full GUIDs, partial GUIDs (8+ chars), or name search terms.\n\n"+
+				"USE FOR:\n"+
+				"- Finding filters by name: search-filters(query=\"Last Year\")\n"+
+				"- Finding a filter by GUID: search-filters(query=\"A1B2C3D4\")\n"+
+				"- Getting a quick view of filter expressions and how many prioritized reports use them\n\n"+
+				"DO NOT USE FOR:\n"+
+				"- Full filter definitions and the reports using them (use get-filter with the GUID instead)\n"+
+				"- Searching Prompts (use search-prompts instead)\n\n"+
+				"PAGINATION: Returns 100 results. If moreResults=true, call again with cursor=nextCursor (or offset+100).",
+		),
+		mcp.WithInputSchema[SearchFiltersInput](),
+		mcp.WithOutputSchema[SearchFiltersOutput](),
+		mcp.WithTitleAnnotation("Search for Filters by GUID or name"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(true),
+	)
+}
+
+// SearchFiltersHandler returns the handler function for the search-filters tool
+func SearchFiltersHandler(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleSearchFilters(ctx, deps, request)
+	}
+}
+
+func handleSearchFilters(ctx context.Context, deps *tools.ToolDependencies, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if deps.DBService == nil {
+		errMessage := "Database service is not initialized"
+		slog.ErrorContext(ctx, errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	// Parse input
+	var input SearchFiltersInput
+	if err := request.BindArguments(&input); err != nil {
+		slog.ErrorContext(ctx, "error binding arguments", "error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid input: %v", err)), nil
+	}
+
+	// Validate required field
+	if input.Query == "" {
+		return mcp.NewToolResultError("query parameter is required"), nil
+	}
+
+	params := map[string]any{
+		"query":  input.Query,
+		"offset": input.Offset,
+		"scope":  scopeParam(deps, input.Scope),
+	}
+
+	pages, err := newPager("search-filters", params, input.Cursor)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	reportProgress(ctx, stageSearching)
+	records, err := deps.DBService.ExecuteReadQuery(ctx, searchFiltersQuery, params)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to execute search-filters query", "error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Query execution failed: %v", err)), nil
+	}
+
+	pages.attachNextCursor(ctx, records, "results")
+
+	reportProgress(ctx, stageFormatting)
+	response, err := deps.DBService.Neo4jRecordsToJSON(records)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to format search-filters results to JSON", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(response), nil
+}
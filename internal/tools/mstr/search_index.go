@@ -0,0 +1,102 @@
+package mstr
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/database"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools"
+	"github.com/neo4j/neo4j-go-driver/v6/neo4j"
+)
+
+// SearchIndexName is the Neo4j full-text index used for relevance-ranked name search
+const SearchIndexName = "mstr_object_search"
+
+// CheckSearchIndexQuery returns the state of the full-text search index (no rows when it does not exist)
+const CheckSearchIndexQuery = "SHOW INDEXES YIELD name, type, state WHERE name = $name AND type = 'FULLTEXT' RETURN state"
+
+// createSearchIndexQuery creates the full-text index over MSTR object names and descriptions
+const createSearchIndexQuery = `CREATE FULLTEXT INDEX ` + SearchIndexName + ` IF NOT EXISTS
+FOR (n:MSTRObject) ON EACH [n.name, n.description]`
+
+// guidLikePattern mirrors the isGuidLike check of the search queries
+var guidLikePattern = regexp.MustCompile(`^[A-Fa-f0-9]{8,}$`)
+
+// luceneSpecialChars are escaped in user input before building a full-text query
+var luceneSpecialChars = regexp.MustCompile(`([+\-&|!(){}\[\]^"~*?:\\/])`)
+
+// CheckSearchIndex reports whether the full-text search index exists and is ONLINE
+func CheckSearchIndex(ctx context.Context, dbService database.Service) (bool, error) {
+	records, err := dbService.ExecuteReadQuery(ctx, CheckSearchIndexQuery, map[string]any{"name": SearchIndexName})
+	if err != nil {
+		return false, err
+	}
+	for _, record := range records {
+		state, _, err := neo4j.GetRecordValue[string](record, "state")
+		if err == nil && state == "ONLINE" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// CreateSearchIndex creates the full-text search index if it does not exist yet.
+// Neo4j populates the index in the background; it is used once its state is ONLINE.
+func CreateSearchIndex(ctx context.Context, dbService database.Service) error {
+	if _, err := dbService.ExecuteWriteQuery(ctx, createSearchIndexQuery, nil); err != nil {
+		return fmt.Errorf("failed to create full-text index %s: %w", SearchIndexName, err)
+	}
+	return nil
+}
+
+// buildFullTextQuery converts a free-text search term into a Lucene query:
+// the exact phrase is boosted, and every term must match as a prefix.
+func buildFullTextQuery(query string) string {
+	terms := strings.Fields(query)
+	if len(terms) == 0 {
+		return ""
+	}
+	escaped := make([]string, len(terms))
+	prefixes := make([]string, len(terms))
+	for i, term := range terms {
+		escaped[i] = luceneSpecialChars.ReplaceAllString(term, `\$1`)
+		prefixes[i] = escaped[i] + "*"
+	}
+	return fmt.Sprintf(`"%s"^2 OR (%s)`, strings.Join(escaped, " "), strings.Join(prefixes, " AND "))
+}
+
+// useSearchIndex reports whether a search term should be answered by the full-text index
+func useSearchIndex(deps *tools.ToolDependencies, query string) bool {
+	return deps.SearchIndex != nil && deps.SearchIndex.Load() && !guidLikePattern.MatchString(query)
+}
+
+// executeRankedSearch runs the full-text query with $index/$ftQuery parameters when the search index is
+// available, and the CONTAINS query otherwise. A failing full-text query falls back to the CONTAINS query;
+// when the error names the index (e.g. it was dropped) the index is also marked as unavailable.
+// The returned facetsSource counts the same match set.
+func executeRankedSearch(ctx context.Context, deps *tools.ToolDependencies, queries searchQueries, params map[string]any) ([]*neo4j.Record, facetsSource, error) {
+	query, _ := params["query"].(string)
+	if useSearchIndex(deps, query) {
+		ftParams := make(map[string]any, len(params)+2)
+		for k, v := range params {
+			ftParams[k] = v
+		}
+		ftParams["index"] = SearchIndexName
+		ftParams["ftQuery"] = buildFullTextQuery(query)
+
+		records, err := deps.DBService.ExecuteReadQuery(ctx, queries.fullText, ftParams)
+		if err == nil {
+			return records, facetsSource{query: queries.fullTextFacets, params: ftParams}, nil
+		}
+		slog.WarnContext(ctx, "full-text search failed, falling back to CONTAINS search", "index", SearchIndexName, "error", err)
+		if strings.Contains(err.Error(), SearchIndexName) {
+			// The index was dropped: stop trying it until the next startup check
+			deps.SearchIndex.Store(false)
+		}
+	}
+	records, err := deps.DBService.ExecuteReadQuery(ctx, queries.contains, params)
+	return records, facetsSource{query: queries.containsFacets, params: params}, err
+}
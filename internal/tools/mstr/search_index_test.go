@@ -0,0 +1,134 @@
+package mstr_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	db "github.com/brunogc-cit/flow-microstrategy-mcp/internal/database/mocks"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools/mstr"
+	"github.com/neo4j/neo4j-go-driver/v6/neo4j"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func searchIndexFlag(available bool) *atomic.Bool {
+	flag := &atomic.Bool{}
+	flag.Store(available)
+	return flag
+}
+
+func TestCheckSearchIndex(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name     string
+		records  []*neo4j.Record
+		expected bool
+	}{
+		{name: "missing index", records: []*neo4j.Record{}, expected: false},
+		{name: "populating index", records: []*neo4j.Record{{Keys: []string{"state"}, Values: []any{"POPULATING"}}}, expected: false},
+		{name: "online index", records: []*neo4j.Record{{Keys: []string{"state"}, Values: []any{"ONLINE"}}}, expected: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB := db.NewMockService(ctrl)
+			mockDB.EXPECT().
+				ExecuteReadQuery(gomock.Any(), mstr.CheckSearchIndexQuery, gomock.Eq(map[string]any{"name": mstr.SearchIndexName})).
+				Return(tt.records, nil)
+
+			available, err := mstr.CheckSearchIndex(context.Background(), mockDB)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, available)
+		})
+	}
+}
+
+func TestRankedSearch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	containsParams := map[string]any{"query": "net sales", "status": nil, "sortBy": nil, "offset": 0, "after": nil, "scope": nil}
+
+	t.Run("uses the full-text index when available", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Eq(map[string]any{
+				"query":   "net sales",
+				"status":  nil,
+				"sortBy":  nil,
+				"offset":  0,
+				"after":   nil,
+				"scope":   nil,
+				"index":   mstr.SearchIndexName,
+				"ftQuery": `"net sales"^2 OR (net* AND sales*)`,
+			})).
+			Return([]*neo4j.Record{}, nil)
+		mockDB.EXPECT().Neo4jRecordsToJSON(gomock.Any()).Return("[]", nil)
+
+		deps := &tools.ToolDependencies{DBService: mockDB, SearchIndex: searchIndexFlag(true)}
+		result := callTool(t, mstr.SearchMetricsHandler(deps), map[string]any{"query": "net sales"})
+		assert.False(t, result.IsError)
+	})
+
+	t.Run("escapes Lucene special characters", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Cond(func(x any) bool {
+				return x.(map[string]any)["ftQuery"] == `"Sales \(LY\)"^2 OR (Sales* AND \(LY\)*)`
+			})).
+			Return([]*neo4j.Record{}, nil)
+		mockDB.EXPECT().Neo4jRecordsToJSON(gomock.Any()).Return("[]", nil)
+
+		deps := &tools.ToolDependencies{DBService: mockDB, SearchIndex: searchIndexFlag(true)}
+		result := callTool(t, mstr.SearchAttributesHandler(deps), map[string]any{"query": "Sales (LY)"})
+		assert.False(t, result.IsError)
+	})
+
+	t.Run("falls back to CONTAINS when the index is missing", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Eq(containsParams)).
+			Return([]*neo4j.Record{}, nil)
+		mockDB.EXPECT().Neo4jRecordsToJSON(gomock.Any()).Return("[]", nil)
+
+		deps := &tools.ToolDependencies{DBService: mockDB, SearchIndex: searchIndexFlag(false)}
+		result := callTool(t, mstr.SearchMetricsHandler(deps), map[string]any{"query": "net sales"})
+		assert.False(t, result.IsError)
+	})
+
+	t.Run("falls back to CONTAINS when the index was dropped", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		gomock.InOrder(
+			mockDB.EXPECT().
+				ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Not(gomock.Eq(containsParams))).
+				Return(nil, errors.New("There is no such fulltext schema index: "+mstr.SearchIndexName)),
+			mockDB.EXPECT().
+				ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Eq(containsParams)).
+				Return([]*neo4j.Record{}, nil),
+		)
+		mockDB.EXPECT().Neo4jRecordsToJSON(gomock.Any()).Return("[]", nil)
+
+		index := searchIndexFlag(true)
+		deps := &tools.ToolDependencies{DBService: mockDB, SearchIndex: index}
+		result := callTool(t, mstr.SearchMetricsHandler(deps), map[string]any{"query": "net sales"})
+		assert.False(t, result.IsError)
+		assert.False(t, index.Load())
+	})
+
+	t.Run("GUID searches do not use the index", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Eq(map[string]any{"query": "2F00974D", "status": nil, "sortBy": nil, "offset": 0, "after": nil, "scope": nil})).
+			Return([]*neo4j.Record{}, nil)
+		mockDB.EXPECT().Neo4jRecordsToJSON(gomock.Any()).Return("[]", nil)
+
+		deps := &tools.ToolDependencies{DBService: mockDB, SearchIndex: searchIndexFlag(true)}
+		result := callTool(t, mstr.SearchMetricsHandler(deps), map[string]any{"query": "2F00974D"})
+		assert.False(t, result.IsError)
+	})
+}
@@ -0,0 +1,143 @@
+package mstr
+
+// searchMetricsQueries and searchAttributesQueries are the queries of search-metrics and search-attributes
+var (
+	searchMetricsQueries    = newSearchQueries("Metric", "formula: n.formula")
+	searchAttributesQueries = newSearchQueries("Attribute", "forms_json: n.forms_json")
+)
+
+// newSearchQueries builds the search queries of an object label. definition is the entry of the result
+// map holding the definition of the label (formula for Metrics, forms_json for Attributes).
+func newSearchQueries(label, definition string) searchQueries {
+	// match matches objects by GUID or name (CONTAINS); shared by the page and facets queries
+	match := `
+// Search for ` + label + `s by GUID or name
+// $query: GUID (full/partial) or name search term
+// $status: optional parity status filter (applies to effective status)
+// $scope: optional location prefixes (project/folder scope), null for all projects
+// $offset: pagination offset (0, 100, 200, ...)
+//
+// Design Decision: Returns ALL ` + label + `s matching the query, including those without
+// parity mapping. Objects not in the parity matrix get status "No Status".
+// The updated_parity_status property (from ADO backlog sync) takes precedence
+// over the computed parity_status.
+
+// Determine if query looks like a GUID (hex chars, 8+ length)
+WITH $query as query,
+     $query =~ '^[A-Fa-f0-9]{8,}$' as isGuidLike
+
+MATCH (n:` + label + `)
+WHERE n.guid IS NOT NULL
+  AND (
+    // GUID match: exact or partial (starts with)
+    (isGuidLike AND (n.guid = query OR n.guid STARTS WITH toUpper(query)))
+    OR
+    // Name match: case-insensitive contains
+    (NOT isGuidLike AND toLower(n.name) CONTAINS toLower(query))
+  )
+  AND ($status IS NULL OR COALESCE(n.updated_parity_status, n.parity_status) IN $status)
+  AND ($scope IS NULL OR any(p IN $scope WHERE replace(toLower(n.location), '\\', '/') + '/' STARTS WITH p))
+`
+
+	// fullTextMatch matches objects through the full-text index; shared by the page and facets queries
+	fullTextMatch := `
+// Relevance-ranked search for ` + label + `s using the full-text index
+// $index: full-text index name
+// $ftQuery: Lucene query built from the search term
+// $status: optional parity status filter (applies to effective status)
+// $scope: optional location prefixes (project/folder scope), null for all projects
+// $offset: pagination offset (0, 100, 200, ...)
+
+CALL db.index.fulltext.queryNodes($index, $ftQuery) YIELD node, score
+WITH node as n, score
+WHERE n:` + label + `
+  AND n.guid IS NOT NULL
+  AND ($status IS NULL OR COALESCE(n.updated_parity_status, n.parity_status) IN $status)
+  AND ($scope IS NULL OR any(p IN $scope WHERE replace(toLower(n.location), '\\', '/') + '/' STARTS WITH p))
+`
+
+	// results expects n and score for the current page and returns the results with moreResults
+	results := `
+// Compute effective values (updated_ properties take precedence)
+WITH n, score,
+     COALESCE(n.updated_parity_status, n.parity_status, 'No Status') as effectiveStatus
+
+// Collect results with all properties (updated_ values take precedence)
+WITH collect({
+  type: '` + label + `',
+  guid: n.guid,
+  name: n.name,
+  status: effectiveStatus,
+  priority: n.inherited_priority_level,
+  ` + definition + `,
+  notes: COALESCE(n.updated_parity_notes, n.parity_notes),
+  raw: COALESCE(n.updated_db_raw, n.db_raw),
+  serve: COALESCE(n.updated_db_serve, n.db_serve),
+  semantic: n.pb_semantic,
+  edwTable: COALESCE(n.updated_edw_table, n.edw_table),
+  edwColumn: n.edw_column,
+  adeTable: COALESCE(n.updated_ade_db_table, n.ade_db_table),
+  adeColumn: n.ade_db_column,
+  semanticName: n.pb_semantic_name,
+  semanticModel: n.pb_semantic_model,
+  dbEssential: n.db_essential,
+  pbEssential: n.pb_essential,
+  reportCount: COALESCE(n.lineage_used_by_reports_count, 0),
+  tableCount: COALESCE(n.lineage_source_tables_count, 0),
+  ado_link: COALESCE(n.updated_ado_link, n.ado_link),
+  score: score
+}) as fetched
+
+// Return first 100; moreResults=true if 101st exists
+RETURN 
+  fetched[0..100] as results,
+  size(fetched) > 100 as moreResults
+`
+
+	return searchQueries{
+		contains: match + `
+// Alphabetical order unless $sortBy is set (no relevance score without the full-text index)
+// $sortBy: optional sort order (name, reportCount, tableCount, priority, status)
+// $after: {name, guid} of the last result of the previous page (cursor), or null
+WITH n, null as score,` + searchSortKey + `
+WHERE ($after IS NULL OR n.name > $after.name OR (n.name = $after.name AND n.guid > $after.guid))
+ORDER BY sortKey ASC, n.name ASC, n.guid ASC
+SKIP CASE WHEN $after IS NULL THEN $offset ELSE 0 END
+LIMIT 101  // Fetch 101 to determine if more results exist
+` + results,
+		containsFacets: match + searchFacets,
+		// Used instead of contains for name searches when the index is ONLINE
+		fullText: fullTextMatch + `
+// Best match first unless $sortBy is set; ties broken by name
+WITH n, score,` + searchSortKey + `
+ORDER BY sortKey ASC, CASE $sortBy WHEN 'name' THEN n.name END ASC, score DESC, n.name ASC, n.guid ASC
+SKIP $offset
+LIMIT 101  // Fetch 101 to determine if more results exist
+` + results,
+		fullTextFacets: fullTextMatch + searchFacets,
+		candidates: `
+// Fuzzy search candidates: every ` + label + ` name
+// $status: optional parity status filter (applies to effective status)
+// $scope: optional location prefixes (project/folder scope), null for all projects
+// $limit: maximum number of candidates ranked (one more to detect larger sets)
+
+MATCH (n:` + label + `)
+WHERE n.guid IS NOT NULL
+  AND n.name IS NOT NULL
+  AND ($status IS NULL OR COALESCE(n.updated_parity_status, n.parity_status) IN $status)
+  AND ($scope IS NULL OR any(p IN $scope WHERE replace(toLower(n.location), '\\', '/') + '/' STARTS WITH p))
+RETURN n.guid as guid, n.name as name
+LIMIT $limit
+`,
+		page: `
+// Fuzzy search results for the current page
+// $guids: GUIDs of the page, best match first (101 to determine if more results exist)
+// $scores: similarity scores aligned with $guids
+
+UNWIND range(0, size($guids) - 1) as i
+MATCH (n:` + label + ` {guid: $guids[i]})
+WITH i, n, $scores[i] as score
+ORDER BY i ASC
+` + results,
+	}
+}
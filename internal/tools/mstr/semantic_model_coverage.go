@@ -0,0 +1,359 @@
+package mstr
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// SemanticModelCoverageInput defines the input parameters for the semantic-model-coverage tool
+type SemanticModelCoverageInput struct {
+	Model  string `json:"model,omitempty" jsonschema:"description=Power BI semantic model name (pb_semantic_model). Omit for a per-model summary of all models"`
+	Scope  string `json:"scope,omitempty" jsonschema:"description=Project or folder to search in (e.g. Retail or Retail/Public Objects/Metrics; see list-projects). Defaults to the server scope; * for all projects"`
+	Offset int    `json:"offset,omitempty" jsonschema:"default=0,description=Skip first N results for pagination (models in summary mode, list items in detail mode)"`
+	Cursor string `json:"cursor,omitempty" jsonschema:"description=nextCursor from the previous page (continues at the next position: equivalent to offset+100)"`
+}
+
+// CoverageObject is a mapped or unmapped Metric/Attribute of a semantic model
+type CoverageObject struct {
+	Type          string `json:"type,omitempty"`
+	GUID          string `json:"guid,omitempty"`
+	Name          string `json:"name,omitempty"`
+	Status        string `json:"status,omitempty"`
+	SemanticName  string `json:"semanticName,omitempty"`
+	MappedInModel string `json:"mappedInModel,omitempty"`
+	UsedByReports int    `json:"usedByReports,omitempty"`
+}
+
+// DuplicateSemanticName is a semantic name mapped from more than one object of a model
+type DuplicateSemanticName struct {
+	SemanticName string      `json:"semanticName,omitempty"`
+	Objects      []ObjectRef `json:"objects,omitempty"`
+}
+
+// ModelCoverage is the coverage of a semantic model: counts (summary mode) and lists (detail mode)
+type ModelCoverage struct {
+	Model              string                  `json:"model,omitempty"`
+	ReportCount        int                     `json:"reportCount,omitempty"`
+	MappedMetrics      int                     `json:"mappedMetrics,omitempty"`
+	MappedAttributes   int                     `json:"mappedAttributes,omitempty"`
+	UnmappedCount      int                     `json:"unmappedCount,omitempty"`
+	DuplicateNameCount int                     `json:"duplicateNameCount,omitempty"`
+	Mapped             []CoverageObject        `json:"mapped,omitempty"`
+	Unmapped           []CoverageObject        `json:"unmapped,omitempty"`
+	Duplicates         []DuplicateSemanticName `json:"duplicates,omitempty"`
+}
+
+// SemanticModelCoverageResult lists the coverage of the semantic models
+type SemanticModelCoverageResult struct {
+	Models      []ModelCoverage `json:"models"`
+	MoreResults bool            `json:"moreResults"`
+}
+
+// SemanticModelCoverageOutput defines the output of the semantic-model-coverage tool
+type SemanticModelCoverageOutput struct {
+	Result     SemanticModelCoverageResult `json:"result"`
+	NextCursor string                      `json:"nextCursor,omitempty"`
+	Truncated  []TruncatedList             `json:"truncated,omitempty"`
+}
+
+// semanticModelSummaryQuery counts the coverage of every semantic model (summary mode)
+const semanticModelSummaryQuery = `
+// Power BI semantic model coverage summary: counts per model, no lists
+// $offset: pagination offset for models
+// $scope: optional location prefixes (project/folder scope), null for all projects
+//
+// Counts follow semanticModelCoverageQuery (mapped, unmapped and duplicates); they are only
+// computed for the models of the page.
+
+MATCH (o:MSTRObject)
+WHERE o.pb_semantic_model IS NOT NULL
+  AND ($scope IS NULL OR any(p IN $scope WHERE replace(toLower(o.location), '\\', '/') + '/' STARTS WITH p))
+WITH DISTINCT o.pb_semantic_model as model
+ORDER BY model ASC
+SKIP $offset
+LIMIT 101  // Fetch 101 to determine if more models exist
+WITH collect(model) as fetched
+
+CALL {
+  WITH fetched
+  UNWIND fetched[0..100] as model
+
+  // Mapped objects
+  CALL {
+    WITH model
+    MATCH (m:MSTRObject)
+    WHERE m.type IN ['Metric', 'Attribute']
+      AND m.pb_semantic_model = model
+      AND ($scope IS NULL OR any(p IN $scope WHERE replace(toLower(m.location), '\\', '/') + '/' STARTS WITH p))
+    RETURN count(CASE WHEN m.type = 'Metric' THEN 1 END) as mappedMetrics,
+           count(CASE WHEN m.type = 'Attribute' THEN 1 END) as mappedAttributes
+  }
+
+  // Prioritized reports assigned to the model
+  CALL {
+    WITH model
+    MATCH (r:MSTRObject)
+    WHERE r.type IN ['Report', 'GridReport', 'Document']
+      AND r.priority_level IS NOT NULL
+      AND r.pb_semantic_model = model
+      AND ($scope IS NULL OR any(p IN $scope WHERE replace(toLower(r.location), '\\', '/') + '/' STARTS WITH p))
+    RETURN count(r) as reportCount
+  }
+
+  // Dependencies of the model's prioritized reports that are not mapped into the model
+  CALL {
+    WITH model
+    MATCH (r:MSTRObject)
+    WHERE r.type IN ['Report', 'GridReport', 'Document']
+      AND r.priority_level IS NOT NULL
+      AND r.pb_semantic_model = model
+      AND ($scope IS NULL OR any(p IN $scope WHERE replace(toLower(r.location), '\\', '/') + '/' STARTS WITH p))
+    MATCH path = (r)-[:DEPENDS_ON*1..10]->(dep)
+    WHERE dep.type IN ['Metric', 'Attribute']
+      AND COALESCE(dep.pb_semantic_model, '') <> model
+      AND ALL(mid IN nodes(path)[1..-1] WHERE mid.type IN ['Prompt', 'Filter'])
+    RETURN count(DISTINCT dep) as unmappedCount
+  }
+
+  // Semantic names mapped more than once within the model
+  CALL {
+    WITH model
+    MATCH (m:MSTRObject)
+    WHERE m.type IN ['Metric', 'Attribute']
+      AND m.pb_semantic_model = model
+      AND ($scope IS NULL OR any(p IN $scope WHERE replace(toLower(m.location), '\\', '/') + '/' STARTS WITH p))
+      AND m.pb_semantic_name IS NOT NULL
+    WITH m.pb_semantic_name as semanticName, count(*) as objects
+    WHERE objects > 1
+    RETURN count(semanticName) as duplicateNameCount
+  }
+
+  RETURN collect({
+    model: model,
+    reportCount: reportCount,
+    mappedMetrics: mappedMetrics,
+    mappedAttributes: mappedAttributes,
+    unmappedCount: unmappedCount,
+    duplicateNameCount: duplicateNameCount
+  }) as models
+}
+
+RETURN {
+  models: models,
+  moreResults: size(fetched) > 100
+} as result
+`
+
+// semanticModelCoverageQuery lists the coverage of a semantic model (detail mode)
+const semanticModelCoverageQuery = `
+// Power BI semantic model coverage
+// $model: semantic model name
+// $offset: pagination offset for the mapped, unmapped and duplicates lists
+// $scope: optional location prefixes (project/folder scope), null for all projects
+//
+// - mapped: Metrics/Attributes whose pb_semantic_model is the model
+// - unmapped: Metrics/Attributes used by prioritized reports assigned to the model
+//   (report.pb_semantic_model) that are not mapped into that model; traversal follows
+//   outgoing DEPENDS_ON through [Prompt, Filter] intermediates, like the upstream traces
+// - duplicates: pb_semantic_name values mapped from more than one object in the model
+
+MATCH (o:MSTRObject)
+WHERE o.pb_semantic_model = $model
+  AND ($scope IS NULL OR any(p IN $scope WHERE replace(toLower(o.location), '\\', '/') + '/' STARTS WITH p))
+WITH DISTINCT o.pb_semantic_model as model
+
+// Mapped objects
+CALL {
+  WITH model
+  MATCH (m:MSTRObject)
+  WHERE m.type IN ['Metric', 'Attribute']
+    AND m.pb_semantic_model = model
+    AND ($scope IS NULL OR any(p IN $scope WHERE replace(toLower(m.location), '\\', '/') + '/' STARTS WITH p))
+  WITH m ORDER BY m.name ASC
+  RETURN collect({
+    guid: m.guid,
+    name: m.name,
+    type: m.type,
+    semanticName: m.pb_semantic_name,
+    status: COALESCE(m.updated_parity_status, m.parity_status, 'No Status')
+  }) as mapped
+}
+
+// Prioritized reports assigned to the model
+CALL {
+  WITH model
+  MATCH (r:MSTRObject)
+  WHERE r.type IN ['Report', 'GridReport', 'Document']
+    AND r.priority_level IS NOT NULL
+    AND r.pb_semantic_model = model
+    AND ($scope IS NULL OR any(p IN $scope WHERE replace(toLower(r.location), '\\', '/') + '/' STARTS WITH p))
+  RETURN count(r) as reportCount
+}
+
+// Dependencies of the model's prioritized reports that are not mapped into the model
+CALL {
+  WITH model
+  MATCH (r:MSTRObject)
+  WHERE r.type IN ['Report', 'GridReport', 'Document']
+    AND r.priority_level IS NOT NULL
+    AND r.pb_semantic_model = model
+    AND ($scope IS NULL OR any(p IN $scope WHERE replace(toLower(r.location), '\\', '/') + '/' STARTS WITH p))
+  MATCH path = (r)-[:DEPENDS_ON*1..10]->(dep)
+  WHERE dep.type IN ['Metric', 'Attribute']
+    AND COALESCE(dep.pb_semantic_model, '') <> model
+    AND ALL(mid IN nodes(path)[1..-1] WHERE mid.type IN ['Prompt', 'Filter'])
+  WITH dep, count(DISTINCT r) as usedByReports
+  ORDER BY usedByReports DESC, dep.name ASC
+  RETURN collect({
+    guid: dep.guid,
+    name: dep.name,
+    type: dep.type,
+    status: COALESCE(dep.updated_parity_status, dep.parity_status, 'No Status'),
+    mappedInModel: dep.pb_semantic_model,
+    usedByReports: usedByReports
+  }) as unmapped
+}
+
+// Semantic names mapped more than once within the model
+CALL {
+  WITH model
+  MATCH (m:MSTRObject)
+  WHERE m.type IN ['Metric', 'Attribute']
+    AND m.pb_semantic_model = model
+    AND ($scope IS NULL OR any(p IN $scope WHERE replace(toLower(m.location), '\\', '/') + '/' STARTS WITH p))
+    AND m.pb_semantic_name IS NOT NULL
+  WITH m.pb_semantic_name as semanticName, collect({guid: m.guid, name: m.name, type: m.type}) as objects
+  WHERE size(objects) > 1
+  WITH semanticName, objects ORDER BY semanticName ASC
+  RETURN collect({semanticName: semanticName, objects: objects}) as duplicates
+}
+
+WITH collect({
+  model: model,
+  reportCount: reportCount,
+  mappedMetrics: size([m IN mapped WHERE m.type = 'Metric']),
+  mappedAttributes: size([m IN mapped WHERE m.type = 'Attribute']),
+  unmappedCount: size(unmapped),
+  duplicateNameCount: size(duplicates),
+  mapped: mapped,
+  unmapped: unmapped,
+  duplicates: duplicates
+}) as fetched
+
+// Lists paginated by $offset
+RETURN {
+  models: [f IN fetched | f {
+    .model, .reportCount, .mappedMetrics, .mappedAttributes, .unmappedCount, .duplicateNameCount,
+    mapped: f.mapped[$offset..$offset + 100],
+    unmapped: f.unmapped[$offset..$offset + 100],
+    duplicates: f.duplicates[$offset..$offset + 100]
+  }],
+  moreResults: any(f IN fetched WHERE size(f.mapped) > $offset + 100 OR size(f.unmapped) > $offset + 100 OR size(f.duplicates) > $offset + 100)
+} as result
+`
+
+// SemanticModelCoverageSpec returns the MCP tool definition for semantic-model-coverage
+func SemanticModelCoverageSpec() mcp.Tool {
+	return mcp.NewTool("semantic-model-coverage",
+		mcp.WithDescription(
+			"Report Power BI semantic model coverage: mapped Metrics/Attributes, dependencies of the model's PRIORITIZED reports "+
+				"that are still unmapped, and semantic names mapped more than once.\n\n"+
+				"MODES:\n"+
+				"- Without model: one summary row per semantic model (counts only)\n"+
+				"- With model: mapped, unmapped and duplicate lists for that model\n\n"+
+				"USE FOR:\n"+
+				"- Tracking a model owner's backlog: semantic-model-coverage(model=\"Retail Sales\")\n"+
+				"- Comparing coverage across models: semantic-model-coverage()\n\n"+
+				"DO NOT USE FOR:\n"+
+				"- Mapping details of a single object (use search-metrics/search-attributes instead)\n\n"+
+				"NOTE: Reports are assigned to a model through their pb_semantic_model property. Unmapped objects are sorted by how many of the model's reports use them.\n\n"+
+				"PAGINATION: Returns 100 models (summary) or 100 items per list (detail). If moreResults=true, call again with cursor=nextCursor (or offset+100).",
+		),
+		mcp.WithInputSchema[SemanticModelCoverageInput](),
+		mcp.WithOutputSchema[SemanticModelCoverageOutput](),
+		mcp.WithTitleAnnotation("Power BI semantic model coverage"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(true),
+	)
+}
+
+// SemanticModelCoverageHandler returns the handler function for the semantic-model-coverage tool
+func SemanticModelCoverageHandler(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleSemanticModelCoverage(ctx, deps, request)
+	}
+}
+
+func handleSemanticModelCoverage(ctx context.Context, deps *tools.ToolDependencies, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if deps.DBService == nil {
+		errMessage := "Database service is not initialized"
+		slog.ErrorContext(ctx, errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	// Parse input
+	var input SemanticModelCoverageInput
+	if err := request.BindArguments(&input); err != nil {
+		slog.ErrorContext(ctx, "error binding arguments", "error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid input: %v", err)), nil
+	}
+
+	params := map[string]any{
+		"offset": input.Offset,
+		"scope":  scopeParam(deps, input.Scope),
+	}
+
+	// Handle model filter - nil if empty (summary mode), otherwise the model name
+	if input.Model != "" {
+		params["model"] = input.Model
+	} else {
+		params["model"] = nil
+	}
+
+	// Models have no GUID and the detail lists are paged together: pages by position
+	pages, err := newOffsetPager("semantic-model-coverage", params, input.Cursor)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	slog.InfoContext(ctx, "executing semantic-model-coverage query", "model", input.Model, "offset", params["offset"])
+
+	query := semanticModelCoverageQuery
+	if input.Model == "" {
+		query = semanticModelSummaryQuery
+	}
+
+	reportProgress(ctx, stageTraversing)
+	records, err := deps.DBService.ExecuteReadQuery(ctx, query, params)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to execute semantic-model-coverage query", "error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Query execution failed: %v", err)), nil
+	}
+
+	if input.Model == "" {
+		pages.attachNextCursor(ctx, records, "result", "models")
+	} else if len(records) == 1 {
+		result, _ := records[0].Get("result")
+		detail, _ := result.(map[string]any)
+		more, _ := detail["moreResults"].(bool)
+		if cursor := pages.nextCursor(ctx, more); cursor != "" {
+			records[0].Keys = append(records[0].Keys, "nextCursor")
+			records[0].Values = append(records[0].Values, cursor)
+		}
+	}
+
+	reportProgress(ctx, stageFormatting)
+	response, err := deps.DBService.Neo4jRecordsToJSON(records)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to format semantic-model-coverage results to JSON", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(response), nil
+}
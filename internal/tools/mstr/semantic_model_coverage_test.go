@@ -0,0 +1,48 @@
+package mstr_test
+
+import (
+	"testing"
+
+	db "github.com/brunogc-cit/flow-microstrategy-mcp/internal/database/mocks"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools/mstr"
+	"github.com/neo4j/neo4j-go-driver/v6/neo4j"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSemanticModelCoverageHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("summary mode without model", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Regex(`counts per model, no lists`), gomock.Eq(map[string]any{"model": nil, "offset": 0, "scope": nil})).
+			Return([]*neo4j.Record{}, nil)
+		mockDB.EXPECT().
+			Neo4jRecordsToJSON(gomock.Any()).
+			Return("[]", nil)
+
+		result := callTool(t, mstr.SemanticModelCoverageHandler(&tools.ToolDependencies{DBService: mockDB}), map[string]any{})
+		assert.False(t, result.IsError)
+	})
+
+	t.Run("detail mode with model", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Not(gomock.Regex(`counts per model`)), gomock.Eq(map[string]any{"model": "Retail Sales", "offset": 100, "scope": nil})).
+			Return([]*neo4j.Record{}, nil)
+		mockDB.EXPECT().
+			Neo4jRecordsToJSON(gomock.Any()).
+			Return("[]", nil)
+
+		result := callTool(t, mstr.SemanticModelCoverageHandler(&tools.ToolDependencies{DBService: mockDB}), map[string]any{"model": "Retail Sales", "offset": 100, "scope": nil})
+		assert.False(t, result.IsError)
+	})
+
+	t.Run("nil database service", func(t *testing.T) {
+		result := callTool(t, mstr.SemanticModelCoverageHandler(&tools.ToolDependencies{}), map[string]any{})
+		assert.True(t, result.IsError)
+	})
+}
@@ -0,0 +1,58 @@
+package mstr
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Sort orders accepted by the sortBy parameter
+const (
+	sortByName        = "name"
+	sortByReportCount = "reportCount"
+	sortByTableCount  = "tableCount"
+	sortByPriority    = "priority"
+	sortByStatus      = "status"
+	sortByArea        = "area"
+)
+
+// searchSortByOptions are the sortBy values of search-metrics and search-attributes
+var searchSortByOptions = []string{sortByName, sortByReportCount, sortByTableCount, sortByPriority, sortByStatus}
+
+// upstreamSortByOptions are the sortBy values of upstream traces (reports)
+var upstreamSortByOptions = []string{sortByName, sortByPriority, sortByArea}
+
+// searchSortKey computes the primary sort key of a search result (n) for $sortBy, ascending:
+// most used / most sourced first, highest priority (lowest level) first, and outstanding
+// migration work first for status. Name and relevance orders use a constant key.
+const searchSortKey = `
+     CASE $sortBy
+       WHEN 'reportCount' THEN -COALESCE(n.lineage_used_by_reports_count, 0)
+       WHEN 'tableCount' THEN -COALESCE(n.lineage_source_tables_count, 0)
+       WHEN 'priority' THEN COALESCE(n.inherited_priority_level, 2147483647)
+       WHEN 'status' THEN
+         CASE COALESCE(n.updated_parity_status, n.parity_status, 'No Status')
+           WHEN 'No Status' THEN 0
+           WHEN 'Not Planned' THEN 1
+           WHEN 'Planned' THEN 2
+           WHEN 'Complete' THEN 3
+           ELSE 4
+         END
+       ELSE 0
+     END as sortKey`
+
+// validateSortBy checks a sortBy input against the options of a tool ("" is the default order)
+func validateSortBy(sortBy string, options []string) error {
+	if sortBy == "" || slices.Contains(options, sortBy) {
+		return nil
+	}
+	return fmt.Errorf("invalid sortBy %q: expected one of %s", sortBy, strings.Join(options, ", "))
+}
+
+// sortByParam returns the $sortBy query parameter: nil for the default order
+func sortByParam(sortBy string) any {
+	if sortBy == "" {
+		return nil
+	}
+	return sortBy
+}
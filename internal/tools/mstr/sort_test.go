@@ -0,0 +1,105 @@
+package mstr_test
+
+import (
+	"context"
+	"testing"
+
+	db "github.com/brunogc-cit/flow-microstrategy-mcp/internal/database/mocks"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools/mstr"
+	"github.com/neo4j/neo4j-go-driver/v6/neo4j"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// sortedSearch calls search-metrics with a full page of results and returns the query params and nextCursor
+func sortedSearch(t *testing.T, args map[string]any) (map[string]any, string) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var params map[string]any
+	var cursor string
+	mockDB := db.NewMockService(ctrl)
+	mockDB.EXPECT().
+		ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, query string, p map[string]any) ([]*neo4j.Record, error) {
+			assert.Contains(t, query, "as sortKey")
+			params = p
+			return []*neo4j.Record{{Keys: []string{"results", "moreResults"}, Values: []any{pageItems(100), true}}}, nil
+		})
+	mockDB.EXPECT().
+		Neo4jRecordsToJSON(gomock.Any()).
+		DoAndReturn(func(records []*neo4j.Record) (string, error) {
+			value, _ := records[0].Get("nextCursor")
+			cursor, _ = value.(string)
+			return "[]", nil
+		})
+
+	result := callTool(t, mstr.SearchMetricsHandler(&tools.ToolDependencies{DBService: mockDB}), args)
+	require.False(t, result.IsError, resultText(t, result))
+	return params, cursor
+}
+
+func TestSortBy(t *testing.T) {
+	t.Run("passes the sort order to the search query", func(t *testing.T) {
+		params, _ := sortedSearch(t, map[string]any{"query": "sales", "sortBy": "reportCount"})
+		assert.Equal(t, "reportCount", params["sortBy"])
+	})
+
+	t.Run("defaults to no sort order", func(t *testing.T) {
+		params, _ := sortedSearch(t, map[string]any{"query": "sales"})
+		assert.Nil(t, params["sortBy"])
+	})
+
+	t.Run("cursors continue by offset when not sorted by name", func(t *testing.T) {
+		_, cursor := sortedSearch(t, map[string]any{"query": "sales", "sortBy": "priority"})
+		require.NotEmpty(t, cursor)
+		params, _ := sortedSearch(t, map[string]any{"query": "sales", "sortBy": "priority", "cursor": cursor})
+		assert.Nil(t, params["after"])
+		assert.Equal(t, 100, params["offset"])
+	})
+
+	t.Run("cursors use the name key when sorted by name", func(t *testing.T) {
+		_, cursor := sortedSearch(t, map[string]any{"query": "sales", "sortBy": "name"})
+		params, _ := sortedSearch(t, map[string]any{"query": "sales", "sortBy": "name", "cursor": cursor})
+		assert.Equal(t, map[string]any{"name": "Item 099", "guid": "G099"}, params["after"])
+	})
+
+	t.Run("rejects invalid sort orders", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		deps := &tools.ToolDependencies{DBService: db.NewMockService(ctrl)}
+
+		result := callTool(t, mstr.SearchAttributesHandler(deps), map[string]any{"query": "store", "sortBy": "size"})
+		assert.True(t, result.IsError)
+		assert.Contains(t, resultText(t, result), "invalid sortBy")
+
+		result = callTool(t, mstr.SearchMetricsHandler(deps), map[string]any{"query": "sales", "mode": "fuzzy", "sortBy": "priority"})
+		assert.True(t, result.IsError)
+		assert.Contains(t, resultText(t, result), "not supported with mode=fuzzy")
+
+		result = callTool(t, mstr.TraceMetricHandler(deps), map[string]any{"guid": "ABC", "direction": "downstream", "sortBy": "priority"})
+		assert.True(t, result.IsError)
+		assert.Contains(t, resultText(t, result), "invalid sortBy")
+	})
+
+	t.Run("sorts upstream reports by priority", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, query string, params map[string]any) ([]*neo4j.Record, error) {
+				assert.Contains(t, query, "CASE $sortBy WHEN 'priority' THEN report.priority_level END ASC")
+				assert.Equal(t, "priority", params["sortBy"])
+				return []*neo4j.Record{{Keys: []string{"result"}, Values: []any{map[string]any{"reports": []any{}}}}}, nil
+			})
+		mockDB.EXPECT().Neo4jRecordsToJSON(gomock.Any()).Return("[]", nil)
+
+		result := callTool(t, mstr.TraceAttributeHandler(&tools.ToolDependencies{DBService: mockDB}), map[string]any{"guid": "ABC", "direction": "upstream", "sortBy": "priority"})
+		require.False(t, result.IsError, resultText(t, result))
+	})
+}
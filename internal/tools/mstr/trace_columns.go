@@ -0,0 +1,763 @@
+package mstr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"maps"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/neo4j/neo4j-go-driver/v6/neo4j"
+)
+
+const maxColumnLineageResults = 100
+
+// maxColumnCandidates bounds the Facts/Attributes mentioning a column that the reverse mode parses in Go
+const maxColumnCandidates = 20000
+
+// TraceColumnsInput defines the input parameters for the trace-columns tool
+type TraceColumnsInput struct {
+	GUID   string `json:"guid,omitempty" jsonschema:"description=Full GUID of the Metric to trace down to physical columns (forward mode)"`
+	Name   string `json:"name,omitempty" jsonschema:"description=Metric name when the GUID is not known (forward mode, alternative to guid). Several matches return the candidates to pick from"`
+	Column string `json:"column,omitempty" jsonschema:"description=Physical column name. Returns the metrics reading it (reverse mode)"`
+	Table  string `json:"table,omitempty" jsonschema:"description=Optional physical or logical table name to narrow the reverse mode"`
+	Scope  string `json:"scope,omitempty" jsonschema:"description=Project or folder to search in (e.g. Retail or Retail/Public Objects/Metrics; see list-projects). Defaults to the server scope; * for all projects"`
+	Offset int    `json:"offset,omitempty" jsonschema:"default=0,description=Skip first N results (columns or metrics) for pagination"`
+	Cursor string `json:"cursor,omitempty" jsonschema:"description=nextCursor from the previous page (preferred over offset)"`
+}
+
+// TraceColumnsOutput defines the output of the trace-columns tool: metric, columnCount and columns
+// in forward mode (guid or name), column, table, metricCount and metrics in reverse mode (column),
+// ambiguous when the name matches several metrics
+type TraceColumnsOutput struct {
+	Metric      *MetricDetails  `json:"metric,omitempty"`
+	Column      string          `json:"column,omitempty"`
+	Table       string          `json:"table,omitempty"`
+	Direction   string          `json:"direction,omitempty"`
+	ColumnCount int             `json:"columnCount,omitempty"`
+	Columns     []ColumnLineage `json:"columns,omitempty"`
+	MetricCount int             `json:"metricCount,omitempty"`
+	Metrics     []ColumnReader  `json:"metrics,omitempty"`
+	MoreResults bool            `json:"moreResults,omitempty"`
+	NextCursor  string          `json:"nextCursor,omitempty"`
+	Ambiguous   *AmbiguousName  `json:"ambiguous,omitempty"`
+	Truncated   []TruncatedList `json:"truncated,omitempty"`
+}
+
+// traceColumnsForwardQuery fetches the Facts/Attributes a metric reads, with their expressions and tables.
+// Column references are parsed from expressions_json/forms_json in Go.
+const traceColumnsForwardQuery = `
+// Column-level lineage for a Metric (toward physical columns)
+// $guid: Full GUID of the Metric
+// $scope: optional location prefixes (project/folder scope) of the Facts/Attributes read, null for all projects
+//
+// LIVE TRAVERSAL: Follows outgoing DEPENDS_ON relationships through [Fact, Metric, Attribute, Column]
+// intermediate nodes (same path filter as trace-metric downstream) to the Facts/Attributes read.
+
+MATCH (n:MSTRObject {guid: $guid})
+WHERE n.type IN ['Metric', 'DerivedMetric']
+
+OPTIONAL MATCH path = (n)-[:DEPENDS_ON*1..10]->(via)
+WHERE via.type IN ['Fact', 'Attribute']
+  AND ALL(mid IN nodes(path)[1..-1] WHERE mid.type IN ['Fact', 'Metric', 'Attribute', 'Column'])
+  AND ($scope IS NULL OR any(p IN $scope WHERE replace(toLower(via.location), '\\', '/') + '/' STARTS WITH p))
+
+WITH n, collect(DISTINCT via) as vias
+
+RETURN
+  {
+    type: n.type,
+    guid: n.guid,
+    name: n.name,
+    status: COALESCE(n.updated_parity_status, n.parity_status, 'No Status'),
+    formula: n.formula
+  } as metric,
+  [via IN vias | {
+    guid: via.guid,
+    name: via.name,
+    type: via.type,
+    expressions_json: via.expressions_json,
+    forms_json: via.forms_json,
+    edwTable: COALESCE(via.updated_edw_table, via.edw_table),
+    edwColumn: via.edw_column,
+    tables: [(via)-[:DEPENDS_ON]->(t) WHERE t.type IN ['LogicalTable', 'Table'] | {name: t.name, physicalTable: t.physical_table_name}],
+    columns: [(via)-[:DEPENDS_ON]->(c) WHERE c.type = 'Column' | c.name]
+  }] as sources
+`
+
+// traceColumnsReverseQuery fetches the Facts/Attributes whose definition mentions a column (at most
+// $limit). Candidates are confirmed in Go against the parsed expressions before their metrics are traced.
+const traceColumnsReverseQuery = `
+// Reverse column lineage: which Facts/Attributes may read a physical column?
+// $column: Column name (case-insensitive)
+// $scope: optional location prefixes (project/folder scope) of the Facts/Attributes, null for all projects
+// $limit: maximum number of candidates
+//
+// Candidates: Facts/Attributes whose expressions_json/forms_json/edw_column mention the column,
+// or that depend on a Column node with that name.
+
+WITH toLower($column) as column
+
+MATCH (via:MSTRObject)
+WHERE via.type IN ['Fact', 'Attribute']
+  AND ($scope IS NULL OR any(p IN $scope WHERE replace(toLower(via.location), '\\', '/') + '/' STARTS WITH p))
+  AND (
+    toLower(COALESCE(via.expressions_json, '')) CONTAINS column
+    OR toLower(COALESCE(via.forms_json, '')) CONTAINS column
+    OR toLower(COALESCE(via.edw_column, '')) = column
+    OR column IN [(via)-[:DEPENDS_ON]->(c) WHERE c.type = 'Column' | toLower(c.name)]
+  )
+WITH via
+LIMIT $limit
+
+RETURN
+  {
+    guid: via.guid,
+    name: via.name,
+    type: via.type,
+    expressions_json: via.expressions_json,
+    forms_json: via.forms_json,
+    edwTable: COALESCE(via.updated_edw_table, via.edw_table),
+    edwColumn: via.edw_column,
+    tables: [(via)-[:DEPENDS_ON]->(t) WHERE t.type IN ['LogicalTable', 'Table'] | {name: t.name, physicalTable: t.physical_table_name}],
+    columns: [(via)-[:DEPENDS_ON]->(c) WHERE c.type = 'Column' | c.name]
+  } as source
+`
+
+// traceColumnsReadersQuery fetches the metrics reading the confirmed Facts/Attributes of the reverse mode
+const traceColumnsReadersQuery = `
+// Reverse column lineage: metrics reading the Facts/Attributes confirmed to read the column
+// $guids: GUIDs of the Facts/Attributes
+// $scope: optional location prefixes (project/folder scope) of the metrics, null for all projects
+//
+// Metrics are found through incoming DEPENDS_ON relationships, only passing through
+// [Fact, Metric, Attribute, Column] intermediate nodes (reverse of trace-metric downstream).
+
+MATCH (via:MSTRObject)
+WHERE via.guid IN $guids
+
+MATCH path = (m)-[:DEPENDS_ON*1..10]->(via)
+WHERE m.type IN ['Metric', 'DerivedMetric']
+  AND ($scope IS NULL OR any(p IN $scope WHERE replace(toLower(m.location), '\\', '/') + '/' STARTS WITH p))
+  AND ALL(mid IN nodes(path)[1..-1] WHERE mid.type IN ['Fact', 'Metric', 'Attribute', 'Column'])
+
+RETURN
+  via.guid as guid,
+  collect(DISTINCT {
+    type: m.type,
+    guid: m.guid,
+    name: m.name,
+    status: COALESCE(m.updated_parity_status, m.parity_status, 'No Status'),
+    priority: m.inherited_priority_level
+  }) as metrics
+`
+
+// ColumnLineageVia identifies the Fact or Attribute through which a column is read
+type ColumnLineageVia struct {
+	GUID string `json:"guid,omitempty"`
+	Name string `json:"name,omitempty"`
+	Type string `json:"type,omitempty"`
+}
+
+// ColumnLineage is a (table, column, expression, via-object) tuple
+type ColumnLineage struct {
+	Table        string           `json:"table,omitempty"`
+	LogicalTable string           `json:"logicalTable,omitempty"`
+	Column       string           `json:"column"`
+	Expression   string           `json:"expression,omitempty"`
+	Form         string           `json:"form,omitempty"`
+	Via          ColumnLineageVia `json:"via"`
+}
+
+// ColumnReader is a metric reading a column, with the tuples that connect them
+type ColumnReader struct {
+	GUID     string          `json:"guid,omitempty"`
+	Name     string          `json:"name,omitempty"`
+	Type     string          `json:"type,omitempty"`
+	Status   any             `json:"status,omitempty"`
+	Priority any             `json:"priority,omitempty"`
+	Reads    []ColumnLineage `json:"reads"`
+}
+
+// columnSource is a Fact/Attribute as returned by the trace-columns queries
+type columnSource struct {
+	Via             ColumnLineageVia
+	ExpressionsJSON string
+	FormsJSON       string
+	EDWTable        string
+	EDWColumn       string
+	Tables          []columnSourceTable
+	Columns         []string
+}
+
+type columnSourceTable struct {
+	Name          string
+	PhysicalTable string
+}
+
+// expressionEntry is a single expression parsed from expressions_json/forms_json
+type expressionEntry struct {
+	Expression string
+	Form       string
+	Tables     []string
+}
+
+// TraceColumnsSpec returns the MCP tool definition for trace-columns
+func TraceColumnsSpec() mcp.Tool {
+	return mcp.NewTool("trace-columns",
+		mcp.WithDescription(
+			"Column-level lineage: the physical (table, column, expression, via-object) tuples a Metric reads, "+
+				"combining Fact/Attribute expressions (expressions_json/forms_json) with the table traversal.\n\n"+
+				"MODES:\n"+
+				"- With guid (or name): columns read by the metric, through its Facts/Attributes\n"+
+				"- With column (and optional table): metrics reading that column\n\n"+
+				"USE FOR:\n"+
+				"- Rebuilding a metric in dbt/Power BI from physical columns: trace-columns(guid=\"A1B2C3D4...\")\n"+
+				"- Impact of a column change: trace-columns(column=\"sales_amount\", table=\"fact_sales\")\n\n"+
+				"DO NOT USE FOR:\n"+
+				"- Table-level lineage or report usage (use trace-metric instead)\n\n"+
+				"NOTE: Columns are parsed from object expressions; function names and SQL keywords are ignored. "+
+				"When an expression does not name its table, the tables the Fact/Attribute depends on are used.\n\n"+
+				"NAMES: When name matches several metrics, the user is asked to pick one if the client supports it; "+
+				"otherwise 'ambiguous' lists the candidates: call again with the guid of the intended one.\n\n"+
+				"PAGINATION: Returns 100 results. If moreResults=true, call again with cursor=nextCursor (or offset+100).",
+		),
+		mcp.WithInputSchema[TraceColumnsInput](),
+		mcp.WithOutputSchema[TraceColumnsOutput](),
+		mcp.WithTitleAnnotation("Trace column-level lineage"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(true),
+	)
+}
+
+// TraceColumnsHandler returns the handler function for the trace-columns tool
+func TraceColumnsHandler(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleTraceColumns(ctx, deps, request)
+	}
+}
+
+func handleTraceColumns(ctx context.Context, deps *tools.ToolDependencies, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if deps.DBService == nil {
+		errMessage := "Database service is not initialized"
+		slog.ErrorContext(ctx, errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	// Parse input
+	var input TraceColumnsInput
+	if err := request.BindArguments(&input); err != nil {
+		slog.ErrorContext(ctx, "error binding arguments", "error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid input: %v", err)), nil
+	}
+
+	// Validate mode: exactly one of guid/name (forward) or column (reverse)
+	forward := input.GUID != "" || input.Name != ""
+	if forward == (input.Column != "") || (input.GUID != "" && input.Name != "") {
+		return mcp.NewToolResultError("exactly one of guid, name or column parameters is required"), nil
+	}
+	if forward && input.Table != "" {
+		return mcp.NewToolResultError("table parameter is only supported together with column"), nil
+	}
+
+	params := map[string]any{
+		"guid":   input.GUID,
+		"name":   input.Name,
+		"column": input.Column,
+		"table":  input.Table,
+		"scope":  scopeParam(deps, input.Scope),
+		"offset": input.Offset,
+	}
+	pages, err := newPager("trace-columns", params, input.Cursor)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	offset := params["offset"].(int)
+
+	var response map[string]any
+	var listKey string
+	var more bool
+	if forward {
+		if input.GUID == "" {
+			guid, ambiguous, err := resolveName(ctx, deps, "Metric", input.Name, input.Scope)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if ambiguous != nil {
+				return ambiguousNameResult(ctx, ambiguous), nil
+			}
+			input.GUID = guid
+		}
+
+		slog.InfoContext(ctx, "executing trace-columns forward query", "guid", input.GUID, "offset", offset)
+
+		reportProgress(ctx, stageTraversing)
+		records, err := deps.DBService.ExecuteReadQuery(ctx, traceColumnsForwardQuery, map[string]any{"guid": input.GUID, "scope": scopeParam(deps, input.Scope)})
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to execute trace-columns query", "error", err)
+			return mcp.NewToolResultError(fmt.Sprintf("Query execution failed: %v", err)), nil
+		}
+
+		// Check if metric was found
+		if len(records) == 0 {
+			return mcp.NewToolResultError(fmt.Sprintf("Metric with GUID %s not found", input.GUID)), nil
+		}
+
+		metric, sources, err := processColumnForwardRecord(records[0])
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to process trace-columns results", "error", err)
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		lineage := make([]ColumnLineage, 0)
+		for _, source := range sources {
+			lineage = append(lineage, columnLineageFor(source)...)
+		}
+		sortColumnLineage(lineage)
+		var page []ColumnLineage
+		page, more = paginate(lineage, offset, maxColumnLineageResults)
+		listKey = "columns"
+		response = map[string]any{
+			"metric":      metric,
+			"direction":   "forward",
+			"columnCount": len(lineage),
+			"columns":     page,
+			"moreResults": more,
+		}
+	} else {
+		slog.InfoContext(ctx, "executing trace-columns reverse query", "column", input.Column, "table", input.Table, "offset", offset)
+
+		reportProgress(ctx, stageSearching)
+		records, err := deps.DBService.ExecuteReadQuery(ctx, traceColumnsReverseQuery, map[string]any{
+			"column": input.Column,
+			"scope":  scopeParam(deps, input.Scope),
+			"limit":  maxColumnCandidates + 1,
+		})
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to execute trace-columns query", "error", err)
+			return mcp.NewToolResultError(fmt.Sprintf("Query execution failed: %v", err)), nil
+		}
+		if len(records) > maxColumnCandidates {
+			return mcp.NewToolResultError(fmt.Sprintf("more than %d Facts/Attributes mention column '%s': narrow the search with scope or use a more specific column name", maxColumnCandidates, input.Column)), nil
+		}
+
+		matches, err := matchColumnSources(records, input.Column, input.Table)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to process trace-columns results", "error", err)
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Only the confirmed Facts/Attributes are traced to their metrics
+		readers := make([]ColumnReader, 0)
+		if len(matches) > 0 {
+			reportProgress(ctx, stageTraversing)
+			records, err = deps.DBService.ExecuteReadQuery(ctx, traceColumnsReadersQuery, map[string]any{
+				"guids": slices.Sorted(maps.Keys(matches)),
+				"scope": scopeParam(deps, input.Scope),
+			})
+			if err != nil {
+				slog.ErrorContext(ctx, "failed to execute trace-columns query", "error", err)
+				return mcp.NewToolResultError(fmt.Sprintf("Query execution failed: %v", err)), nil
+			}
+			readers, err = groupColumnReaders(records, matches)
+			if err != nil {
+				slog.ErrorContext(ctx, "failed to process trace-columns results", "error", err)
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+		}
+
+		var page []ColumnReader
+		page, more = paginate(readers, offset, maxColumnLineageResults)
+		listKey = "metrics"
+		response = map[string]any{
+			"column":      input.Column,
+			"table":       input.Table,
+			"direction":   "reverse",
+			"metricCount": len(readers),
+			"metrics":     page,
+			"moreResults": more,
+		}
+	}
+
+	if cursor := pages.nextCursor(ctx, more, listKey); cursor != "" {
+		response["nextCursor"] = cursor
+	}
+
+	reportProgress(ctx, stageFormatting)
+	jsonData, err := json.MarshalIndent(response, "", "  ")
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to format trace-columns results to JSON", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(string(jsonData)), nil
+}
+
+// processColumnForwardRecord converts the forward query record into the metric and its column sources
+func processColumnForwardRecord(record *neo4j.Record) (map[string]any, []columnSource, error) {
+	metric, _, err := neo4j.GetRecordValue[map[string]any](record, "metric")
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid 'metric' column in record: %w", err)
+	}
+	rawSources, _, err := neo4j.GetRecordValue[[]any](record, "sources")
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid 'sources' column in record: %w", err)
+	}
+	sources := make([]columnSource, 0, len(rawSources))
+	for _, raw := range rawSources {
+		if m, ok := raw.(map[string]any); ok {
+			sources = append(sources, toColumnSource(m))
+		}
+	}
+	return metric, sources, nil
+}
+
+// matchColumnSources returns the column tuples of the candidate Facts/Attributes that match column/table,
+// by GUID of the Fact/Attribute. Candidates whose text match is not confirmed by the parsed column
+// references are left out.
+func matchColumnSources(records []*neo4j.Record, column, table string) (map[string][]ColumnLineage, error) {
+	matches := make(map[string][]ColumnLineage)
+	for _, record := range records {
+		rawSource, _, err := neo4j.GetRecordValue[map[string]any](record, "source")
+		if err != nil {
+			return nil, fmt.Errorf("invalid 'source' column in record: %w", err)
+		}
+		source := toColumnSource(rawSource)
+		for _, l := range columnLineageFor(source) {
+			if strings.EqualFold(l.Column, column) && (table == "" || strings.EqualFold(l.Table, table) || strings.EqualFold(l.LogicalTable, table)) {
+				matches[source.Via.GUID] = append(matches[source.Via.GUID], l)
+			}
+		}
+	}
+	return matches, nil
+}
+
+// groupColumnReaders groups the matched column tuples by the metrics reading their Fact/Attribute,
+// ordered by metric name.
+func groupColumnReaders(records []*neo4j.Record, matches map[string][]ColumnLineage) ([]ColumnReader, error) {
+	readers := make(map[string]*ColumnReader)
+	for _, record := range records {
+		via, _, err := neo4j.GetRecordValue[string](record, "guid")
+		if err != nil {
+			return nil, fmt.Errorf("invalid 'guid' column in record: %w", err)
+		}
+		rawMetrics, _, err := neo4j.GetRecordValue[[]any](record, "metrics")
+		if err != nil {
+			return nil, fmt.Errorf("invalid 'metrics' column in record: %w", err)
+		}
+
+		for _, raw := range rawMetrics {
+			m, ok := raw.(map[string]any)
+			if !ok {
+				continue
+			}
+			guid := stringValue(m["guid"])
+			reader, ok := readers[guid]
+			if !ok {
+				reader = &ColumnReader{
+					GUID:     guid,
+					Name:     stringValue(m["name"]),
+					Type:     stringValue(m["type"]),
+					Status:   m["status"],
+					Priority: m["priority"],
+				}
+				readers[guid] = reader
+			}
+			reader.Reads = append(reader.Reads, matches[via]...)
+		}
+	}
+
+	result := make([]ColumnReader, 0, len(readers))
+	for _, r := range readers {
+		sortColumnLineage(r.Reads)
+		result = append(result, *r)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Name != result[j].Name {
+			return result[i].Name < result[j].Name
+		}
+		return result[i].GUID < result[j].GUID
+	})
+	return result, nil
+}
+
+// toColumnSource converts a source map returned by the trace-columns queries
+func toColumnSource(m map[string]any) columnSource {
+	source := columnSource{
+		Via: ColumnLineageVia{
+			GUID: stringValue(m["guid"]),
+			Name: stringValue(m["name"]),
+			Type: stringValue(m["type"]),
+		},
+		ExpressionsJSON: stringValue(m["expressions_json"]),
+		FormsJSON:       stringValue(m["forms_json"]),
+		EDWTable:        stringValue(m["edwTable"]),
+		EDWColumn:       stringValue(m["edwColumn"]),
+	}
+	if tables, ok := m["tables"].([]any); ok {
+		for _, t := range tables {
+			if tm, ok := t.(map[string]any); ok {
+				source.Tables = append(source.Tables, columnSourceTable{
+					Name:          stringValue(tm["name"]),
+					PhysicalTable: stringValue(tm["physicalTable"]),
+				})
+			}
+		}
+	}
+	if columns, ok := m["columns"].([]any); ok {
+		for _, c := range columns {
+			if s := stringValue(c); s != "" {
+				source.Columns = append(source.Columns, s)
+			}
+		}
+	}
+	return source
+}
+
+// columnLineageFor returns the de-duplicated column tuples of a Fact/Attribute.
+// Columns come from the parsed expressions, then from Column nodes and edw_column.
+// Expressions without an explicit table are attributed to the tables the source depends on.
+func columnLineageFor(source columnSource) []ColumnLineage {
+	seen := make(map[string]struct{})
+	lineage := make([]ColumnLineage, 0)
+	add := func(l ColumnLineage) {
+		key := strings.ToLower(l.Table + "\x00" + l.LogicalTable + "\x00" + l.Column + "\x00" + l.Form + "\x00" + l.Expression)
+		if _, ok := seen[key]; ok {
+			return
+		}
+		seen[key] = struct{}{}
+		lineage = append(lineage, l)
+	}
+
+	// Tables used when an expression does not name its own
+	defaultTables := source.Tables
+	if len(defaultTables) == 0 && source.EDWTable != "" {
+		defaultTables = []columnSourceTable{{PhysicalTable: source.EDWTable}}
+	}
+	if len(defaultTables) == 0 {
+		defaultTables = []columnSourceTable{{}}
+	}
+
+	entries := parseExpressionEntries(source.ExpressionsJSON)
+	entries = append(entries, parseExpressionEntries(source.FormsJSON)...)
+	for _, entry := range entries {
+		for _, ref := range extractColumnReferences(entry.Expression) {
+			tables := defaultTables
+			switch {
+			case ref.table != "":
+				tables = []columnSourceTable{{PhysicalTable: ref.table}}
+			case len(entry.Tables) > 0:
+				tables = resolveEntryTables(entry.Tables, source.Tables)
+			}
+			for _, t := range tables {
+				add(ColumnLineage{
+					Table:        t.PhysicalTable,
+					LogicalTable: t.Name,
+					Column:       ref.column,
+					Expression:   entry.Expression,
+					Form:         entry.Form,
+					Via:          source.Via,
+				})
+			}
+		}
+	}
+
+	// Fall back to Column nodes and edw_column when expressions do not cover them
+	covered := make(map[string]struct{}, len(lineage))
+	for _, l := range lineage {
+		covered[strings.ToLower(l.Column)] = struct{}{}
+	}
+	extra := append([]string{}, source.Columns...)
+	if source.EDWColumn != "" {
+		extra = append(extra, source.EDWColumn)
+	}
+	for _, column := range extra {
+		if _, ok := covered[strings.ToLower(column)]; ok {
+			continue
+		}
+		for _, t := range defaultTables {
+			add(ColumnLineage{
+				Table:        t.PhysicalTable,
+				LogicalTable: t.Name,
+				Column:       column,
+				Via:          source.Via,
+			})
+		}
+	}
+
+	for i := range lineage {
+		if lineage[i].Table == "" {
+			lineage[i].Table, lineage[i].LogicalTable = lineage[i].LogicalTable, ""
+		}
+	}
+	return lineage
+}
+
+// resolveEntryTables maps table names listed in an expression to the source's tables,
+// keeping names that do not match any of them as physical tables.
+func resolveEntryTables(names []string, known []columnSourceTable) []columnSourceTable {
+	tables := make([]columnSourceTable, 0, len(names))
+	for _, name := range names {
+		resolved := columnSourceTable{PhysicalTable: name}
+		for _, t := range known {
+			if strings.EqualFold(t.Name, name) || strings.EqualFold(t.PhysicalTable, name) {
+				resolved = t
+				break
+			}
+		}
+		tables = append(tables, resolved)
+	}
+	return tables
+}
+
+// parseExpressionEntries extracts expressions from expressions_json/forms_json.
+// Both properties are free-form JSON exported from MicroStrategy: a list of expression objects
+// ({"expression": ..., "tables": [...]}), a form-to-column map ({"ID": "product_id"}), or nested
+// combinations of both. Invalid JSON is treated as a single plain expression.
+func parseExpressionEntries(raw string) []expressionEntry {
+	if strings.TrimSpace(raw) == "" {
+		return nil
+	}
+	var value any
+	if err := json.Unmarshal([]byte(raw), &value); err != nil {
+		return []expressionEntry{{Expression: raw}}
+	}
+	return collectExpressionEntries(value, "")
+}
+
+func collectExpressionEntries(value any, form string) []expressionEntry {
+	switch v := value.(type) {
+	case string:
+		if strings.TrimSpace(v) == "" {
+			return nil
+		}
+		return []expressionEntry{{Expression: v, Form: form}}
+	case []any:
+		entries := make([]expressionEntry, 0, len(v))
+		for _, item := range v {
+			entries = append(entries, collectExpressionEntries(item, form)...)
+		}
+		return entries
+	case map[string]any:
+		for _, key := range []string{"expression", "formula", "text", "column"} {
+			if expr, ok := v[key].(string); ok && strings.TrimSpace(expr) != "" {
+				entry := expressionEntry{Expression: expr, Form: form, Tables: stringList(v["tables"])}
+				if len(entry.Tables) == 0 {
+					entry.Tables = stringList(v["table"])
+				}
+				if f, ok := v["form"].(string); ok {
+					entry.Form = f
+				}
+				return []expressionEntry{entry}
+			}
+		}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		entries := make([]expressionEntry, 0)
+		for _, key := range keys {
+			childForm := form
+			if key != "expressions" && key != "forms" {
+				childForm = key
+			}
+			entries = append(entries, collectExpressionEntries(v[key], childForm)...)
+		}
+		return entries
+	}
+	return nil
+}
+
+// stringList converts a JSON string, list of strings or list of {"name": ...} objects into names
+func stringList(value any) []string {
+	switch v := value.(type) {
+	case string:
+		if v != "" {
+			return []string{v}
+		}
+	case []any:
+		names := make([]string, 0, len(v))
+		for _, item := range v {
+			switch i := item.(type) {
+			case string:
+				names = append(names, i)
+			case map[string]any:
+				if name := stringValue(i["name"]); name != "" {
+					names = append(names, name)
+				}
+			}
+		}
+		return names
+	}
+	return nil
+}
+
+var (
+	// expressionLiteralPattern matches quoted literals, removed before looking for column references
+	expressionLiteralPattern = regexp.MustCompile(`'[^']*'|"[^"]*"`)
+	// expressionIdentifierPattern matches optionally table-qualified identifiers and a following "(" for calls
+	expressionIdentifierPattern = regexp.MustCompile(`([A-Za-z_][A-Za-z0-9_]*\.)?([A-Za-z_][A-Za-z0-9_#$]*)(\s*\()?`)
+	// expressionKeywords are SQL/MicroStrategy keywords that are never column names
+	expressionKeywords = map[string]struct{}{
+		"and": {}, "or": {}, "not": {}, "null": {}, "is": {}, "in": {}, "as": {}, "case": {}, "when": {},
+		"then": {}, "else": {}, "end": {}, "between": {}, "like": {}, "distinct": {}, "true": {}, "false": {},
+	}
+)
+
+type columnReference struct {
+	table  string
+	column string
+}
+
+// extractColumnReferences returns the column references of an expression, skipping literals,
+// function calls and keywords. "table.column" references keep their table.
+func extractColumnReferences(expression string) []columnReference {
+	stripped := expressionLiteralPattern.ReplaceAllString(expression, " ")
+	refs := make([]columnReference, 0)
+	seen := make(map[string]struct{})
+	for _, match := range expressionIdentifierPattern.FindAllStringSubmatch(stripped, -1) {
+		if match[3] != "" {
+			continue // function call
+		}
+		column := match[2]
+		if _, ok := expressionKeywords[strings.ToLower(column)]; ok {
+			continue
+		}
+		ref := columnReference{table: strings.TrimSuffix(match[1], "."), column: column}
+		key := strings.ToLower(ref.table + "." + ref.column)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		refs = append(refs, ref)
+	}
+	return refs
+}
+
+// sortColumnLineage orders tuples by table, column and via-object name
+func sortColumnLineage(lineage []ColumnLineage) {
+	sort.SliceStable(lineage, func(i, j int) bool {
+		if lineage[i].Table != lineage[j].Table {
+			return lineage[i].Table < lineage[j].Table
+		}
+		if lineage[i].Column != lineage[j].Column {
+			return lineage[i].Column < lineage[j].Column
+		}
+		return lineage[i].Via.Name < lineage[j].Via.Name
+	})
+}
+
+// stringValue returns v as a string, or "" when it is not one
+func stringValue(v any) string {
+	s, _ := v.(string)
+	return s
+}
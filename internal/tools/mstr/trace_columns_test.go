@@ -0,0 +1,173 @@
+package mstr_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	db "github.com/brunogc-cit/flow-microstrategy-mcp/internal/database/mocks"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools/mstr"
+	"github.com/neo4j/neo4j-go-driver/v6/neo4j"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func salesFactSource() map[string]any {
+	return map[string]any{
+		"guid":             "F1",
+		"name":             "Sales Amount",
+		"type":             "Fact",
+		"expressions_json": `[{"expression": "Sum(sales_amount * fx_rate)", "tables": ["LU_SALES"]}, {"expression": "fact_returns.return_amount"}]`,
+		"forms_json":       nil,
+		"edwTable":         nil,
+		"edwColumn":        nil,
+		"tables":           []any{map[string]any{"name": "LU_SALES", "physicalTable": "dbo.fact_sales"}},
+		"columns":          []any{},
+	}
+}
+
+func TestTraceColumnsHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("forward mode parses expressions into column tuples", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Eq(map[string]any{"guid": "M1", "scope": nil})).
+			Return([]*neo4j.Record{{
+				Keys: []string{"metric", "sources"},
+				Values: []any{
+					map[string]any{"guid": "M1", "name": "Net Sales", "type": "Metric"},
+					[]any{
+						salesFactSource(),
+						map[string]any{
+							"guid":       "A1",
+							"name":       "Product",
+							"type":       "Attribute",
+							"forms_json": `{"ID": "product_id", "DESC": "product_desc"}`,
+							"tables":     []any{map[string]any{"name": "LU_PRODUCT", "physicalTable": "dbo.dim_product"}},
+						},
+					},
+				},
+			}}, nil)
+
+		result := callTool(t, mstr.TraceColumnsHandler(&tools.ToolDependencies{DBService: mockDB}), map[string]any{"guid": "M1"})
+		require.False(t, result.IsError, resultText(t, result))
+
+		var response struct {
+			Columns     []mstr.ColumnLineage `json:"columns"`
+			MoreResults bool                 `json:"moreResults"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(resultText(t, result)), &response))
+
+		got := make([]string, 0, len(response.Columns))
+		for _, c := range response.Columns {
+			got = append(got, c.Table+"."+c.Column+"@"+c.Via.Name)
+		}
+		assert.Equal(t, []string{
+			"dbo.dim_product.product_desc@Product",
+			"dbo.dim_product.product_id@Product",
+			"dbo.fact_sales.fx_rate@Sales Amount",
+			"dbo.fact_sales.sales_amount@Sales Amount",
+			"fact_returns.return_amount@Sales Amount",
+		}, got)
+		assert.Equal(t, "LU_SALES", response.Columns[2].LogicalTable)
+		assert.False(t, response.MoreResults)
+	})
+
+	t.Run("reverse mode returns metrics reading the column", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Regex(`LIMIT \$limit`), gomock.Eq(map[string]any{"column": "SALES_AMOUNT", "scope": nil, "limit": 20001})).
+			Return([]*neo4j.Record{{Keys: []string{"source"}, Values: []any{salesFactSource()}}}, nil)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Regex(`via.guid IN \$guids`), gomock.Eq(map[string]any{"guids": []string{"F1"}, "scope": nil})).
+			Return([]*neo4j.Record{{
+				Keys: []string{"guid", "metrics"},
+				Values: []any{"F1", []any{
+					map[string]any{"guid": "M2", "name": "Revenue", "type": "Metric"},
+					map[string]any{"guid": "M1", "name": "Net Sales", "type": "Metric"},
+				}},
+			}}, nil)
+
+		result := callTool(t, mstr.TraceColumnsHandler(&tools.ToolDependencies{DBService: mockDB}), map[string]any{"column": "SALES_AMOUNT", "table": "lu_sales"})
+		require.False(t, result.IsError, resultText(t, result))
+
+		var response struct {
+			Metrics []mstr.ColumnReader `json:"metrics"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(resultText(t, result)), &response))
+		require.Len(t, response.Metrics, 2)
+		assert.Equal(t, "Net Sales", response.Metrics[0].Name)
+		require.Len(t, response.Metrics[0].Reads, 1)
+		assert.Equal(t, "sales_amount", response.Metrics[0].Reads[0].Column)
+	})
+
+	t.Run("reverse mode ignores substring-only matches", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		// Only the candidates query runs: no Fact/Attribute is confirmed, so none is traced
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return([]*neo4j.Record{{Keys: []string{"source"}, Values: []any{salesFactSource()}}}, nil)
+
+		result := callTool(t, mstr.TraceColumnsHandler(&tools.ToolDependencies{DBService: mockDB}), map[string]any{"column": "amount", "scope": nil})
+		require.False(t, result.IsError)
+		assert.Contains(t, resultText(t, result), `"metricCount": 0`)
+	})
+
+	t.Run("reverse mode scopes the candidates", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Regex(`via.type IN \['Fact', 'Attribute'\]\s+AND \(\$scope IS NULL OR any\(p IN \$scope WHERE replace\(toLower\(via.location\)`),
+				gomock.Eq(map[string]any{"column": "sales_amount", "scope": []string{"/retail/", "retail/"}, "limit": 20001})).
+			Return([]*neo4j.Record{}, nil)
+
+		result := callTool(t, mstr.TraceColumnsHandler(&tools.ToolDependencies{DBService: mockDB}), map[string]any{"column": "sales_amount", "scope": "Retail"})
+		require.False(t, result.IsError, resultText(t, result))
+	})
+
+	t.Run("reverse mode rejects too many candidates", func(t *testing.T) {
+		records := make([]*neo4j.Record, 20001)
+		for i := range records {
+			records[i] = &neo4j.Record{Keys: []string{"source"}, Values: []any{salesFactSource()}}
+		}
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(records, nil)
+
+		result := callTool(t, mstr.TraceColumnsHandler(&tools.ToolDependencies{DBService: mockDB}), map[string]any{"column": "id"})
+		assert.True(t, result.IsError)
+		assert.Contains(t, resultText(t, result), "narrow the search with scope")
+	})
+
+	t.Run("metric not found", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return([]*neo4j.Record{}, nil)
+
+		result := callTool(t, mstr.TraceColumnsHandler(&tools.ToolDependencies{DBService: mockDB}), map[string]any{"guid": "M1"})
+		assert.True(t, result.IsError)
+	})
+
+	t.Run("requires exactly one of guid or column", func(t *testing.T) {
+		handler := mstr.TraceColumnsHandler(&tools.ToolDependencies{DBService: db.NewMockService(ctrl)})
+		assert.True(t, callTool(t, handler, map[string]any{}).IsError)
+		assert.True(t, callTool(t, handler, map[string]any{"guid": "M1", "column": "x"}).IsError)
+	})
+
+	t.Run("forward mode scopes the facts and attributes read", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Regex(`\$scope IS NULL OR`), gomock.Eq(map[string]any{"guid": "M1", "scope": []string{"/retail/", "retail/"}})).
+			Return([]*neo4j.Record{{
+				Keys:   []string{"metric", "sources"},
+				Values: []any{map[string]any{"guid": "M1", "name": "Net Sales", "type": "Metric"}, []any{}},
+			}}, nil)
+
+		result := callTool(t, mstr.TraceColumnsHandler(&tools.ToolDependencies{DBService: mockDB, DefaultScope: "Retail"}), map[string]any{"guid": "M1"})
+		require.False(t, result.IsError, resultText(t, result))
+	})
+}
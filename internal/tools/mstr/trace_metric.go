@@ -11,9 +11,40 @@ import (
 
 // TraceMetricInput defines the input parameters for the trace-metric tool
 type TraceMetricInput struct {
-	GUID      string `json:"guid" jsonschema:"required,description=Full GUID of the Metric to trace"`
+	GUID      string `json:"guid,omitempty" jsonschema:"description=Full GUID of the Metric to trace"`
+	Name      string `json:"name,omitempty" jsonschema:"description=Metric name when the GUID is not known (alternative to guid). Several matches return the candidates to pick from"`
 	Direction string `json:"direction" jsonschema:"required,enum=upstream,enum=downstream,description=Trace direction: 'upstream' (toward reports - who uses this?) or 'downstream' (toward tables - where does data come from?)"`
+	SortBy    string `json:"sortBy,omitempty" jsonschema:"enum=name,enum=priority,enum=area,description=Upstream report order: name (A-Z, default), priority (highest first) or area (usage area A-Z). Downstream results are sorted by name"`
+	Scope     string `json:"scope,omitempty" jsonschema:"description=Project or folder to search in (e.g. Retail or Retail/Public Objects/Metrics; see list-projects). Defaults to the server scope; * for all projects"`
 	Offset    int    `json:"offset,omitempty" jsonschema:"default=0,description=Skip first N results for pagination"`
+	Cursor    string `json:"cursor,omitempty" jsonschema:"description=nextCursor from the previous page (preferred over offset: stable and cheap for deep pages)"`
+}
+
+// MetricDependency is a direct (1-2 hop) dependency of a Metric
+type MetricDependency struct {
+	Type    string `json:"type,omitempty"`
+	GUID    string `json:"guid,omitempty"`
+	Name    string `json:"name,omitempty"`
+	Formula string `json:"formula,omitempty"`
+}
+
+// TraceMetricResult is the lineage of a Metric: reports (upstream) or tables and dependencies (downstream)
+type TraceMetricResult struct {
+	Metric       MetricDetails      `json:"metric"`
+	Direction    string             `json:"direction"`
+	Reports      []ReportRef        `json:"reports,omitempty"`
+	Tables       []TableRef         `json:"tables,omitempty"`
+	Dependencies []MetricDependency `json:"dependencies,omitempty"`
+	MoreResults  bool               `json:"moreResults"`
+}
+
+// TraceMetricOutput defines the output of the trace-metric tool: result, or ambiguous when the name
+// matches several metrics
+type TraceMetricOutput struct {
+	Result     TraceMetricResult `json:"result,omitempty"`
+	NextCursor string            `json:"nextCursor,omitempty"`
+	Ambiguous  *AmbiguousName    `json:"ambiguous,omitempty"`
+	Truncated  []TruncatedList   `json:"truncated,omitempty"`
 }
 
 // traceMetricUpstreamQuery traces upstream lineage (toward reports - who uses this metric?)
@@ -22,6 +53,9 @@ const traceMetricUpstreamQuery = `
 // Trace UPSTREAM lineage for a Metric (toward reports)
 // $guid: Full GUID of the Metric
 // $offset: Pagination offset
+// $sortBy: optional report order (name, priority, area)
+// $scope: optional location prefixes (project/folder scope), null for all projects
+// $after: {name, guid} of the last item of the previous page (cursor), or null
 //
 // LIVE TRAVERSAL: Follows incoming DEPENDS_ON relationships to find consumers.
 // Traverses up to 10 hops to find Reports/GridReports/Documents that use this metric.
@@ -39,10 +73,15 @@ OPTIONAL MATCH path = (report)-[:DEPENDS_ON*1..10]->(n)
 WHERE report.type IN ['Report', 'GridReport', 'Document']
   AND report.priority_level IS NOT NULL
   AND ALL(mid IN nodes(path)[1..-1] WHERE mid.type IN ['Prompt', 'Filter'])
+  AND ($scope IS NULL OR any(p IN $scope WHERE replace(toLower(report.location), '\\', '/') + '/' STARTS WITH p))
+  AND ($after IS NULL OR report.name > $after.name OR (report.name = $after.name AND report.guid > $after.guid))  // keyset pagination after a cursor
 
-WITH n, effectiveStatus, report
-ORDER BY report.name ASC
-SKIP $offset
+WITH DISTINCT n, effectiveStatus, report
+ORDER BY
+  CASE $sortBy WHEN 'priority' THEN report.priority_level END ASC,
+  CASE $sortBy WHEN 'area' THEN report.usage_area END ASC,
+  report.name ASC, report.guid ASC
+SKIP CASE WHEN $after IS NULL THEN $offset ELSE 0 END
 LIMIT 101
 
 // Collect paginated reports (filter out null results from OPTIONAL MATCH)
@@ -89,6 +128,8 @@ const traceMetricDownstreamQuery = `
 // Trace DOWNSTREAM lineage for a Metric (toward source tables)
 // $guid: Full GUID of the Metric
 // $offset: Pagination offset
+// $scope: optional location prefixes (project/folder scope) of the tables, null for all projects
+// $after: {name, guid} of the last item of the previous page (cursor), or null
 //
 // LIVE TRAVERSAL: Follows outgoing DEPENDS_ON relationships to find data sources.
 // Tables are reached via Facts; Dependencies are direct DEPENDS_ON targets.
@@ -104,10 +145,12 @@ WITH n,
 OPTIONAL MATCH path = (n)-[:DEPENDS_ON*1..10]->(t)
 WHERE t.type IN ['LogicalTable', 'Table']
   AND ALL(mid IN nodes(path)[1..-1] WHERE mid.type IN ['Fact', 'Metric', 'Attribute', 'Column'])
+  AND ($scope IS NULL OR t.location IS NULL OR any(p IN $scope WHERE replace(toLower(t.location), '\\', '/') + '/' STARTS WITH p))  // tables without location are not scoped
+  AND ($after IS NULL OR t.name > $after.name OR (t.name = $after.name AND t.guid > $after.guid))  // keyset pagination after a cursor
 
-WITH n, effectiveStatus, t
-ORDER BY t.name ASC
-SKIP $offset
+WITH DISTINCT n, effectiveStatus, t
+ORDER BY t.name ASC, t.guid ASC
+SKIP CASE WHEN $after IS NULL THEN $offset ELSE 0 END
 LIMIT 101
 
 // Collect paginated tables (filter out null results from OPTIONAL MATCH)
@@ -173,12 +216,17 @@ func TraceMetricSpec() mcp.Tool {
 				"- Data lineage: where does this metric's data come from? (downstream)\n"+
 				"- Migration planning: understanding metric dependencies before conversion\n\n"+
 				"DO NOT USE FOR:\n"+
-				"- Searching for metrics by name - use search-metrics first to get the GUID\n"+
+				"- Searching for metrics - use search-metrics instead (name only accepts the name of a known metric)\n"+
 				"- Getting metric details without lineage - use search-metrics instead\n\n"+
 				"NOTE: Upstream only returns reports with priority_level (prioritized reports).\n\n"+
-				"PAGINATION: Returns 100 results. If moreResults=true, call again with offset+100.",
+				"NAMES: Pass guid, or name when the GUID is not known. When name matches several metrics, the user is "+
+				"asked to pick one if the client supports it; otherwise 'ambiguous' lists the candidates: call again "+
+				"with the guid of the intended one.\n\n"+
+				"SORTING: Upstream reports can be sorted by priority (highest first) or area: sortBy=\"priority\".\n\n"+
+				"PAGINATION: Returns 100 results. If moreResults=true, call again with cursor=nextCursor (or offset+100).",
 		),
 		mcp.WithInputSchema[TraceMetricInput](),
+		mcp.WithOutputSchema[TraceMetricOutput](),
 		mcp.WithTitleAnnotation("Trace Metric lineage by direction"),
 		mcp.WithReadOnlyHintAnnotation(true),
 		mcp.WithIdempotentHintAnnotation(true),
@@ -197,46 +245,74 @@ func TraceMetricHandler(deps *tools.ToolDependencies) func(context.Context, mcp.
 func handleTraceMetric(ctx context.Context, deps *tools.ToolDependencies, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	if deps.DBService == nil {
 		errMessage := "Database service is not initialized"
-		slog.Error(errMessage)
+		slog.ErrorContext(ctx, errMessage)
 		return mcp.NewToolResultError(errMessage), nil
 	}
 
 	// Parse input
 	var input TraceMetricInput
 	if err := request.BindArguments(&input); err != nil {
-		slog.Error("error binding arguments", "error", err)
+		slog.ErrorContext(ctx, "error binding arguments", "error", err)
 		return mcp.NewToolResultError(fmt.Sprintf("Invalid input: %v", err)), nil
 	}
 
 	// Validate required fields
-	if input.GUID == "" {
-		return mcp.NewToolResultError("guid parameter is required"), nil
+	if input.GUID == "" && input.Name == "" {
+		return mcp.NewToolResultError("guid or name parameter is required"), nil
+	}
+	if input.GUID != "" && input.Name != "" {
+		return mcp.NewToolResultError("only one of guid or name parameters is supported"), nil
 	}
 	if input.Direction == "" {
 		return mcp.NewToolResultError("direction parameter is required (must be 'downstream' or 'upstream')"), nil
 	}
 
 	// Select query based on direction
-	var query string
+	var query, listKey string
+	sortByOptions := []string{sortByName}
 	switch input.Direction {
 	case "upstream":
-		query = traceMetricUpstreamQuery
+		query, listKey = traceMetricUpstreamQuery, "reports"
+		sortByOptions = upstreamSortByOptions
 	case "downstream":
-		query = traceMetricDownstreamQuery
+		query, listKey = traceMetricDownstreamQuery, "tables"
 	default:
 		return mcp.NewToolResultError(fmt.Sprintf("Invalid direction '%s': must be 'upstream' or 'downstream'", input.Direction)), nil
 	}
 
+	if err := validateSortBy(input.SortBy, sortByOptions); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if input.GUID == "" {
+		guid, ambiguous, err := resolveName(ctx, deps, "Metric", input.Name, input.Scope)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if ambiguous != nil {
+			return ambiguousNameResult(ctx, ambiguous), nil
+		}
+		input.GUID = guid
+	}
+
 	params := map[string]any{
 		"guid":   input.GUID,
+		"sortBy": sortByParam(input.SortBy),
 		"offset": input.Offset,
+		"scope":  scopeParam(deps, input.Scope),
 	}
 
-	slog.Info("executing trace-metric query", "guid", input.GUID, "direction", input.Direction, "offset", input.Offset)
+	slog.InfoContext(ctx, "executing trace-metric query", "guid", input.GUID, "direction", input.Direction, "offset", input.Offset)
 
+	pages, err := newPager("trace-metric:"+input.Direction, params, input.Cursor)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	reportProgress(ctx, stageTraversing)
 	records, err := deps.DBService.ExecuteReadQuery(ctx, query, params)
 	if err != nil {
-		slog.Error("failed to execute trace-metric query", "error", err)
+		slog.ErrorContext(ctx, "failed to execute trace-metric query", "error", err)
 		return mcp.NewToolResultError(fmt.Sprintf("Query execution failed: %v", err)), nil
 	}
 
@@ -245,9 +321,12 @@ func handleTraceMetric(ctx context.Context, deps *tools.ToolDependencies, reques
 		return mcp.NewToolResultError(fmt.Sprintf("Metric with GUID %s not found", input.GUID)), nil
 	}
 
+	pages.attachNextCursor(ctx, records, "result", listKey)
+
+	reportProgress(ctx, stageFormatting)
 	response, err := deps.DBService.Neo4jRecordsToJSON(records)
 	if err != nil {
-		slog.Error("failed to format trace-metric results to JSON", "error", err)
+		slog.ErrorContext(ctx, "failed to format trace-metric results to JSON", "error", err)
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
@@ -0,0 +1,209 @@
+package mstr
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// TraceTransformationInput defines the input parameters for the trace-transformation tool
+type TraceTransformationInput struct {
+	GUID   string `json:"guid" jsonschema:"required,description=Full GUID of the Transformation to trace"`
+	Scope  string `json:"scope,omitempty" jsonschema:"description=Project or folder to search in (e.g. Retail or Retail/Public Objects/Metrics; see list-projects). Defaults to the server scope; * for all projects"`
+	Offset int    `json:"offset,omitempty" jsonschema:"default=0,description=Skip first N metrics for pagination"`
+	Cursor string `json:"cursor,omitempty" jsonschema:"description=nextCursor from the previous page (preferred over offset: stable and cheap for deep pages)"`
+}
+
+// TransformationDetails is a Transformation with its expressions
+type TransformationDetails struct {
+	Type            string `json:"type,omitempty"`
+	GUID            string `json:"guid,omitempty"`
+	Name            string `json:"name,omitempty"`
+	Location        string `json:"location,omitempty"`
+	ExpressionsJSON string `json:"expressions_json,omitempty"`
+}
+
+// TransformationMember is an attribute shifted by a Transformation
+type TransformationMember struct {
+	Name      string `json:"name,omitempty"`
+	GUID      string `json:"guid,omitempty"`
+	Status    string `json:"status,omitempty"`
+	EDWTable  string `json:"edwTable,omitempty"`
+	EDWColumn string `json:"edwColumn,omitempty"`
+}
+
+// TraceTransformationResult is a Transformation with its members, mapping tables and metrics
+type TraceTransformationResult struct {
+	Transformation TransformationDetails  `json:"transformation"`
+	Members        []TransformationMember `json:"members"`
+	MappingTables  []TableRef             `json:"mappingTables"`
+	Metrics        []MetricDetails        `json:"metrics"`
+	MoreResults    bool                   `json:"moreResults"`
+}
+
+// TraceTransformationOutput defines the output of the trace-transformation tool
+type TraceTransformationOutput struct {
+	Result     TraceTransformationResult `json:"result"`
+	NextCursor string                    `json:"nextCursor,omitempty"`
+	Truncated  []TruncatedList           `json:"truncated,omitempty"`
+}
+
+const traceTransformationQuery = `
+// Trace a Transformation: member attributes, mapping tables and every metric applying it
+// $guid: Full GUID of the Transformation
+// $offset: Pagination offset for metrics
+// $scope: optional location prefixes (project/folder scope), null for all projects
+// $after: {name, guid} of the last metric of the previous page (cursor), or null
+//
+// LIVE TRAVERSAL: Members and mapping tables are direct DEPENDS_ON targets;
+// metrics applying the transformation are direct DEPENDS_ON sources.
+
+MATCH (n:MSTRObject {guid: $guid})
+WHERE n.type = 'Transformation'
+
+// Member attributes (the attributes shifted by the transformation)
+OPTIONAL MATCH (n)-[:DEPENDS_ON]->(a)
+WHERE a.type = 'Attribute'
+WITH n, [attr IN collect(DISTINCT {
+  name: a.name,
+  guid: a.guid,
+  status: COALESCE(a.updated_parity_status, a.parity_status, 'No Status'),
+  edwTable: COALESCE(a.updated_edw_table, a.edw_table),
+  edwColumn: a.edw_column
+}) WHERE attr.guid IS NOT NULL] as members
+
+// Mapping tables (e.g. vwLookupFinancialYearWeekLastYear)
+OPTIONAL MATCH (n)-[:DEPENDS_ON]->(t)
+WHERE t.type IN ['LogicalTable', 'Table']
+WITH n, members, [tbl IN collect(DISTINCT {
+  name: t.name,
+  guid: t.guid,
+  physicalTable: t.physical_table_name,
+  database: t.database_instance
+}) WHERE tbl.guid IS NOT NULL] as mappingTables
+
+// Metrics applying the transformation
+OPTIONAL MATCH (m)-[:DEPENDS_ON]->(n)
+WHERE m.type IN ['Metric', 'DerivedMetric']
+  AND ($scope IS NULL OR any(p IN $scope WHERE replace(toLower(m.location), '\\', '/') + '/' STARTS WITH p))
+  AND ($after IS NULL OR m.name > $after.name OR (m.name = $after.name AND m.guid > $after.guid))  // keyset pagination after a cursor
+
+WITH DISTINCT n, members, mappingTables, m
+ORDER BY m.name ASC, m.guid ASC
+SKIP CASE WHEN $after IS NULL THEN $offset ELSE 0 END
+LIMIT 101
+
+// Collect paginated metrics (filter out null results from OPTIONAL MATCH)
+WITH n, members, mappingTables, [metric IN collect(DISTINCT {
+  name: m.name,
+  guid: m.guid,
+  type: m.type,
+  status: COALESCE(m.updated_parity_status, m.parity_status, 'No Status'),
+  priority: m.inherited_priority_level,
+  formula: m.formula,
+  semanticName: m.pb_semantic_name
+}) WHERE metric.guid IS NOT NULL] as fetched
+
+RETURN {
+  transformation: {
+    type: 'Transformation',
+    guid: n.guid,
+    name: n.name,
+    location: n.location,
+    expressions_json: n.expressions_json
+  },
+  members: members,
+  mappingTables: mappingTables,
+  metrics: fetched[0..100],
+  moreResults: size(fetched) > 100
+} as result
+`
+
+// TraceTransformationSpec returns the MCP tool definition for trace-transformation
+func TraceTransformationSpec() mcp.Tool {
+	return mcp.NewTool("trace-transformation",
+		mcp.WithDescription(
+			"Trace a Transformation (time-series shift): its member attributes, mapping tables and every metric that applies it.\n\n"+
+				"USE FOR:\n"+
+				"- Translating \"... LY\" style metrics: which mapping table drives the shift and which metrics share it\n"+
+				"- Designing one Power BI/dbt date-intelligence pattern per transformation\n\n"+
+				"DO NOT USE FOR:\n"+
+				"- Finding transformations by name (use list-transformations first to get the GUID)\n"+
+				"- Report lineage of a metric (use trace-metric instead)\n\n"+
+				"PAGINATION: Returns 100 metrics. If moreResults=true, call again with cursor=nextCursor (or offset+100).",
+		),
+		mcp.WithInputSchema[TraceTransformationInput](),
+		mcp.WithOutputSchema[TraceTransformationOutput](),
+		mcp.WithTitleAnnotation("Trace Transformation members and usage"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(true),
+	)
+}
+
+// TraceTransformationHandler returns the handler function for the trace-transformation tool
+func TraceTransformationHandler(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleTraceTransformation(ctx, deps, request)
+	}
+}
+
+func handleTraceTransformation(ctx context.Context, deps *tools.ToolDependencies, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if deps.DBService == nil {
+		errMessage := "Database service is not initialized"
+		slog.ErrorContext(ctx, errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	// Parse input
+	var input TraceTransformationInput
+	if err := request.BindArguments(&input); err != nil {
+		slog.ErrorContext(ctx, "error binding arguments", "error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid input: %v", err)), nil
+	}
+
+	// Validate required fields
+	if input.GUID == "" {
+		return mcp.NewToolResultError("guid parameter is required"), nil
+	}
+
+	params := map[string]any{
+		"guid":   input.GUID,
+		"offset": input.Offset,
+		"scope":  scopeParam(deps, input.Scope),
+	}
+
+	slog.InfoContext(ctx, "executing trace-transformation query", "guid", input.GUID, "offset", input.Offset)
+
+	pages, err := newPager("trace-transformation", params, input.Cursor)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	reportProgress(ctx, stageTraversing)
+	records, err := deps.DBService.ExecuteReadQuery(ctx, traceTransformationQuery, params)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to execute trace-transformation query", "error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Query execution failed: %v", err)), nil
+	}
+
+	// Check if transformation was found
+	if len(records) == 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("Transformation with GUID %s not found", input.GUID)), nil
+	}
+
+	pages.attachNextCursor(ctx, records, "result", "metrics")
+
+	reportProgress(ctx, stageFormatting)
+	response, err := deps.DBService.Neo4jRecordsToJSON(records)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to format trace-transformation results to JSON", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(response), nil
+}
@@ -0,0 +1,63 @@
+package mstr_test
+
+import (
+	"testing"
+
+	db "github.com/brunogc-cit/flow-microstrategy-mcp/internal/database/mocks"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools/mstr"
+	"github.com/neo4j/neo4j-go-driver/v6/neo4j"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListTransformationsHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("lists all transformations without query", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Eq(map[string]any{"query": nil, "offset": 0, "scope": nil, "after": nil})).
+			Return([]*neo4j.Record{}, nil)
+		mockDB.EXPECT().
+			Neo4jRecordsToJSON(gomock.Any()).
+			Return("[]", nil)
+
+		result := callTool(t, mstr.ListTransformationsHandler(&tools.ToolDependencies{DBService: mockDB}), map[string]any{})
+		assert.False(t, result.IsError)
+	})
+
+	t.Run("passes search term", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Eq(map[string]any{"query": "Last Year", "offset": 100, "scope": nil, "after": nil})).
+			Return([]*neo4j.Record{}, nil)
+		mockDB.EXPECT().
+			Neo4jRecordsToJSON(gomock.Any()).
+			Return("[]", nil)
+
+		result := callTool(t, mstr.ListTransformationsHandler(&tools.ToolDependencies{DBService: mockDB}), map[string]any{"query": "Last Year", "offset": 100, "scope": nil})
+		assert.False(t, result.IsError)
+	})
+}
+
+func TestTraceTransformationHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("transformation not found", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return([]*neo4j.Record{}, nil)
+
+		result := callTool(t, mstr.TraceTransformationHandler(&tools.ToolDependencies{DBService: mockDB}), map[string]any{"guid": "ABC"})
+		assert.True(t, result.IsError)
+	})
+
+	t.Run("missing guid", func(t *testing.T) {
+		result := callTool(t, mstr.TraceTransformationHandler(&tools.ToolDependencies{DBService: db.NewMockService(ctrl)}), map[string]any{})
+		assert.True(t, result.IsError)
+	})
+}
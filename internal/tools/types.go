@@ -1,6 +1,8 @@
 package tools
 
 import (
+	"sync/atomic"
+
 	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/analytics"
 	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/database"
 )
@@ -10,4 +12,8 @@ type ToolDependencies struct {
 	DBService        database.Service
 	AnalyticsService analytics.Service
 	SchemaSampleSize int
+	// SearchIndex is true when the MSTR full-text search index is ONLINE (nil: never use it)
+	SearchIndex *atomic.Bool
+	// DefaultScope is the project/location scope of MSTR tools called without scope (empty: all projects)
+	DefaultScope string
 }
@@ -0,0 +1,77 @@
+//go:build integration
+
+package integration
+
+import (
+	"testing"
+
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools/mstr"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/test/integration/helpers"
+)
+
+// seedEssentialObjects seeds three Databricks-essential objects, one with complete mappings
+func seedEssentialObjects(tc *helpers.TestContext) {
+	tc.SeedMSTRGraph([]map[string]any{
+		{"id": "M1", "type": "Metric", "name": "Net Sales", "db_essential": "Y", "db_raw": "Y", "db_serve": "Y"},
+		{"id": "M2", "type": "Metric", "name": "Margin", "db_essential": true, "db_raw": "Y", "db_serve": "Y", "updated_db_serve": "N"},
+		{"id": "M3", "type": "Metric", "name": "Returns"},
+		{"id": "A1", "type": "Attribute", "name": "Customer", "db_essential": "Yes"},
+		{"id": "F1", "type": "Filter", "name": "This Year"},
+		{"id": "R1", "type": "Report", "name": "Daily Margin", "priority_level": 1},
+		{"id": "R2", "type": "Report", "name": "Yearly Margin", "priority_level": 2},
+		{"id": "R3", "type": "Report", "name": "Unprioritized"},
+	}, [][2]string{
+		{"R1", "M2"}, {"R2", "F1"}, {"F1", "M2"}, {"R3", "M2"}, {"R3", "M3"},
+	})
+}
+
+func TestEssentialObjects(t *testing.T) {
+	t.Parallel()
+
+	t.Run("lists the essential objects, incomplete first", func(t *testing.T) {
+		tc := helpers.NewTestContext(t, dbs.GetDriver())
+		seedEssentialObjects(tc)
+
+		res := tc.CallTool(mstr.EssentialObjectsHandler(tc.Deps), map[string]any{"platform": "databricks", "scope": tc.MSTRProject()})
+
+		var records []mstr.EssentialObjectsOutput
+		tc.ParseJSONResponse(res, &records)
+
+		if len(records) != 1 {
+			t.Fatalf("expected 1 record, got %d", len(records))
+		}
+		result := records[0].Result
+		if result.Total != 3 || result.Complete != 1 || result.Incomplete != 2 {
+			t.Fatalf("expected 3 objects with 1 complete, got total=%d complete=%d incomplete=%d", result.Total, result.Complete, result.Incomplete)
+		}
+		if len(result.Objects) != 3 {
+			t.Fatalf("expected 3 objects, got %+v", result.Objects)
+		}
+		if result.Objects[0].Name != "Customer" || result.Objects[1].Name != "Margin" || result.Objects[2].Name != "Net Sales" {
+			t.Fatalf("expected Customer, Margin, Net Sales, got %s, %s, %s", result.Objects[0].Name, result.Objects[1].Name, result.Objects[2].Name)
+		}
+
+		margin := result.Objects[1]
+		if len(margin.Missing) != 1 || margin.Missing[0] != "serve" {
+			t.Fatalf("expected Margin to miss serve, got %v", margin.Missing)
+		}
+		// Reports reach Margin directly or through a filter; reports without priority are ignored
+		if margin.ReportCount != 2 || len(margin.Reports) != 2 || margin.Reports[0].Name != "Daily Margin" || margin.Reports[1].Name != "Yearly Margin" {
+			t.Fatalf("expected Daily Margin and Yearly Margin, got %d %+v", margin.ReportCount, margin.Reports)
+		}
+	})
+
+	t.Run("only lists incomplete objects", func(t *testing.T) {
+		tc := helpers.NewTestContext(t, dbs.GetDriver())
+		seedEssentialObjects(tc)
+
+		res := tc.CallTool(mstr.EssentialObjectsHandler(tc.Deps), map[string]any{"platform": "databricks", "incomplete": true, "scope": tc.MSTRProject()})
+
+		var records []mstr.EssentialObjectsOutput
+		tc.ParseJSONResponse(res, &records)
+
+		if len(records) != 1 || len(records[0].Result.Objects) != 2 || records[0].Result.Total != 3 {
+			t.Fatalf("expected 2 of 3 objects, got %+v", records)
+		}
+	})
+}
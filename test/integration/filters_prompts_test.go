@@ -0,0 +1,82 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools/mstr"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/test/integration/helpers"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// reportsPage is the report page of get-filter and get-prompt
+type reportsPage struct {
+	Result struct {
+		Reports     []mstr.ReportRef `json:"reports"`
+		MoreResults bool             `json:"moreResults"`
+	} `json:"result"`
+}
+
+func TestFilterAndPromptReports(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		handler func(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error)
+		object  string
+		// via is an object through which the first report reaches the object a second time
+		via string
+	}{
+		{name: "get-filter", handler: mstr.GetFilterHandler, object: "Filter", via: "Filter"},
+		{name: "get-prompt", handler: mstr.GetPromptHandler, object: "Prompt", via: "Filter"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+" pages reports reached through several paths once", func(t *testing.T) {
+			tc := helpers.NewTestContext(t, dbs.GetDriver())
+
+			// 101 prioritized reports using the object; R000 also reaches it through VIA
+			objects := []map[string]any{
+				{"id": "OBJ", "type": tt.object, "name": "Object"},
+				{"id": "VIA", "type": tt.via, "name": "Via"},
+			}
+			dependsOn := [][2]string{{"R000", "VIA"}, {"VIA", "OBJ"}}
+			for i := range 101 {
+				id := fmt.Sprintf("R%03d", i)
+				objects = append(objects, map[string]any{"id": id, "type": "Report", "name": fmt.Sprintf("Report %03d", i), "priority_level": 1})
+				dependsOn = append(dependsOn, [2]string{id, "OBJ"})
+			}
+			tc.SeedMSTRGraph(objects, dependsOn)
+
+			handler := tt.handler(tc.Deps)
+			args := map[string]any{"guid": tc.MSTRGUID("OBJ"), "scope": tc.MSTRProject()}
+
+			var first []reportsPage
+			tc.ParseJSONResponse(tc.CallTool(handler, args), &first)
+			if len(first) != 1 || len(first[0].Result.Reports) != 100 || !first[0].Result.MoreResults {
+				t.Fatalf("expected 100 reports and more results, got %+v", first)
+			}
+			seen := make(map[string]bool, 100)
+			for _, r := range first[0].Result.Reports {
+				if seen[r.GUID] {
+					t.Fatalf("report %s listed twice", r.GUID)
+				}
+				seen[r.GUID] = true
+			}
+
+			args["offset"] = 100
+			var second []reportsPage
+			tc.ParseJSONResponse(tc.CallTool(handler, args), &second)
+			if len(second) != 1 || len(second[0].Result.Reports) != 1 || second[0].Result.MoreResults {
+				t.Fatalf("expected the last report only, got %+v", second)
+			}
+			if second[0].Result.Reports[0].GUID != tc.MSTRGUID("R100") {
+				t.Fatalf("expected R100 on the second page, got %s", second[0].Result.Reports[0].GUID)
+			}
+		})
+	}
+}
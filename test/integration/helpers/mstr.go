@@ -0,0 +1,168 @@
+//go:build integration
+
+package helpers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// MSTRGUID returns a GUID unique to the test for the given object id, so parallel tests
+// seeding the same ids do not match each other's objects.
+func (tc *TestContext) MSTRGUID(id string) string {
+	return fmt.Sprintf("%s_%s", id, tc.TestID)
+}
+
+// MSTRProject returns the project of the objects seeded by SeedMSTRGraph. Pass it as scope so
+// queries over all objects of a type only see the objects of the test.
+func (tc *TestContext) MSTRProject() string {
+	return fmt.Sprintf("it_%s", tc.TestID)
+}
+
+// SeedMSTRGraph creates MSTRObject nodes with the given properties and the DEPENDS_ON relationships
+// between them, given as (from, to) id pairs. Object ids ("id") become GUIDs through MSTRGUID, nodes
+// of type Metric/Attribute also get that label, and every node is located in MSTRProject (with
+// Windows separators, as in the exported metadata) unless it sets its own "location" (nil for none).
+// Nodes are removed by Cleanup.
+func (tc *TestContext) SeedMSTRGraph(objects []map[string]any, dependsOn [][2]string) {
+	tc.t.Helper()
+
+	label := tc.GetUniqueLabel("MSTR")
+
+	nodes := make([]map[string]any, 0, len(objects))
+	for _, object := range objects {
+		props := make(map[string]any, len(object)+1)
+		for key, value := range object {
+			props[key] = value
+		}
+		id, _ := props["id"].(string)
+		delete(props, "id")
+		props["guid"] = tc.MSTRGUID(id)
+		if _, ok := props["location"]; !ok {
+			props["location"] = fmt.Sprintf(`\%s\Public Objects\%v`, tc.MSTRProject(), props["type"])
+		}
+		nodes = append(nodes, props)
+	}
+	query := fmt.Sprintf(`
+UNWIND $nodes as props
+CREATE (n:MSTRObject:%s)
+SET n = props
+FOREACH (_ IN CASE WHEN n.type = 'Metric' THEN [1] ELSE [] END | SET n:Metric)
+FOREACH (_ IN CASE WHEN n.type = 'Attribute' THEN [1] ELSE [] END | SET n:Attribute)
+`, label)
+	if _, err := tc.Service.ExecuteWriteQuery(tc.ctx, query, map[string]any{"nodes": nodes}); err != nil {
+		tc.t.Fatalf("failed to seed MSTR objects: %v", err)
+	}
+
+	if len(dependsOn) == 0 {
+		return
+	}
+	edges := make([]map[string]any, 0, len(dependsOn))
+	for _, edge := range dependsOn {
+		edges = append(edges, map[string]any{"from": tc.MSTRGUID(edge[0]), "to": tc.MSTRGUID(edge[1])})
+	}
+	query = fmt.Sprintf(`
+UNWIND $edges as edge
+MATCH (from:%[1]s {guid: edge.from}), (to:%[1]s {guid: edge.to})
+CREATE (from)-[:DEPENDS_ON]->(to)
+`, label)
+	if _, err := tc.Service.ExecuteWriteQuery(tc.ctx, query, map[string]any{"edges": edges}); err != nil {
+		tc.t.Fatalf("failed to seed MSTR dependencies: %v", err)
+	}
+}
+
+// ParseStructuredResponse validates the structured content of an MSTR tool result against the output
+// schema of the tool and decodes it into v, rejecting fields the output type does not declare
+func (tc *TestContext) ParseStructuredResponse(tool server.ServerTool, res *mcp.CallToolResult, v any) {
+	tc.t.Helper()
+
+	structured, ok := res.StructuredContent.(map[string]any)
+	if !ok {
+		tc.t.Fatalf("expected structured content, got %T", res.StructuredContent)
+	}
+
+	data, err := json.Marshal(tool.Tool.OutputSchema)
+	if err != nil {
+		tc.t.Fatalf("failed to encode output schema: %v", err)
+	}
+	var schema map[string]any
+	if err := json.Unmarshal(data, &schema); err != nil {
+		tc.t.Fatalf("failed to decode output schema: %v", err)
+	}
+	tc.validateSchema(tool.Tool.Name, schema, structured)
+
+	data, err = json.Marshal(structured)
+	if err != nil {
+		tc.t.Fatalf("failed to encode structured content: %v", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		tc.t.Fatalf("structured content does not match the output type: %v\nraw: %s", err, data)
+	}
+}
+
+// validateSchema checks value against the subset of JSON Schema generated for output types: type,
+// properties, required and items. Schemas without type (any) accept every value.
+func (tc *TestContext) validateSchema(path string, schema, value any) {
+	tc.t.Helper()
+
+	rules, ok := schema.(map[string]any)
+	if !ok {
+		return
+	}
+	switch rules["type"] {
+	case "object":
+		object, ok := value.(map[string]any)
+		if !ok {
+			tc.t.Errorf("%s: expected an object, got %T", path, value)
+			return
+		}
+		required, _ := rules["required"].([]any)
+		for _, field := range required {
+			if _, ok := object[field.(string)]; !ok {
+				tc.t.Errorf("%s: missing required field %v", path, field)
+			}
+		}
+		properties, _ := rules["properties"].(map[string]any)
+		for field, fieldValue := range object {
+			if property, ok := properties[field]; ok {
+				tc.validateSchema(path+"."+field, property, fieldValue)
+			}
+		}
+	case "array":
+		items, ok := value.([]any)
+		if !ok {
+			tc.t.Errorf("%s: expected an array, got %T", path, value)
+			return
+		}
+		for i, item := range items {
+			tc.validateSchema(fmt.Sprintf("%s[%d]", path, i), rules["items"], item)
+		}
+	case "string":
+		if _, ok := value.(string); !ok {
+			tc.t.Errorf("%s: expected a string, got %T", path, value)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			tc.t.Errorf("%s: expected a boolean, got %T", path, value)
+		}
+	case "integer":
+		number, ok := value.(json.Number)
+		if !ok {
+			tc.t.Errorf("%s: expected an integer, got %T", path, value)
+			return
+		}
+		if _, err := number.Int64(); err != nil {
+			tc.t.Errorf("%s: expected an integer, got %s", path, number)
+		}
+	case "number":
+		if _, ok := value.(json.Number); !ok {
+			tc.t.Errorf("%s: expected a number, got %T", path, value)
+		}
+	}
+}
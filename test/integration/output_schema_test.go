@@ -0,0 +1,127 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools/mstr"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/test/integration/helpers"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// seedOutputGraph seeds a graph reaching every list and mapping of the MSTR tool outputs and
+// returns the semantic model name
+func seedOutputGraph(tc *helpers.TestContext) string {
+	model := "Retail " + tc.TestID
+	tc.SeedMSTRGraph([]map[string]any{
+		{"id": "M1", "type": "Metric", "name": "Net Sales", "formula": "Sum(sales_amount)", "parity_status": "Planned",
+			"inherited_priority_level": 1, "pb_semantic_model": model, "pb_semantic_name": "Sales",
+			"db_essential": "Y", "db_raw": "Y", "db_serve": "N", "edw_table": "fact_sales", "edw_column": "sales_amount"},
+		{"id": "M2", "type": "Metric", "name": "Gross Sales", "updated_parity_status": "Complete", "inherited_priority_level": 2,
+			"db_essential": true, "pb_essential": "Y"},
+		{"id": "A1", "type": "Attribute", "name": "Product", "forms_json": `{"ID": "product_id"}`, "inherited_priority_level": 1,
+			"edw_table": "dim_product", "edw_column": "product_id", "db_essential": "Y"},
+		{"id": "A2", "type": "Attribute", "name": "Category"},
+		{"id": "F1", "type": "Fact", "name": "Sales Amount", "expressions_json": `[{"expression": "Sum(sales_amount)", "tables": ["LU_SALES"]}]`},
+		{"id": "T1", "type": "LogicalTable", "name": "LU_SALES", "physical_table_name": "dbo.fact_sales", "database_instance": "EDW"},
+		{"id": "T2", "type": "LogicalTable", "name": "LU_PRODUCT", "physical_table_name": "dbo.dim_product"},
+		{"id": "FL1", "type": "Filter", "name": "Current Week", "expression": "Week = Current"},
+		{"id": "P1", "type": "Prompt", "name": "Week Prompt", "prompt_type": "Value", "title": "Pick a week", "required": true},
+		{"id": "X1", "type": "Transformation", "name": "Last Year", "expressions_json": `[{"expression": "year_id - 1"}]`},
+		{"id": "R1", "type": "Report", "name": "Daily Sales", "priority_level": 1, "usage_area": "Retail", "pb_semantic_model": model},
+		{"id": "R2", "type": "Report", "name": "Weekly Sales", "priority_level": 2, "pb_semantic_model": model},
+	}, [][2]string{
+		{"M1", "F1"}, {"M1", "A1"}, {"M2", "F1"}, {"M2", "X1"}, {"F1", "T1"}, {"A1", "T2"}, {"A1", "A2"},
+		{"FL1", "A1"}, {"FL1", "P1"}, {"P1", "M1"}, {"X1", "A1"}, {"X1", "T1"},
+		{"R1", "M1"}, {"R1", "A1"}, {"R1", "FL1"}, {"R2", "M1"}, {"R2", "A1"},
+	})
+	return model
+}
+
+// TestStructuredOutputConformsToSchema runs every MSTR tool against a seeded graph and validates its
+// structured content against the output schema it publishes
+func TestStructuredOutputConformsToSchema(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		spec    mcp.Tool
+		handler func(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error)
+		// guid is the id of the seeded object passed as guid, model passes the seeded semantic model
+		guid   string
+		model  bool
+		args   map[string]any
+		output any
+	}{
+		{name: "search-metrics", spec: mstr.SearchMetricsSpec(), handler: mstr.SearchMetricsHandler,
+			args: map[string]any{"query": "sales", "facets": true}, output: &mstr.SearchMetricsOutput{}},
+		{name: "search-attributes", spec: mstr.SearchAttributesSpec(), handler: mstr.SearchAttributesHandler,
+			args: map[string]any{"query": "product", "facets": true}, output: &mstr.SearchAttributesOutput{}},
+		{name: "search-filters", spec: mstr.SearchFiltersSpec(), handler: mstr.SearchFiltersHandler,
+			args: map[string]any{"query": "week"}, output: &mstr.SearchFiltersOutput{}},
+		{name: "search-prompts", spec: mstr.SearchPromptsSpec(), handler: mstr.SearchPromptsHandler,
+			args: map[string]any{"query": "week"}, output: &mstr.SearchPromptsOutput{}},
+		{name: "search-by-definition", spec: mstr.SearchByDefinitionSpec(), handler: mstr.SearchByDefinitionHandler,
+			args: map[string]any{"query": "sales_amount"}, output: &mstr.SearchByDefinitionOutput{}},
+		{name: "trace-metric upstream", spec: mstr.TraceMetricSpec(), handler: mstr.TraceMetricHandler, guid: "M1",
+			args: map[string]any{"direction": "upstream"}, output: &mstr.TraceMetricOutput{}},
+		{name: "trace-metric downstream", spec: mstr.TraceMetricSpec(), handler: mstr.TraceMetricHandler, guid: "M1",
+			args: map[string]any{"direction": "downstream"}, output: &mstr.TraceMetricOutput{}},
+		{name: "trace-attribute upstream", spec: mstr.TraceAttributeSpec(), handler: mstr.TraceAttributeHandler, guid: "A1",
+			args: map[string]any{"direction": "upstream"}, output: &mstr.TraceAttributeOutput{}},
+		{name: "trace-attribute downstream", spec: mstr.TraceAttributeSpec(), handler: mstr.TraceAttributeHandler, guid: "A1",
+			args: map[string]any{"direction": "downstream"}, output: &mstr.TraceAttributeOutput{}},
+		{name: "trace-columns forward", spec: mstr.TraceColumnsSpec(), handler: mstr.TraceColumnsHandler, guid: "M1",
+			output: &mstr.TraceColumnsOutput{}},
+		{name: "trace-columns reverse", spec: mstr.TraceColumnsSpec(), handler: mstr.TraceColumnsHandler,
+			args: map[string]any{"column": "sales_amount"}, output: &mstr.TraceColumnsOutput{}},
+		{name: "trace-transformation", spec: mstr.TraceTransformationSpec(), handler: mstr.TraceTransformationHandler, guid: "X1",
+			output: &mstr.TraceTransformationOutput{}},
+		{name: "list-transformations", spec: mstr.ListTransformationsSpec(), handler: mstr.ListTransformationsHandler,
+			output: &mstr.ListTransformationsOutput{}},
+		{name: "get-filter", spec: mstr.GetFilterSpec(), handler: mstr.GetFilterHandler, guid: "FL1",
+			output: &mstr.GetFilterOutput{}},
+		{name: "get-prompt", spec: mstr.GetPromptSpec(), handler: mstr.GetPromptHandler, guid: "P1",
+			output: &mstr.GetPromptOutput{}},
+		{name: "get-attribute-hierarchy", spec: mstr.GetAttributeHierarchySpec(), handler: mstr.GetAttributeHierarchyHandler, guid: "A1",
+			output: &mstr.GetAttributeHierarchyOutput{}},
+		{name: "find-similar-reports clusters", spec: mstr.FindSimilarReportsSpec(), handler: mstr.FindSimilarReportsHandler,
+			args: map[string]any{"threshold": 0.5}, output: &mstr.FindSimilarReportsOutput{}},
+		{name: "find-similar-reports by guid", spec: mstr.FindSimilarReportsSpec(), handler: mstr.FindSimilarReportsHandler, guid: "R1",
+			args: map[string]any{"threshold": 0.5}, output: &mstr.FindSimilarReportsOutput{}},
+		{name: "list-projects", spec: mstr.ListProjectsSpec(), handler: mstr.ListProjectsHandler,
+			output: &mstr.ListProjectsOutput{}},
+		{name: "semantic-model-coverage summary", spec: mstr.SemanticModelCoverageSpec(), handler: mstr.SemanticModelCoverageHandler,
+			output: &mstr.SemanticModelCoverageOutput{}},
+		{name: "semantic-model-coverage detail", spec: mstr.SemanticModelCoverageSpec(), handler: mstr.SemanticModelCoverageHandler, model: true,
+			output: &mstr.SemanticModelCoverageOutput{}},
+		{name: "essential-objects", spec: mstr.EssentialObjectsSpec(), handler: mstr.EssentialObjectsHandler,
+			args: map[string]any{"platform": "databricks"}, output: &mstr.EssentialObjectsOutput{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := helpers.NewTestContext(t, dbs.GetDriver())
+			model := seedOutputGraph(tc)
+
+			args := map[string]any{"scope": tc.MSTRProject()}
+			for key, value := range tt.args {
+				args[key] = value
+			}
+			if tt.guid != "" {
+				args["guid"] = tc.MSTRGUID(tt.guid)
+			}
+			if tt.model {
+				args["model"] = model
+			}
+
+			// Wrapped the way the server registers MSTR tools
+			tool := mstr.WithStructuredOutput(mstr.WithFieldProjection(server.ServerTool{Tool: tt.spec, Handler: tt.handler(tc.Deps)}))
+			tc.ParseStructuredResponse(tool, tc.CallTool(tool.Handler, args), tt.output)
+		})
+	}
+}
@@ -0,0 +1,123 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools/mstr"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/test/integration/helpers"
+)
+
+// enableSearchIndex creates the full-text search index, waits until it is ONLINE and lets the
+// tools of tc use it
+func enableSearchIndex(t *testing.T, tc *helpers.TestContext) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	if err := mstr.CreateSearchIndex(ctx, tc.Service); err != nil {
+		t.Fatalf("failed to create search index: %v", err)
+	}
+	for {
+		online, err := mstr.CheckSearchIndex(ctx, tc.Service)
+		if err != nil {
+			t.Fatalf("failed to check search index: %v", err)
+		}
+		if online {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			t.Fatal("search index did not come ONLINE")
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+
+	tc.Deps.SearchIndex = &atomic.Bool{}
+	tc.Deps.SearchIndex.Store(true)
+}
+
+func TestSearchMetrics(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ranks name matches with the full-text index", func(t *testing.T) {
+		tc := helpers.NewTestContext(t, dbs.GetDriver())
+		enableSearchIndex(t, tc)
+
+		tc.SeedMSTRGraph([]map[string]any{
+			{"id": "M1", "type": "Metric", "name": "Retail Sales LY"},
+			{"id": "M2", "type": "Metric", "name": "Retail Sales"},
+			{"id": "M3", "type": "Metric", "name": "Retail Stock"},
+			{"id": "M4", "type": "Metric", "name": "Net Sales"},
+			{"id": "A1", "type": "Attribute", "name": "Retail Sales Channel"},
+		}, nil)
+
+		res := tc.CallTool(mstr.SearchMetricsHandler(tc.Deps), map[string]any{
+			"query": "retail sales",
+			"scope": tc.MSTRProject(),
+		})
+
+		var records []mstr.SearchMetricsOutput
+		tc.ParseJSONResponse(res, &records)
+
+		if len(records) != 1 {
+			t.Fatalf("expected 1 record, got %d", len(records))
+		}
+		results := records[0].Results
+		if len(results) != 2 || results[0].Name != "Retail Sales" || results[1].Name != "Retail Sales LY" {
+			t.Fatalf("expected Retail Sales then Retail Sales LY, got %+v", results)
+		}
+		if results[0].Score <= 0 || results[0].Score < results[1].Score {
+			t.Fatalf("expected relevance scores best first, got %v and %v", results[0].Score, results[1].Score)
+		}
+	})
+
+	t.Run("cursor continues after the last result of the previous page", func(t *testing.T) {
+		tc := helpers.NewTestContext(t, dbs.GetDriver())
+
+		// 105 metrics; the last of the first page and the first of the second share a name
+		metrics := make([]map[string]any, 0, 105)
+		for i := range 105 {
+			name := fmt.Sprintf("Sales %03d", i)
+			if i == 100 {
+				name = "Sales 099"
+			}
+			metrics = append(metrics, map[string]any{"id": fmt.Sprintf("M%03d", i), "type": "Metric", "name": name})
+		}
+		tc.SeedMSTRGraph(metrics, nil)
+
+		handler := mstr.SearchMetricsHandler(tc.Deps)
+		args := map[string]any{"query": "sales", "scope": tc.MSTRProject()}
+
+		var first []mstr.SearchMetricsOutput
+		tc.ParseJSONResponse(tc.CallTool(handler, args), &first)
+		if len(first) != 1 || len(first[0].Results) != 100 || !first[0].MoreResults || first[0].NextCursor == "" {
+			t.Fatalf("expected a full first page with a cursor, got %+v", first)
+		}
+
+		// A metric sorted before the cursor shifts offset pages but not keyset pages
+		tc.SeedMSTRGraph([]map[string]any{{"id": "M000A", "type": "Metric", "name": "Sales 000A"}}, nil)
+
+		args["cursor"] = first[0].NextCursor
+		var second []mstr.SearchMetricsOutput
+		tc.ParseJSONResponse(tc.CallTool(handler, args), &second)
+		if len(second) != 1 || second[0].MoreResults || second[0].NextCursor != "" {
+			t.Fatalf("expected a last page without cursor, got %+v", second)
+		}
+
+		got := make([]string, 0, len(second[0].Results))
+		for _, r := range second[0].Results {
+			got = append(got, r.GUID)
+		}
+		want := []string{tc.MSTRGUID("M100"), tc.MSTRGUID("M101"), tc.MSTRGUID("M102"), tc.MSTRGUID("M103"), tc.MSTRGUID("M104")}
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Fatalf("expected second page %v, got %v", want, got)
+		}
+	})
+}
@@ -0,0 +1,88 @@
+//go:build integration
+
+package integration
+
+import (
+	"testing"
+
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools/mstr"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/test/integration/helpers"
+)
+
+// seedSemanticModel seeds a semantic model with two mapped metrics sharing a semantic name and
+// returns the model name
+func seedSemanticModel(tc *helpers.TestContext) string {
+	model := "Retail " + tc.TestID
+	tc.SeedMSTRGraph([]map[string]any{
+		{"id": "M1", "type": "Metric", "name": "Net Sales", "pb_semantic_model": model, "pb_semantic_name": "Sales"},
+		{"id": "M2", "type": "Metric", "name": "Gross Sales", "pb_semantic_model": model, "pb_semantic_name": "Sales"},
+		{"id": "M3", "type": "Metric", "name": "Margin"},
+		{"id": "A1", "type": "Attribute", "name": "Store"},
+		{"id": "P1", "type": "Prompt", "name": "Store Prompt"},
+		{"id": "R1", "type": "Report", "name": "Daily Sales", "priority_level": 1, "pb_semantic_model": model},
+		{"id": "R2", "type": "Report", "name": "Weekly Sales", "priority_level": 2, "pb_semantic_model": model},
+		{"id": "R3", "type": "Report", "name": "Unprioritized", "pb_semantic_model": model},
+	}, [][2]string{
+		{"R1", "M1"}, {"R1", "M3"}, {"R2", "M3"}, {"R2", "P1"}, {"P1", "A1"}, {"R3", "A1"},
+	})
+	return model
+}
+
+func TestSemanticModelCoverage(t *testing.T) {
+	t.Parallel()
+
+	t.Run("lists the coverage of a model", func(t *testing.T) {
+		tc := helpers.NewTestContext(t, dbs.GetDriver())
+		model := seedSemanticModel(tc)
+
+		res := tc.CallTool(mstr.SemanticModelCoverageHandler(tc.Deps), map[string]any{
+			"model": model,
+			"scope": tc.MSTRProject(),
+		})
+
+		var records []mstr.SemanticModelCoverageOutput
+		tc.ParseJSONResponse(res, &records)
+
+		if len(records) != 1 || len(records[0].Result.Models) != 1 {
+			t.Fatalf("expected one model, got %+v", records)
+		}
+		coverage := records[0].Result.Models[0]
+		if coverage.ReportCount != 2 || coverage.MappedMetrics != 2 || coverage.MappedAttributes != 0 {
+			t.Fatalf("expected 2 reports and 2 mapped metrics, got %+v", coverage)
+		}
+
+		// Margin is used by both prioritized reports, Store through the prompt of one of them
+		if len(coverage.Unmapped) != 2 {
+			t.Fatalf("expected 2 unmapped objects, got %+v", coverage.Unmapped)
+		}
+		if coverage.Unmapped[0].Name != "Margin" || coverage.Unmapped[0].UsedByReports != 2 {
+			t.Fatalf("expected Margin used by 2 reports first, got %+v", coverage.Unmapped[0])
+		}
+		if coverage.Unmapped[1].Name != "Store" || coverage.Unmapped[1].UsedByReports != 1 {
+			t.Fatalf("expected Store used by 1 report, got %+v", coverage.Unmapped[1])
+		}
+
+		if len(coverage.Duplicates) != 1 || coverage.Duplicates[0].SemanticName != "Sales" || len(coverage.Duplicates[0].Objects) != 2 {
+			t.Fatalf("expected Sales mapped twice, got %+v", coverage.Duplicates)
+		}
+	})
+
+	t.Run("summarizes the models with counts only", func(t *testing.T) {
+		tc := helpers.NewTestContext(t, dbs.GetDriver())
+		model := seedSemanticModel(tc)
+
+		var records []mstr.SemanticModelCoverageOutput
+		tc.ParseJSONResponse(tc.CallTool(mstr.SemanticModelCoverageHandler(tc.Deps), map[string]any{"scope": tc.MSTRProject()}), &records)
+
+		if len(records) != 1 || len(records[0].Result.Models) != 1 || records[0].Result.MoreResults {
+			t.Fatalf("expected one model, got %+v", records)
+		}
+		summary := records[0].Result.Models[0]
+		if summary.Model != model || summary.ReportCount != 2 || summary.MappedMetrics != 2 || summary.UnmappedCount != 2 || summary.DuplicateNameCount != 1 {
+			t.Fatalf("expected the counts of %s, got %+v", model, summary)
+		}
+		if summary.Mapped != nil || summary.Unmapped != nil || summary.Duplicates != nil {
+			t.Fatalf("expected no lists in summary mode, got %+v", summary)
+		}
+	})
+}
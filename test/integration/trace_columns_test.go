@@ -0,0 +1,78 @@
+//go:build integration
+
+package integration
+
+import (
+	"testing"
+
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools/mstr"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/test/integration/helpers"
+)
+
+// seedColumnLineage seeds two metrics reading the sales_amount column through a Fact,
+// one of them also reading the product_id column through an Attribute
+func seedColumnLineage(tc *helpers.TestContext) {
+	tc.SeedMSTRGraph([]map[string]any{
+		{"id": "M1", "type": "Metric", "name": "Net Sales"},
+		{"id": "M2", "type": "Metric", "name": "Gross Sales"},
+		{"id": "F1", "type": "Fact", "name": "Sales Amount", "expressions_json": `[{"expression": "Sum(sales_amount)", "tables": ["LU_SALES"]}]`},
+		{"id": "A1", "type": "Attribute", "name": "Product", "forms_json": `{"ID": "product_id"}`},
+		{"id": "T1", "type": "LogicalTable", "name": "LU_SALES", "physical_table_name": "dbo.fact_sales"},
+		{"id": "T2", "type": "LogicalTable", "name": "LU_PRODUCT", "physical_table_name": "dbo.dim_product"},
+	}, [][2]string{
+		{"M1", "F1"}, {"M1", "A1"}, {"M2", "F1"}, {"F1", "T1"}, {"A1", "T2"},
+	})
+}
+
+func TestTraceColumns(t *testing.T) {
+	t.Parallel()
+
+	t.Run("forward mode returns the columns read by a metric", func(t *testing.T) {
+		tc := helpers.NewTestContext(t, dbs.GetDriver())
+		seedColumnLineage(tc)
+
+		res := tc.CallTool(mstr.TraceColumnsHandler(tc.Deps), map[string]any{
+			"guid":  tc.MSTRGUID("M1"),
+			"scope": tc.MSTRProject(),
+		})
+
+		var response mstr.TraceColumnsOutput
+		tc.ParseJSONResponse(res, &response)
+
+		if response.Metric == nil || response.Metric.Name != "Net Sales" {
+			t.Fatalf("expected metric Net Sales, got %+v", response.Metric)
+		}
+		got := make([]string, 0, len(response.Columns))
+		for _, c := range response.Columns {
+			got = append(got, c.Table+"."+c.Column+"@"+c.Via.Name)
+		}
+		want := []string{"dbo.dim_product.product_id@Product", "dbo.fact_sales.sales_amount@Sales Amount"}
+		if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+			t.Fatalf("expected columns %v, got %v", want, got)
+		}
+	})
+
+	t.Run("reverse mode returns the metrics reading a column", func(t *testing.T) {
+		tc := helpers.NewTestContext(t, dbs.GetDriver())
+		seedColumnLineage(tc)
+
+		res := tc.CallTool(mstr.TraceColumnsHandler(tc.Deps), map[string]any{
+			"column": "SALES_AMOUNT",
+			"table":  "lu_sales",
+			"scope":  tc.MSTRProject(),
+		})
+
+		var response mstr.TraceColumnsOutput
+		tc.ParseJSONResponse(res, &response)
+
+		if response.MetricCount != 2 || len(response.Metrics) != 2 {
+			t.Fatalf("expected 2 metrics, got %+v", response.Metrics)
+		}
+		if response.Metrics[0].Name != "Gross Sales" || response.Metrics[1].Name != "Net Sales" {
+			t.Fatalf("expected Gross Sales and Net Sales, got %s and %s", response.Metrics[0].Name, response.Metrics[1].Name)
+		}
+		if len(response.Metrics[1].Reads) != 1 || response.Metrics[1].Reads[0].Via.GUID != tc.MSTRGUID("F1") {
+			t.Fatalf("expected Net Sales to read sales_amount through F1, got %+v", response.Metrics[1].Reads)
+		}
+	})
+}
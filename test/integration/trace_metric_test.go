@@ -0,0 +1,44 @@
+//go:build integration
+
+package integration
+
+import (
+	"testing"
+
+	"github.com/brunogc-cit/flow-microstrategy-mcp/internal/tools/mstr"
+	"github.com/brunogc-cit/flow-microstrategy-mcp/test/integration/helpers"
+)
+
+func TestTraceMetric(t *testing.T) {
+	t.Parallel()
+
+	t.Run("downstream keeps the tables without location in the default scope", func(t *testing.T) {
+		tc := helpers.NewTestContext(t, dbs.GetDriver())
+		tc.Deps.DefaultScope = tc.MSTRProject()
+
+		// Warehouse tables are often exported without a location
+		tc.SeedMSTRGraph([]map[string]any{
+			{"id": "M1", "type": "Metric", "name": "Net Sales"},
+			{"id": "F1", "type": "Fact", "name": "Sales Amount"},
+			{"id": "T1", "type": "LogicalTable", "name": "LU_SALES"},
+			{"id": "T2", "type": "Table", "name": "fact_sales", "location": nil},
+			{"id": "T3", "type": "Table", "name": "other_sales", "location": `\Other\Tables`},
+		}, [][2]string{
+			{"M1", "F1"}, {"F1", "T1"}, {"F1", "T2"}, {"F1", "T3"},
+		})
+
+		var records []mstr.TraceMetricOutput
+		tc.ParseJSONResponse(tc.CallTool(mstr.TraceMetricHandler(tc.Deps), map[string]any{
+			"guid":      tc.MSTRGUID("M1"),
+			"direction": "downstream",
+		}), &records)
+
+		if len(records) != 1 {
+			t.Fatalf("expected 1 record, got %d", len(records))
+		}
+		tables := records[0].Result.Tables
+		if len(tables) != 2 || tables[0].Name != "LU_SALES" || tables[1].Name != "fact_sales" {
+			t.Fatalf("expected LU_SALES and fact_sales, got %+v", tables)
+		}
+	})
+}